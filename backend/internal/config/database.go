@@ -69,11 +69,32 @@ func InitializeDatabase(db *gorm.DB, zapLogger *zap.Logger) error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Hash tokens stored before tokens were hashed at rest
+	if err := migrator.HashPlaintextTokens(); err != nil {
+		return fmt.Errorf("failed to hash stored tokens: %w", err)
+	}
+
 	// Auto-migrate GORM models to ensure schema is up to date
 	if err := db.AutoMigrate(
 		&models.Board{},
 		&models.Page{},
 		&models.Element{},
+		&models.ElementRevision{},
+		&models.BoardSnapshot{},
+		&models.PageTemplate{},
+		&models.BoardTemplate{},
+		&models.PageRecurrence{},
+		&models.Tag{},
+		&models.PageTag{},
+		&models.ElementTag{},
+		&models.Keyring{},
+		&models.KeyringBoard{},
+		&models.User{},
+		&models.Session{},
+		&models.BoardMember{},
+		&models.ShareLink{},
+		&models.BoardTokenRotation{},
+		&models.BoardUploadReference{},
 	); err != nil {
 		return fmt.Errorf("failed to auto-migrate models: %w", err)
 	}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegisterRequest represents the request to create an account
+type RegisterRequest struct {
+	Email       string `json:"email" validate:"required,email,max=255"`
+	Password    string `json:"password" validate:"required,min=8,max=72"`
+	DisplayName string `json:"display_name,omitempty" validate:"omitempty,max=100"`
+}
+
+// LoginRequest represents the request to sign in
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// SetBoardMemberRequest represents the request to grant an account a role on a board
+type SetBoardMemberRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"required,oneof=editor viewer"`
+}
+
+// UserResponse represents an account in API responses
+type UserResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"display_name,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SessionResponse represents a new sign-in. Clients send the session token in the
+// X-Session-Token header; it is only returned once.
+type SessionResponse struct {
+	User         UserResponse `json:"user"`
+	SessionToken string       `json:"session_token"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+}
+
+// MemberBoardResponse represents a board of the signed-in account with the account's role
+type MemberBoardResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Skin        string    `json:"skin"`
+	PublicToken uuid.UUID `json:"public_token"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	PageCount   int       `json:"pageCount"`
+}
+
+// BoardMemberResponse represents a member of a board
+type BoardMemberResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Email       string    `json:"email"`
+	DisplayName string    `json:"display_name,omitempty"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BoardMembersListResponse represents the response for listing a board's members
+type BoardMembersListResponse struct {
+	Members []BoardMemberResponse `json:"members"`
+	Total   int                   `json:"total"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ArchiveManifest describes the contents of a board export archive
+type ArchiveManifest struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Board      ArchiveBoard   `json:"board"`
+	Assets     []ArchiveAsset `json:"assets"`
+}
+
+// ArchiveBoard is the board content carried by an export archive. It holds no IDs, tokens or
+// sharing settings; imports always get fresh ones.
+type ArchiveBoard struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Skin        string        `json:"skin"`
+	Pages       []ArchivePage `json:"pages"`
+}
+
+// ArchivePage is a page of an exported board
+type ArchivePage struct {
+	Title    string           `json:"title"`
+	Date     time.Time        `json:"date"`
+	OrderIdx int              `json:"order_idx"`
+	Elements []ArchiveElement `json:"elements"`
+}
+
+// ArchiveElement is an element of an exported page
+type ArchiveElement struct {
+	Kind     string         `json:"kind"`
+	X        float64        `json:"x"`
+	Y        float64        `json:"y"`
+	W        float64        `json:"w"`
+	H        float64        `json:"h"`
+	Rotation float64        `json:"rotation"`
+	Z        int            `json:"z"`
+	Visible  bool           `json:"visible"`
+	Locked   bool           `json:"locked"`
+	Payload  datatypes.JSON `json:"payload"`
+}
+
+// ArchiveAsset represents an uploaded file bundled in an export archive
+type ArchiveAsset struct {
+	Path     string `json:"path"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+}
@@ -6,11 +6,16 @@ import (
 	"github.com/google/uuid"
 )
 
-// CreateBoardRequest represents the request to create a new board
+// CreateBoardRequest represents the request to create a new board. With a template_id the
+// board's pages are generated from a board template over the given month or dates.
 type CreateBoardRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=255"`
-	Description string `json:"description,omitempty" validate:"omitempty,max=500"`
-	Skin        string `json:"skin,omitempty" validate:"omitempty,oneof=default wood notebook cork"`
+	Title       string     `json:"title" validate:"required,min=1,max=255"`
+	Description string     `json:"description,omitempty" validate:"omitempty,max=500"`
+	Skin        string     `json:"skin,omitempty" validate:"omitempty,oneof=default wood notebook cork"`
+	TemplateID  *uuid.UUID `json:"template_id,omitempty"`
+	Month       string     `json:"month,omitempty" validate:"omitempty,datetime=2006-01"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	Days        *int       `json:"days,omitempty" validate:"omitempty,min=1,max=366"`
 }
 
 // UpdateBoardRequest represents the request to update a board
@@ -20,36 +25,65 @@ type UpdateBoardRequest struct {
 	Skin        *string `json:"skin,omitempty" validate:"omitempty,oneof=default wood notebook cork"`
 }
 
+// CloneBoardRequest represents the request to duplicate a board
+type CloneBoardRequest struct {
+	Title     *string `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	CopyFiles bool    `json:"copy_files"`
+}
+
+// ListBoardsRequest represents the query parameters for listing boards. From and To
+// filter on the creation date and are both inclusive.
+type ListBoardsRequest struct {
+	Limit       int    `query:"limit" validate:"omitempty,min=1,max=200"`
+	Cursor      string `query:"cursor"`
+	Sort        string `query:"sort" validate:"omitempty,oneof=created_at updated_at title"`
+	Order       string `query:"order" validate:"omitempty,oneof=asc desc"`
+	TitlePrefix string `query:"title_prefix" validate:"omitempty,max=255"`
+	From        string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To          string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
 // BoardResponse represents a board in API responses
 type BoardResponse struct {
-	ID          uuid.UUID      `json:"id"`
-	Title       string         `json:"title"`
-	Description string         `json:"description,omitempty"`
-	Skin        string         `json:"skin"`
-	PublicToken uuid.UUID      `json:"public_token"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	PageCount   int            `json:"pageCount"`
-	Pages       []PageResponse `json:"pages,omitempty"`
+	ID               uuid.UUID      `json:"id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description,omitempty"`
+	Skin             string         `json:"skin"`
+	PublicToken      uuid.UUID      `json:"public_token"`
+	PinnedSnapshotID *uuid.UUID     `json:"pinned_snapshot_id,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	PageCount        int            `json:"pageCount"`
+	Pages            []PageResponse `json:"pages,omitempty"`
 }
 
 // CreateBoardResponse represents the response when creating a board
 type CreateBoardResponse struct {
 	Board     BoardWithTokensResponse `json:"board"`
-	EditURL   string                  `json:"edit_url"`
+	EditURL   string                  `json:"edit_url,omitempty"`
 	PublicURL string                  `json:"public_url"`
 }
 
-// BoardWithTokensResponse represents a board with sensitive tokens (for edit access)
+// BoardWithTokensResponse represents a board with sensitive tokens (for edit access). Edit tokens
+// are stored hashed, so edit_token is only present when the client presented it or it was just issued.
 type BoardWithTokensResponse struct {
 	ID          uuid.UUID      `json:"id"`
 	Title       string         `json:"title"`
 	Description string         `json:"description,omitempty"`
 	Skin        string         `json:"skin"`
-	EditToken   uuid.UUID      `json:"edit_token"`
+	EditToken   *uuid.UUID     `json:"edit_token,omitempty"`
 	PublicToken uuid.UUID      `json:"public_token"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	PageCount   int            `json:"pageCount"`
 	Pages       []PageResponse `json:"pages,omitempty"`
+	// PassphraseProtected reports whether public readers must unlock the board with a passphrase
+	PassphraseProtected bool `json:"passphrase_protected"`
+}
+
+// BoardDirectoryRequest represents the request to list the boards a client owns. Ownership is
+// proven by presenting the boards' edit tokens, a keyring token, or both.
+type BoardDirectoryRequest struct {
+	EditTokens   []uuid.UUID `json:"edit_tokens,omitempty" validate:"omitempty,max=200"`
+	KeyringToken *uuid.UUID  `json:"keyring_token,omitempty"`
 }
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Board template page repeat modes; pages without one are created once
+const (
+	BoardTemplateRepeatDaily  = "daily"
+	BoardTemplateRepeatWeekly = "weekly"
+)
+
+// BoardTemplatePage describes one page, or a repeating run of pages, of a board template.
+// Titles may use the {date}, {weekday}, {month} and {day} placeholders.
+type BoardTemplatePage struct {
+	Title      string     `json:"title"`
+	Repeat     string     `json:"repeat,omitempty"`
+	DayOffset  int        `json:"day_offset,omitempty"`
+	TemplateID *uuid.UUID `json:"template_id,omitempty"`
+}
+
+// BoardTemplateResponse represents a board template in API responses
+type BoardTemplateResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Skin        string              `json:"skin"`
+	DatePattern string              `json:"date_pattern"`
+	DefaultDays int                 `json:"default_days"`
+	Pages       []BoardTemplatePage `json:"pages"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// BoardTemplatesListResponse represents the response for listing board templates
+type BoardTemplatesListResponse struct {
+	Templates []BoardTemplateResponse `json:"templates"`
+	Total     int                     `json:"total"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+)
+
+// SetBoardPassphraseRequest represents the request to protect public access with a passphrase
+type SetBoardPassphraseRequest struct {
+	Passphrase string `json:"passphrase" validate:"required,min=6,max=72"`
+}
+
+// UnlockBoardRequest represents the request to unlock a passphrase-protected board
+type UnlockBoardRequest struct {
+	Passphrase string `json:"passphrase" validate:"required,max=72"`
+}
+
+// UnlockBoardResponse represents the unlock token of a passphrase-protected board. It is also set
+// as a cookie; clients that do not send cookies pass it in the X-Board-Unlock header.
+type UnlockBoardResponse struct {
+	UnlockToken string    `json:"unlock_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
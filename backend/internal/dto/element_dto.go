@@ -28,6 +28,7 @@ type UpdateElementRequest struct {
 	Visible  *bool       `json:"visible,omitempty"`
 	Locked   *bool       `json:"locked,omitempty"`
 	Payload  interface{} `json:"payload,omitempty"`
+	Version  *int        `json:"version,omitempty" validate:"omitempty,min=1"`
 }
 
 // ReorderElementsRequest represents the request payload for batch z-index updates
@@ -41,6 +42,18 @@ type ElementZIndexUpdate struct {
 	Z  int       `json:"z" validate:"required,min=0"`
 }
 
+// ListElementsRequest represents the query parameters for listing a page's elements. From and To
+// filter on the creation date and are both inclusive.
+type ListElementsRequest struct {
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=200"`
+	Cursor string `query:"cursor"`
+	Sort   string `query:"sort" validate:"omitempty,oneof=z created_at updated_at"`
+	Order  string `query:"order" validate:"omitempty,oneof=asc desc"`
+	Kind   string `query:"kind" validate:"omitempty,oneof=text image sticker shape"`
+	From   string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To     string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
 // ElementResponse represents an element in the response
 type ElementResponse struct {
 	ID        uuid.UUID   `json:"id"`
@@ -52,6 +65,7 @@ type ElementResponse struct {
 	H         float64     `json:"h"`
 	Rotation  float64     `json:"rotation"`
 	Z         int         `json:"z"`
+	Version   int         `json:"version"`
 	Visible   bool        `json:"visible"`
 	Locked    bool        `json:"locked"`
 	Payload   interface{} `json:"payload"`
@@ -61,6 +75,7 @@ type ElementResponse struct {
 
 // ElementsListResponse represents the response for listing elements
 type ElementsListResponse struct {
-	Elements []ElementResponse `json:"elements"`
-	Total    int               `json:"total"`
+	Elements   []ElementResponse `json:"elements"`
+	Total      int               `json:"total"`
+	NextCursor *string           `json:"next_cursor"` // nil on the last page
 }
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// PageHistoryRequest represents the query parameters for listing a page's history
+type PageHistoryRequest struct {
+	ElementID string `query:"element_id" validate:"omitempty,uuid"`
+	Before    string `query:"before" validate:"omitempty"`
+	Limit     int    `query:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+// PageSnapshotRequest represents the query parameters for viewing a page at a point in time
+type PageSnapshotRequest struct {
+	At string `query:"at" validate:"required"`
+}
+
+// RestorePageRequest represents the request payload for restoring a page to a point in time
+type RestorePageRequest struct {
+	At time.Time `json:"at" validate:"required"`
+}
+
+// ElementRevisionResponse represents a recorded element mutation in API responses
+type ElementRevisionResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	ElementID uuid.UUID      `json:"element_id"`
+	PageID    uuid.UUID      `json:"page_id"`
+	Action    string         `json:"action"`
+	Before    datatypes.JSON `json:"before"`
+	After     datatypes.JSON `json:"after"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// PageHistoryResponse represents a page of element revisions, newest first
+type PageHistoryResponse struct {
+	Revisions  []ElementRevisionResponse `json:"revisions"`
+	Total      int                       `json:"total"`
+	NextBefore *time.Time                `json:"next_before,omitempty"`
+}
+
+// PageSnapshotResponse represents the elements of a page as they were at a point in time
+type PageSnapshotResponse struct {
+	PageID   uuid.UUID         `json:"page_id"`
+	At       time.Time         `json:"at"`
+	Elements []ElementResponse `json:"elements"`
+}
+
+// RestorePageResponse represents the result of restoring a page
+type RestorePageResponse struct {
+	PageID     uuid.UUID                 `json:"page_id"`
+	RestoredTo time.Time                 `json:"restored_to"`
+	Changes    []ElementRevisionResponse `json:"changes"`
+	Elements   []ElementResponse         `json:"elements"`
+}
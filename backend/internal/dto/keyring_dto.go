@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KeyringBoardsRequest represents the request to add boards to a keyring by their edit tokens
+type KeyringBoardsRequest struct {
+	EditTokens []uuid.UUID `json:"edit_tokens,omitempty" validate:"omitempty,max=200"`
+}
+
+// KeyringResponse represents a keyring in API responses. The token is only known to its owner.
+type KeyringResponse struct {
+	Token      uuid.UUID `json:"token"`
+	BoardCount int       `json:"board_count"`
+	CreatedAt  time.Time `json:"created_at"`
+}
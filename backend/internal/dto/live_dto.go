@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Live event types pushed to WebSocket clients
+const (
+	LiveEventWelcome           = "session.welcome"
+	LiveEventBoardRestored     = "board.restored"
+	LiveEventPageCreated       = "page.created"
+	LiveEventPageUpdated       = "page.updated"
+	LiveEventPageDeleted       = "page.deleted"
+	LiveEventElementCreated    = "element.created"
+	LiveEventElementUpdated    = "element.updated"
+	LiveEventElementDeleted    = "element.deleted"
+	LiveEventElementsReordered = "elements.reordered"
+	LiveEventPresenceJoined    = "presence.joined"
+	LiveEventPresenceUpdated   = "presence.updated"
+	LiveEventPresenceLeft      = "presence.left"
+)
+
+// Live message types sent by WebSocket clients
+const (
+	LiveMessagePresenceUpdate    = "presence.update"
+	LiveMessagePresenceHeartbeat = "presence.heartbeat"
+)
+
+// LiveEvent represents a message pushed to clients connected to a board's live channel
+type LiveEvent struct {
+	Type      string      `json:"type"`
+	BoardID   uuid.UUID   `json:"board_id"`
+	PageID    *uuid.UUID  `json:"page_id,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// LiveWelcomeData is sent to a client right after it joins a live channel
+type LiveWelcomeData struct {
+	ClientID uuid.UUID `json:"client_id"`
+	ReadOnly bool      `json:"read_only"`
+	Pinned   bool      `json:"pinned"` // the client sees the pinned snapshot and gets no board changes
+}
+
+// LiveClientMessage represents a message received from a live client
+type LiveClientMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
@@ -8,8 +8,9 @@ import (
 
 // CreatePageRequest represents the request payload for creating a page
 type CreatePageRequest struct {
-	Title string    `json:"title" validate:"required,min=1,max=255"`
-	Date  time.Time `json:"date" validate:"required"`
+	Title      string     `json:"title" validate:"required,min=1,max=255"`
+	Date       time.Time  `json:"date" validate:"required"`
+	TemplateID *uuid.UUID `json:"template_id,omitempty"`
 }
 
 // UpdatePageRequest represents the request payload for updating a page
@@ -17,6 +18,20 @@ type UpdatePageRequest struct {
 	Title    string    `json:"title" validate:"required,min=1,max=255"`
 	Date     time.Time `json:"date" validate:"required"`
 	OrderIdx *int      `json:"order_idx,omitempty" validate:"omitempty,min=0"`
+	Version  *int      `json:"version,omitempty" validate:"omitempty,min=1"`
+}
+
+// ListPagesRequest represents the query parameters for listing a board's pages. From and To
+// filter on the page date and are both inclusive; Tags keeps pages tagged with any of the names.
+type ListPagesRequest struct {
+	Limit       int    `query:"limit" validate:"omitempty,min=1,max=200"`
+	Cursor      string `query:"cursor"`
+	Sort        string `query:"sort" validate:"omitempty,oneof=order_idx date title created_at"`
+	Order       string `query:"order" validate:"omitempty,oneof=asc desc"`
+	TitlePrefix string `query:"title_prefix" validate:"omitempty,max=255"`
+	From        string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To          string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Tags        string `query:"tags"`
 }
 
 // PageResponse represents the response payload for a page
@@ -26,6 +41,7 @@ type PageResponse struct {
 	Title     string    `json:"title"`
 	Date      time.Time `json:"date"`
 	OrderIdx  int       `json:"order_idx"`
+	Version   int       `json:"version"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -37,6 +53,7 @@ type PageWithElementsResponse struct {
 	Title     string            `json:"title"`
 	Date      time.Time         `json:"date"`
 	OrderIdx  int               `json:"order_idx"`
+	Version   int               `json:"version"`
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
 	Elements  []ElementResponse `json:"elements"`
@@ -44,12 +61,14 @@ type PageWithElementsResponse struct {
 
 // PagesListResponse represents the response for listing pages
 type PagesListResponse struct {
-	Pages []PageResponse `json:"pages"`
-	Total int            `json:"total"`
+	Pages      []PageResponse `json:"pages"`
+	Total      int            `json:"total"`
+	NextCursor *string        `json:"next_cursor"` // nil on the last page
 }
 
 // PagesWithElementsListResponse represents the response for listing pages with elements
 type PagesWithElementsListResponse struct {
-	Pages []PageWithElementsResponse `json:"pages"`
-	Total int                        `json:"total"`
+	Pages      []PageWithElementsResponse `json:"pages"`
+	Total      int                        `json:"total"`
+	NextCursor *string                    `json:"next_cursor"` // nil on the last page
 }
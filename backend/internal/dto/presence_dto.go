@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PresenceCursor represents a cursor position in canvas coordinates
+type PresenceCursor struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// PresenceUpdateRequest represents a presence update sent by a live client
+type PresenceUpdateRequest struct {
+	PageID             *uuid.UUID      `json:"page_id,omitempty"`
+	DisplayName        *string         `json:"display_name,omitempty" validate:"omitempty,min=1,max=50"`
+	Color              *string         `json:"color,omitempty" validate:"omitempty,hexcolor"`
+	SelectedElementIDs []uuid.UUID     `json:"selected_element_ids,omitempty" validate:"omitempty,max=200"`
+	Cursor             *PresenceCursor `json:"cursor,omitempty"`
+}
+
+// PresenceSessionResponse represents a single connected session in the roster
+type PresenceSessionResponse struct {
+	ClientID           uuid.UUID       `json:"client_id"`
+	PageID             *uuid.UUID      `json:"page_id,omitempty"`
+	DisplayName        string          `json:"display_name"`
+	Color              string          `json:"color"`
+	SelectedElementIDs []uuid.UUID     `json:"selected_element_ids"`
+	Cursor             *PresenceCursor `json:"cursor,omitempty"`
+	ReadOnly           bool            `json:"read_only"`
+	JoinedAt           time.Time       `json:"joined_at"`
+	LastSeen           time.Time       `json:"last_seen"`
+}
+
+// PresenceRosterResponse represents the presence snapshot of a board
+type PresenceRosterResponse struct {
+	BoardID  uuid.UUID                 `json:"board_id"`
+	Count    int                       `json:"count"`
+	Sessions []PresenceSessionResponse `json:"sessions"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdatePublicLinkRequest represents the request to limit a board's public link. Omitted limits
+// are removed; reset_views starts the view count over.
+type UpdatePublicLinkRequest struct {
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	MaxViews   *int       `json:"max_views,omitempty" validate:"omitempty,min=1,max=1000000"`
+	ResetViews bool       `json:"reset_views,omitempty"`
+}
+
+// PublicLinkResponse represents the limits and view count of a board's public link
+type PublicLinkResponse struct {
+	BoardID      uuid.UUID  `json:"board_id"`
+	PublicToken  uuid.UUID  `json:"public_token"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	MaxViews     *int       `json:"max_views,omitempty"`
+	ViewCount    int        `json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	Expired      bool       `json:"expired"`
+}
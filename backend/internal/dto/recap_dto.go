@@ -10,6 +10,7 @@ import (
 type RecapRequest struct {
 	Filter string `query:"filter" validate:"omitempty,oneof=day week month"`
 	Date   string `query:"date" validate:"omitempty"`
+	Tags   string `query:"tags" validate:"omitempty,max=500"`
 }
 
 // RecapPageMetadata represents page metadata in recap response
@@ -30,6 +31,7 @@ type RecapResponse struct {
 	PageCount    int                 `json:"page_count"`
 	ElementCount int                 `json:"element_count"`
 	Pages        []RecapPageMetadata `json:"pages"`
+	Tags         []TagCount          `json:"tags"`
 }
 
 // RecapDateRange represents the date range for the recap
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetRecurrenceRequest represents the request payload for creating or replacing a board's
+// recurring page rule. TitlePattern is a Go time layout such as "Monday, Jan 2".
+type SetRecurrenceRequest struct {
+	Frequency    string     `json:"frequency" validate:"required,oneof=daily weekly monthly"`
+	Interval     *int       `json:"interval,omitempty" validate:"omitempty,min=1,max=365"`
+	TitlePattern string     `json:"title_pattern" validate:"required,min=1,max=255"`
+	StartDate    *time.Time `json:"start_date,omitempty"`
+	Timezone     string     `json:"timezone,omitempty" validate:"omitempty,max=64"`
+	TemplateID   *uuid.UUID `json:"template_id,omitempty"`
+	Enabled      *bool      `json:"enabled,omitempty"`
+}
+
+// RecurrenceResponse represents a board's recurring page rule in API responses
+type RecurrenceResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	BoardID            uuid.UUID  `json:"board_id"`
+	Frequency          string     `json:"frequency"`
+	Interval           int        `json:"interval"`
+	TitlePattern       string     `json:"title_pattern"`
+	StartDate          time.Time  `json:"start_date"`
+	Timezone           string     `json:"timezone"`
+	TemplateID         *uuid.UUID `json:"template_id,omitempty"`
+	Enabled            bool       `json:"enabled"`
+	NextDate           time.Time  `json:"next_date"`
+	LastOccurrenceDate *time.Time `json:"last_occurrence_date,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
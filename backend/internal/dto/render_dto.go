@@ -0,0 +1,7 @@
+package dto
+
+// RenderPageRequest represents the query parameters for rendering a page
+type RenderPageRequest struct {
+	Format string  `query:"format" validate:"omitempty,oneof=png pdf"`
+	Scale  float64 `query:"scale" validate:"omitempty,gt=0,lte=4"`
+}
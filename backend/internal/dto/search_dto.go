@@ -0,0 +1,34 @@
+package dto
+
+import "github.com/google/uuid"
+
+// Search hit kinds
+const (
+	SearchHitBoard   = "board"
+	SearchHitPage    = "page"
+	SearchHitElement = "element"
+)
+
+// SearchRequest represents the query parameters for searching a board
+type SearchRequest struct {
+	Q     string `query:"q" validate:"required,min=1,max=200"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// SearchHit represents a single ranked search match. Snippet is HTML-escaped text
+// with the matched terms wrapped in <mark> tags.
+type SearchHit struct {
+	Kind      string     `json:"kind"`
+	Field     string     `json:"field"`
+	PageID    *uuid.UUID `json:"page_id,omitempty"`
+	ElementID *uuid.UUID `json:"element_id,omitempty"`
+	Rank      float64    `json:"rank"`
+	Snippet   string     `json:"snippet"`
+}
+
+// SearchResponse represents the response for a board search
+type SearchResponse struct {
+	Query string      `json:"query"`
+	Hits  []SearchHit `json:"hits"`
+	Total int         `json:"total"`
+}
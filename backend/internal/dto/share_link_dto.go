@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateShareLinkRequest represents the request to create a share link. A page_id limits the
+// link to that page; expires_at is optional.
+type CreateShareLinkRequest struct {
+	Role      string     `json:"role" validate:"required,oneof=viewer commenter editor"`
+	PageID    *uuid.UUID `json:"page_id,omitempty"`
+	Label     string     `json:"label,omitempty" validate:"omitempty,max=100"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ShareLinkResponse represents a share link in API responses. Share link tokens are stored
+// hashed, so token is only present right after the link was created.
+type ShareLinkResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Token     *uuid.UUID `json:"token,omitempty"`
+	Role      string     `json:"role"`
+	PageID    *uuid.UUID `json:"page_id,omitempty"`
+	Label     string     `json:"label,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Expired   bool       `json:"expired"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ShareLinksListResponse represents the response for listing a board's share links
+type ShareLinksListResponse struct {
+	ShareLinks []ShareLinkResponse `json:"share_links"`
+	Total      int                 `json:"total"`
+}
+
+// SharedBoardResponse represents a board opened through a share link. It leaves out the
+// board's own tokens so revoking the link cuts access, and page-scoped links only see their page.
+type SharedBoardResponse struct {
+	ID          uuid.UUID      `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Skin        string         `json:"skin"`
+	Role        string         `json:"role"`
+	PageID      *uuid.UUID     `json:"page_id,omitempty"`
+	Label       string         `json:"label,omitempty"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	PageCount   int            `json:"pageCount"`
+	Pages       []PageResponse `json:"pages"`
+}
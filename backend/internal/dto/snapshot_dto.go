@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Snapshot restore modes
+const (
+	SnapshotRestoreInPlace  = "in_place"
+	SnapshotRestoreNewBoard = "new_board"
+)
+
+// CreateSnapshotRequest represents the request payload for creating a board snapshot
+type CreateSnapshotRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+// RestoreSnapshotRequest represents the request payload for restoring a snapshot
+type RestoreSnapshotRequest struct {
+	Mode string `json:"mode" validate:"omitempty,oneof=in_place new_board"`
+}
+
+// PinSnapshotRequest represents the request payload for pinning a snapshot; null unpins
+type PinSnapshotRequest struct {
+	SnapshotID *uuid.UUID `json:"snapshot_id"`
+}
+
+// SnapshotDiffRequest represents the query parameters for diffing a snapshot
+type SnapshotDiffRequest struct {
+	Against string `query:"against" validate:"omitempty"`
+}
+
+// SnapshotResponse represents snapshot metadata in API responses
+type SnapshotResponse struct {
+	ID           uuid.UUID `json:"id"`
+	BoardID      uuid.UUID `json:"board_id"`
+	Name         string    `json:"name"`
+	PageCount    int       `json:"page_count"`
+	ElementCount int       `json:"element_count"`
+	Pinned       bool      `json:"pinned"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SnapshotsListResponse represents the response for listing a board's snapshots
+type SnapshotsListResponse struct {
+	Snapshots []SnapshotResponse `json:"snapshots"`
+	Total     int                `json:"total"`
+}
+
+// SnapshotDetailResponse represents a snapshot together with the board it captured
+type SnapshotDetailResponse struct {
+	Snapshot SnapshotResponse `json:"snapshot"`
+	Board    models.Board     `json:"board"`
+}
+
+// PageDiffEntry represents a page that differs between two board versions
+type PageDiffEntry struct {
+	ID      uuid.UUID `json:"id"`
+	Title   string    `json:"title"`
+	Changes []string  `json:"changes,omitempty"`
+}
+
+// ElementDiffEntry represents an element that differs between two board versions
+type ElementDiffEntry struct {
+	ID      uuid.UUID `json:"id"`
+	PageID  uuid.UUID `json:"page_id"`
+	Kind    string    `json:"kind"`
+	Changes []string  `json:"changes,omitempty"`
+}
+
+// SnapshotDiffResponse represents the differences between a snapshot and another board version
+type SnapshotDiffResponse struct {
+	From            string             `json:"from"`
+	To              string             `json:"to"`
+	BoardChanges    []string           `json:"board_changes"`
+	PagesAdded      []PageDiffEntry    `json:"pages_added"`
+	PagesRemoved    []PageDiffEntry    `json:"pages_removed"`
+	PagesChanged    []PageDiffEntry    `json:"pages_changed"`
+	ElementsAdded   []ElementDiffEntry `json:"elements_added"`
+	ElementsRemoved []ElementDiffEntry `json:"elements_removed"`
+	ElementsChanged []ElementDiffEntry `json:"elements_changed"`
+}
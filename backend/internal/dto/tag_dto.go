@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateTagRequest represents the request payload for creating a tag
+type CreateTagRequest struct {
+	Name  string `json:"name" validate:"required,min=1,max=50"`
+	Color string `json:"color,omitempty" validate:"omitempty,hexcolor"`
+}
+
+// UpdateTagRequest represents the request payload for updating a tag
+type UpdateTagRequest struct {
+	Name  *string `json:"name,omitempty" validate:"omitempty,min=1,max=50"`
+	Color *string `json:"color,omitempty" validate:"omitempty,hexcolor"`
+}
+
+// TagResponse represents a tag with its usage counts in API responses
+type TagResponse struct {
+	ID           uuid.UUID `json:"id"`
+	BoardID      uuid.UUID `json:"board_id"`
+	Name         string    `json:"name"`
+	Color        string    `json:"color"`
+	PageCount    int       `json:"page_count"`
+	ElementCount int       `json:"element_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TagDetailResponse represents a tag with the pages and elements it is attached to
+type TagDetailResponse struct {
+	Tag        TagResponse `json:"tag"`
+	PageIDs    []uuid.UUID `json:"page_ids"`
+	ElementIDs []uuid.UUID `json:"element_ids"`
+}
+
+// TagsListResponse represents the response for listing tags
+type TagsListResponse struct {
+	Tags  []TagResponse `json:"tags"`
+	Total int           `json:"total"`
+}
+
+// TagCount represents how often a tag is used, as reported in recaps
+type TagCount struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Color        string    `json:"color"`
+	PageCount    int       `json:"page_count"`
+	ElementCount int       `json:"element_count"`
+}
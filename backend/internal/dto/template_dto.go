@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// Page template scopes
+const (
+	TemplateScopeBoard  = "board"
+	TemplateScopeGlobal = "global"
+)
+
+// CreateTemplateRequest represents the request payload for saving a page as a template
+type CreateTemplateRequest struct {
+	PageID      uuid.UUID `json:"page_id" validate:"required"`
+	Name        string    `json:"name" validate:"required,min=1,max=255"`
+	Description string    `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Scope       string    `json:"scope,omitempty" validate:"omitempty,oneof=board global"`
+}
+
+// TemplateElement is an element layout stored in a page template
+type TemplateElement struct {
+	Kind     string         `json:"kind"`
+	X        float64        `json:"x"`
+	Y        float64        `json:"y"`
+	W        float64        `json:"w"`
+	H        float64        `json:"h"`
+	Rotation float64        `json:"rotation"`
+	Z        int            `json:"z"`
+	Visible  bool           `json:"visible"`
+	Locked   bool           `json:"locked"`
+	Payload  datatypes.JSON `json:"payload"`
+}
+
+// TemplateResponse represents page template metadata in API responses
+type TemplateResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	BoardID      *uuid.UUID `json:"board_id,omitempty"`
+	Scope        string     `json:"scope"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	ElementCount int        `json:"element_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TemplatesListResponse represents the response for listing page templates
+type TemplatesListResponse struct {
+	Templates []TemplateResponse `json:"templates"`
+	Total     int                `json:"total"`
+}
+
+// TemplateDetailResponse represents a page template with its element layout
+type TemplateDetailResponse struct {
+	Template TemplateResponse  `json:"template"`
+	Elements []TemplateElement `json:"elements"`
+}
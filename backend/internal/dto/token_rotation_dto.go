@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RotateBoardTokenRequest represents the request to replace a board's edit or public token.
+// The old token stops working at once unless a grace period (at most 7 days) is given.
+type RotateBoardTokenRequest struct {
+	Kind               string `json:"kind" validate:"required,oneof=edit public"`
+	GracePeriodSeconds int    `json:"grace_period_seconds,omitempty" validate:"min=0,max=604800"`
+	Reason             string `json:"reason,omitempty" validate:"omitempty,max=255"`
+}
+
+// RotateBoardTokenResponse represents the board with its new tokens and the recorded rotation
+type RotateBoardTokenResponse struct {
+	CreateBoardResponse
+	Rotation TokenRotationResponse `json:"rotation"`
+}
+
+// TokenRotationResponse represents an audit trail entry of a board token rotation
+type TokenRotationResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Kind          string     `json:"kind"`
+	GraceUntil    *time.Time `json:"grace_until,omitempty"`
+	InGracePeriod bool       `json:"in_grace_period"`
+	Reason        string     `json:"reason,omitempty"`
+	RotatedBy     *uuid.UUID `json:"rotated_by,omitempty"`
+	ClientIP      string     `json:"client_ip,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// TokenRotationsListResponse represents the response for listing a board's token rotations
+type TokenRotationsListResponse struct {
+	Rotations []TokenRotationResponse `json:"rotations"`
+	Total     int                     `json:"total"`
+}
+
+// EndGracePeriodsResponse represents the response when retired tokens are revoked early
+type EndGracePeriodsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+)
+
+// TrashedBoardResponse represents a board in the trash
+type TrashedBoardResponse struct {
+	Board     BoardResponse `json:"board"`
+	DeletedAt time.Time     `json:"deleted_at"`
+	PurgeAt   time.Time     `json:"purge_at"`
+}
+
+// TrashedPageResponse represents a page in the trash
+type TrashedPageResponse struct {
+	Page      PageResponse `json:"page"`
+	DeletedAt time.Time    `json:"deleted_at"`
+	PurgeAt   time.Time    `json:"purge_at"`
+}
+
+// TrashedElementResponse represents an element in the trash
+type TrashedElementResponse struct {
+	Element   ElementResponse `json:"element"`
+	DeletedAt time.Time       `json:"deleted_at"`
+	PurgeAt   time.Time       `json:"purge_at"`
+}
+
+// TrashResponse represents the trashed items of a board
+type TrashResponse struct {
+	Board    *TrashedBoardResponse    `json:"board,omitempty"`
+	Pages    []TrashedPageResponse    `json:"pages"`
+	Elements []TrashedElementResponse `json:"elements"`
+}
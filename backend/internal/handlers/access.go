@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// authorizeBoardEdit checks that a request may modify a board. Signed-in owners and editors
+// are authorized by their role; everyone else needs the board's edit token or an editor share link.
+func authorizeBoardEdit(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID) error {
+	if ok, err := hasMemberRole(c, boardService, boardID, models.BoardRoleEditor); ok || err != nil {
+		return err
+	}
+
+	editToken, _ := c.Locals("edit_token").(uuid.UUID)
+	return boardService.ValidateBoardEditAccess(boardID, editToken)
+}
+
+// authorizePageEdit checks that a request may modify a page. On top of authorizeBoardEdit it
+// accepts editor share links scoped to that page.
+func authorizePageEdit(c *fiber.Ctx, boardService *services.BoardService, boardID, pageID uuid.UUID) error {
+	if ok, err := hasMemberRole(c, boardService, boardID, models.BoardRoleEditor); ok || err != nil {
+		return err
+	}
+
+	editToken, _ := c.Locals("edit_token").(uuid.UUID)
+	return boardService.ValidatePageEditAccess(boardID, pageID, editToken)
+}
+
+// authorizeBoardRead checks that a request carries a valid board token or comes from a signed-in
+// member of the board, for endpoints that do not serve anonymous readers
+func authorizeBoardRead(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID) error {
+	if ok, err := hasMemberRole(c, boardService, boardID, models.BoardRoleViewer); ok || err != nil {
+		return err
+	}
+
+	token, ok := c.Locals("token").(uuid.UUID)
+	if !ok {
+		// Tell missing boards apart from missing credentials
+		if err := boardService.ValidateBoardExists(boardID); err != nil {
+			return err
+		}
+		return utils.ErrUnauthorized
+	}
+
+	return boardService.ValidateBoardAccess(boardID, token)
+}
+
+// isBoardEditor reports whether a request may edit the board, with a valid edit token or as a
+// signed-in owner or editor. Such readers see the live board rather than a pinned snapshot.
+// The token is validated since header tokens are offered as edit tokens whatever their kind.
+func isBoardEditor(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID) bool {
+	return authorizeBoardEdit(c, boardService, boardID) == nil
+}
+
+// hasMemberRole reports whether the request is signed in as a member holding at least minRole
+func hasMemberRole(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID, minRole string) (bool, error) {
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	if !ok {
+		return false, nil
+	}
+
+	err := boardService.ValidateMemberAccess(boardID, userID, minRole)
+	if err == utils.ErrUnauthorized || err == utils.ErrForbidden {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// ensureBoardUnlocked checks that a reader of a passphrase-protected board has unlocked it.
+// Editors, signed-in members and share links are not asked for the passphrase.
+// When ok is false the error response has already been sent.
+func ensureBoardUnlocked(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID) (ok bool, err error) {
+	return checkPublicAccess(c, boardService, boardID, false)
+}
+
+// ensurePublicReadAllowed checks the public access of a board for readers other than editors,
+// signed-in members and share links: the public link must not have expired, a view-limited link
+// needs the view grant handed out when the board was opened, and a protected board must be unlocked.
+// When ok is false the error response has already been sent.
+func ensurePublicReadAllowed(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID) (ok bool, err error) {
+	return checkPublicAccess(c, boardService, boardID, true)
+}
+
+// checkPublicAccess checks the passphrase of a board and, with checkLimits, the limits of its public link
+func checkPublicAccess(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID, checkLimits bool) (ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	board, err := boardService.GetPublicAccess(boardID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to get board public access", "error", err)
+		return false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	now := time.Now()
+	protected := board.PublicPassphraseHash != "" &&
+		!services.VerifyUnlockToken(boardID, board.PublicPassphraseHash, unlockTokenFromRequest(c, boardID), now)
+	expired := checkLimits && board.PublicExpiresAt != nil && !now.Before(*board.PublicExpiresAt)
+	needsView := checkLimits && board.PublicMaxViews != nil &&
+		!services.VerifyViewGrant(boardID, viewGrantFromRequest(c, boardID), now)
+	if !protected && !expired && !needsView {
+		return true, nil
+	}
+
+	if isPrivilegedReader(c, boardService, boardID) {
+		return true, nil
+	}
+
+	switch {
+	case expired, needsView && board.PublicViewCount >= *board.PublicMaxViews:
+		return false, sendLinkExpired(c)
+	case needsView:
+		return false, utils.SendError(c, fiber.StatusUnauthorized, utils.ErrCodeViewRequired,
+			"Open the board through its public link first", fiber.Map{"board_id": boardID})
+	default:
+		return false, utils.SendError(c, fiber.StatusUnauthorized, utils.ErrCodePassphraseRequired,
+			"This board is protected by a passphrase", fiber.Map{"board_id": boardID})
+	}
+}
+
+// isPrivilegedReader reports whether a request reads a board as a signed-in member, through a
+// share link or as an editor, which the limits of public access do not apply to
+func isPrivilegedReader(c *fiber.Ctx, boardService *services.BoardService, boardID uuid.UUID) bool {
+	if isMember, _ := hasMemberRole(c, boardService, boardID, models.BoardRoleViewer); isMember {
+		return true
+	}
+	if token, found := c.Locals("token").(uuid.UUID); found {
+		if isLink, _ := boardService.HasActiveShareLink(boardID, token); isLink {
+			return true
+		}
+	}
+	return isBoardEditor(c, boardService, boardID)
+}
+
+// sendLinkExpired tells a reader that the board's public link has expired or used up its views
+func sendLinkExpired(c *fiber.Ctx) error {
+	return utils.SendError(c, fiber.StatusGone, utils.ErrCodeLinkExpired,
+		"This link has expired or reached its view limit", nil)
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AccountHandler struct {
+	accountService *services.AccountService
+	boardService   *services.BoardService
+}
+
+func NewAccountHandler(db *gorm.DB) *AccountHandler {
+	return &AccountHandler{
+		accountService: services.NewAccountService(db),
+		boardService:   services.NewBoardService(db),
+	}
+}
+
+// Register creates an account and signs it in
+// POST /api/v1/auth/register
+func (h *AccountHandler) Register(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse request body
+	var req dto.RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	user, token, session, err := h.accountService.Register(req.Email, req.Password, req.DisplayName)
+	if err != nil {
+		if err == services.ErrEmailTaken {
+			return utils.SendConflict(c, "Email is already registered", nil)
+		}
+		logger.Errorw("Failed to register account", "error", err)
+		return utils.SendInternalError(c, "Failed to register account", nil)
+	}
+
+	return c.Status(201).JSON(fiber.Map{"data": convertToSessionResponse(user, token, session)})
+}
+
+// Login signs an account in
+// POST /api/v1/auth/login
+func (h *AccountHandler) Login(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse request body
+	var req dto.LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	user, token, session, err := h.accountService.Login(req.Email, req.Password)
+	if err != nil {
+		if err == services.ErrInvalidCredentials {
+			return utils.SendUnauthorizedError(c, "Invalid email or password")
+		}
+		logger.Errorw("Failed to sign in", "error", err)
+		return utils.SendInternalError(c, "Failed to sign in", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": convertToSessionResponse(user, token, session)})
+}
+
+// Logout ends the current session
+// POST /api/v1/auth/logout
+func (h *AccountHandler) Logout(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	token, _ := c.Locals("session_token").(string)
+	if err := h.accountService.Logout(token); err != nil {
+		logger.Errorw("Failed to sign out", "error", err)
+		return utils.SendInternalError(c, "Failed to sign out", nil)
+	}
+
+	return c.Status(204).Send(nil)
+}
+
+// GetMe retrieves the signed-in account
+// GET /api/v1/auth/me
+func (h *AccountHandler) GetMe(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	user, err := h.accountService.GetUser(c.Locals("user_id").(uuid.UUID))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendUnauthorizedError(c, "Account no longer exists")
+		}
+		logger.Errorw("Failed to get account", "error", err)
+		return utils.SendInternalError(c, "Failed to get account", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": convertToUserResponse(user)})
+}
+
+// GetMyBoards lists the boards the signed-in account is a member of. It accepts the
+// pagination and filter query parameters of the board listing.
+// GET /api/v1/me/boards
+func (h *AccountHandler) GetMyBoards(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse query parameters
+	var req dto.ListBoardsRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	filter, err := newListFilter(req.TitlePrefix, req.From, req.To)
+	if err != nil {
+		return utils.SendValidationError(c, "Invalid date range", nil)
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+	boards, nextCursor, err := h.accountService.ListMemberBoards(userID, filter, newListParams(req.Limit, req.Cursor, req.Sort, req.Order))
+	if err != nil {
+		if err == services.ErrInvalidCursor {
+			return utils.SendValidationError(c, "Invalid cursor", nil)
+		}
+		logger.Errorw("Failed to get boards", "error", err)
+		return utils.SendInternalError(c, "Failed to get boards", nil)
+	}
+
+	response := make([]dto.MemberBoardResponse, len(boards))
+	for i, board := range boards {
+		response[i] = dto.MemberBoardResponse{
+			ID:          board.ID,
+			Title:       board.Title,
+			Description: board.Description,
+			Skin:        board.Skin,
+			PublicToken: board.PublicToken,
+			Role:        board.Role,
+			CreatedAt:   board.CreatedAt,
+			UpdatedAt:   board.UpdatedAt,
+			PageCount:   board.PageCount,
+		}
+	}
+
+	return c.JSON(fiber.Map{"data": response, "next_cursor": nextCursorValue(nextCursor)})
+}
+
+// ClaimBoard makes the signed-in account the owner of a board; the edit token is required
+// POST /api/v1/boards/:boardId/claim
+func (h *AccountHandler) ClaimBoard(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Membership is not enough to claim a board, the edit token proves ownership
+	editToken, ok := c.Locals("edit_token").(uuid.UUID)
+	if !ok {
+		return utils.SendUnauthorized(c, "Edit token is required to claim a board")
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+	if err := h.accountService.ClaimBoard(userID, boardID, editToken); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		if err == services.ErrBoardAlreadyClaimed {
+			return utils.SendConflict(c, "Board is already claimed by another account", nil)
+		}
+		logger.Errorw("Failed to claim board", "error", err)
+		return utils.SendInternalError(c, "Failed to claim board", nil)
+	}
+
+	logger.Infow("Board claimed", "boardId", boardID, "userId", userID)
+	return h.GetMembers(c)
+}
+
+// GetMembers lists the members of a board; only its owner may see them
+// GET /api/v1/boards/:boardId/members
+func (h *AccountHandler) GetMembers(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateOwnerAccess(c)
+	if !ok {
+		return err
+	}
+
+	members, err := h.accountService.ListMembers(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get board members", "error", err)
+		return utils.SendInternalError(c, "Failed to get board members", nil)
+	}
+
+	memberResponses := make([]dto.BoardMemberResponse, len(members))
+	for i := range members {
+		memberResponses[i] = convertToBoardMemberResponse(&members[i])
+	}
+
+	response := dto.BoardMembersListResponse{
+		Members: memberResponses,
+		Total:   len(memberResponses),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// SetMember grants an account an editor or viewer role on a board
+// PUT /api/v1/boards/:boardId/members
+func (h *AccountHandler) SetMember(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateOwnerAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.SetBoardMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	member, err := h.accountService.SetMember(boardID, req.Email, req.Role)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Account not found")
+		}
+		if err == services.ErrCannotChangeOwner {
+			return utils.SendConflict(c, "The board owner's role cannot be changed", nil)
+		}
+		logger.Errorw("Failed to set board member", "error", err)
+		return utils.SendInternalError(c, "Failed to set board member", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": convertToBoardMemberResponse(member)})
+}
+
+// RemoveMember revokes an account's role on a board
+// DELETE /api/v1/boards/:boardId/members/:userId
+func (h *AccountHandler) RemoveMember(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateOwnerAccess(c)
+	if !ok {
+		return err
+	}
+
+	userIDStr := c.Params("userId")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		logger.Warnw("Invalid user ID", "userId", userIDStr)
+		return utils.SendValidationError(c, "Invalid user ID format", nil)
+	}
+
+	if err := h.accountService.RemoveMember(boardID, userID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Member not found")
+		}
+		if err == services.ErrCannotChangeOwner {
+			return utils.SendConflict(c, "The board owner cannot be removed", nil)
+		}
+		logger.Errorw("Failed to remove board member", "error", err)
+		return utils.SendInternalError(c, "Failed to remove board member", nil)
+	}
+
+	return c.Status(204).Send(nil)
+}
+
+// validateOwnerAccess parses the board ID and checks that the signed-in account owns the board.
+// When ok is false the error response has already been sent.
+func (h *AccountHandler) validateOwnerAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	userID := c.Locals("user_id").(uuid.UUID)
+	if err := h.boardService.ValidateMemberAccess(boardID, userID, models.BoardRoleOwner); err != nil {
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrForbidden {
+			return uuid.Nil, false, utils.SendForbidden(c, "Only the board owner can manage members")
+		}
+		logger.Errorw("Failed to validate board membership", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// convertToUserResponse converts a user model to response DTO
+func convertToUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:          user.ID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		CreatedAt:   user.CreatedAt,
+	}
+}
+
+// convertToSessionResponse converts a new session to response DTO
+func convertToSessionResponse(user *models.User, token string, session *models.Session) dto.SessionResponse {
+	return dto.SessionResponse{
+		User:         convertToUserResponse(user),
+		SessionToken: token,
+		ExpiresAt:    session.ExpiresAt,
+	}
+}
+
+// convertToBoardMemberResponse converts a board member to response DTO
+func convertToBoardMemberResponse(member *services.BoardMemberUser) dto.BoardMemberResponse {
+	return dto.BoardMemberResponse{
+		UserID:      member.UserID,
+		Email:       member.Email,
+		DisplayName: member.DisplayName,
+		Role:        member.Role,
+		CreatedAt:   member.CreatedAt,
+	}
+}
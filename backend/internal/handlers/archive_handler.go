@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ArchiveHandler struct {
+	archiveService *services.ArchiveService
+	boardService   *services.BoardService
+}
+
+func NewArchiveHandler(db *gorm.DB) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveService: services.NewArchiveService(db),
+		boardService:   services.NewBoardService(db),
+	}
+}
+
+// ExportBoard streams a zip archive with the board manifest and uploaded files
+// GET /api/v1/boards/:boardId/export
+func (h *ArchiveHandler) ExportBoard(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate edit token and board existence
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	// Build the manifest before streaming so failures can still be reported
+	archive, err := h.archiveService.PrepareExport(boardID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to prepare board export", "error", err)
+		return utils.SendInternalError(c, "Failed to export board", nil)
+	}
+
+	logger.Infow("Board export started", "boardId", boardID, "assetCount", len(archive.Manifest.Assets))
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"board-%s.zip\"", boardID))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.archiveService.WriteArchive(archive, w); err != nil {
+			logger.Errorw("Failed to stream board export", "boardId", boardID, "error", err)
+		}
+		if err := w.Flush(); err != nil {
+			logger.Errorw("Failed to flush board export", "boardId", boardID, "error", err)
+		}
+	})
+
+	return nil
+}
+
+// ImportBoard creates a new board from an uploaded export archive
+// POST /api/v1/boards/import
+func (h *ArchiveHandler) ImportBoard(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Get the uploaded archive
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		logger.Warnw("No file in import request", "error", err)
+		return utils.SendBadRequestError(c, "No file provided")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Errorw("Failed to open uploaded archive", "error", err)
+		return utils.SendInternalError(c, "Failed to read archive", nil)
+	}
+	defer file.Close()
+
+	// Import board
+	board, err := h.archiveService.ImportBoard(file, fileHeader.Size)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidArchive) {
+			logger.Warnw("Rejected board archive", "error", err)
+			return utils.SendValidationError(c, err.Error(), nil)
+		}
+		logger.Errorw("Failed to import board", "error", err)
+		return utils.SendDatabaseError(c, "Failed to import board")
+	}
+
+	// Convert to response DTO (include edit token like board creation)
+	response := convertToCreateBoardResponse(board)
+
+	logger.Infow("Board imported successfully", "boardId", board.ID, "pageCount", len(board.Pages))
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -16,14 +17,22 @@ import (
 )
 
 type BoardHandler struct {
-	boardService *services.BoardService
-	validator    *validator.Validate
+	boardService         *services.BoardService
+	snapshotService      *services.SnapshotService
+	boardTemplateService *services.BoardTemplateService
+	keyringService       *services.KeyringService
+	publicLinkService    *services.PublicLinkService
+	validator            *validator.Validate
 }
 
 func NewBoardHandler(db *gorm.DB) *BoardHandler {
 	return &BoardHandler{
-		boardService: services.NewBoardService(db),
-		validator:    validator.New(),
+		boardService:         services.NewBoardService(db),
+		snapshotService:      services.NewSnapshotService(db),
+		boardTemplateService: services.NewBoardTemplateService(db),
+		keyringService:       services.NewKeyringService(db),
+		publicLinkService:    services.NewPublicLinkService(db),
+		validator:            validator.New(),
 	}
 }
 
@@ -39,6 +48,11 @@ func (h *BoardHandler) CreateBoard(c *fiber.Ctx) error {
 		return utils.SendValidationError(c, err.Error(), nil)
 	}
 
+	// Boards created from a template get their pages generated
+	if req.TemplateID != nil {
+		return h.createBoardFromTemplate(c, &req)
+	}
+
 	// Set default skin if not provided
 	if req.Skin == "" {
 		req.Skin = "default"
@@ -50,30 +64,30 @@ func (h *BoardHandler) CreateBoard(c *fiber.Ctx) error {
 		return utils.SendDatabaseError(c, "Failed to create board")
 	}
 
-	// Build URLs - Point to frontend, not backend
-	frontendURL := os.Getenv("FRONTEND_URL")
-	if frontendURL == "" {
-		frontendURL = "http://localhost:3000" // Default frontend URL
+	// Convert to response DTO (include edit token for board creation)
+	response := convertToCreateBoardResponse(board)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
+}
+
+// createBoardFromTemplate creates a board with pages generated from a board template
+func (h *BoardHandler) createBoardFromTemplate(c *fiber.Ctx, req *dto.CreateBoardRequest) error {
+	board, err := h.boardTemplateService.CreateBoardFromTemplate(*req.TemplateID, req.Title, req.Description, req.Skin, req.Month, req.StartDate, req.Days)
+	if err != nil {
+		if err == services.ErrBoardTemplateNotFound {
+			return utils.SendNotFoundError(c, "Board template not found")
+		}
+		if err == services.ErrTemplateNotFound {
+			return utils.SendNotFoundError(c, "Page template not found")
+		}
+		if errors.Is(err, services.ErrInvalidTemplateDates) {
+			return utils.SendValidationError(c, err.Error(), nil)
+		}
+		return utils.SendDatabaseError(c, "Failed to create board")
 	}
-	editURL := fmt.Sprintf("%s/board/%s/edit?edit_token=%s", frontendURL, board.ID, board.EditToken)
-	publicURL := fmt.Sprintf("%s/board/%s/public?public_token=%s", frontendURL, board.ID, board.PublicToken)
 
 	// Convert to response DTO (include edit token for board creation)
-	response := dto.CreateBoardResponse{
-		Board: dto.BoardWithTokensResponse{
-			ID:          board.ID,
-			Title:       board.Title,
-			Description: board.Description,
-			Skin:        board.Skin,
-			EditToken:   board.EditToken,
-			PublicToken: board.PublicToken,
-			CreatedAt:   board.CreatedAt,
-			UpdatedAt:   board.UpdatedAt,
-			PageCount:   len(board.Pages),
-		},
-		EditURL:   editURL,
-		PublicURL: publicURL,
-	}
+	response := convertToCreateBoardResponse(board)
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
 }
@@ -114,9 +128,50 @@ func (h *BoardHandler) GetBoardByPublicToken(c *fiber.Ctx) error {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
+		if err == utils.ErrLinkExpired {
+			return sendLinkExpired(c)
+		}
 		return utils.SendDatabaseError(c, "Failed to retrieve board")
 	}
 
+	// Passphrase-protected boards must be unlocked first
+	if ok, err := ensureBoardUnlocked(c, h.boardService, board.ID); !ok {
+		return err
+	}
+
+	// Count the view; readers beyond the view limit are turned away. The view grant lets this
+	// reader load the pages and elements of the board afterwards.
+	viewGrant, viewExpiresAt, err := h.publicLinkService.RecordView(board)
+	if err != nil {
+		if err == utils.ErrLinkExpired {
+			return sendLinkExpired(c)
+		}
+		return utils.SendDatabaseError(c, "Failed to record view")
+	}
+	c.Set(BoardViewHeader, viewGrant)
+	c.Cookie(&fiber.Cookie{
+		Name:     boardViewCookiePrefix + board.ID.String(),
+		Value:    viewGrant,
+		Path:     "/",
+		Expires:  viewExpiresAt,
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	// Public readers see the pinned snapshot when there is one
+	if board.PinnedSnapshotID != nil {
+		pinned, err := h.snapshotService.GetPinnedBoard(board.ID)
+		if err != nil {
+			return utils.SendDatabaseError(c, "Failed to retrieve pinned snapshot")
+		}
+		if pinned != nil {
+			// Keep the requested token so a retired one does not reveal its replacement
+			pinned.PublicToken = board.PublicToken
+			board = pinned
+		}
+	}
+
 	// Convert to response DTO (without edit token)
 	response := convertToBoardResponse(board)
 	return c.JSON(fiber.Map{"data": response})
@@ -140,6 +195,17 @@ func (h *BoardHandler) UpdateBoard(c *fiber.Ctx) error {
 		return utils.SendValidationError(c, err.Error(), nil)
 	}
 
+	// Validate edit token and board access
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		return utils.SendDatabaseError(c, "Failed to validate board access")
+	}
+
 	// Update board
 	board, err := h.boardService.UpdateBoard(boardID, req.Title, req.Description, req.Skin)
 	if err != nil {
@@ -154,11 +220,73 @@ func (h *BoardHandler) UpdateBoard(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"data": response})
 }
 
-// GetAllBoards retrieves all boards
+// GetAllBoards lists every board on the server; the route is restricted to administrators.
+// Results can be paginated with ?limit=&cursor= and filtered by ?title_prefix= and a
+// ?from=&to= creation date range.
 // GET /api/v1/boards
 func (h *BoardHandler) GetAllBoards(c *fiber.Ctx) error {
-	boards, err := h.boardService.GetAllBoards()
+	return h.listBoards(c, nil, nil)
+}
+
+// GetBoardDirectory lists the boards whose edit tokens, or keyring token, the client presents.
+// It accepts the same query parameters as GetAllBoards.
+// POST /api/v1/boards/directory
+func (h *BoardHandler) GetBoardDirectory(c *fiber.Ctx) error {
+	var req dto.BoardDirectoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	if len(req.EditTokens) == 0 && req.KeyringToken == nil {
+		return utils.SendUnauthorized(c, "Edit tokens or a keyring token are required")
+	}
+
+	editTokens, err := h.boardService.BoardIDsByEditTokens(req.EditTokens)
+	if err != nil {
+		return utils.SendDatabaseError(c, "Failed to retrieve boards")
+	}
+
+	var keyringBoardIDs []uuid.UUID
+	if req.KeyringToken != nil {
+		keyringBoardIDs, err = h.keyringService.BoardIDs(*req.KeyringToken)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendUnauthorizedError(c, "Invalid keyring token")
+			}
+			return utils.SendDatabaseError(c, "Failed to retrieve boards")
+		}
+	}
+
+	boardIDs := services.DirectoryBoardIDs(editTokens, keyringBoardIDs)
+	return h.listBoards(c, boardIDs, editTokens)
+}
+
+// listBoards responds with a page of boards restricted to boardIDs when it is non-nil. Only edit
+// tokens in editTokens, the ones the client presented, are included since the rest are stored hashed.
+func (h *BoardHandler) listBoards(c *fiber.Ctx, boardIDs []uuid.UUID, editTokens map[uuid.UUID]uuid.UUID) error {
+	var req dto.ListBoardsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	filter, err := newListFilter(req.TitlePrefix, req.From, req.To)
 	if err != nil {
+		return utils.SendValidationError(c, "Invalid date range", nil)
+	}
+
+	boards, nextCursor, err := h.boardService.ListBoards(boardIDs, filter, newListParams(req.Limit, req.Cursor, req.Sort, req.Order))
+	if err != nil {
+		if err == services.ErrInvalidCursor {
+			return utils.SendValidationError(c, "Invalid cursor", nil)
+		}
 		return utils.SendDatabaseError(c, "Failed to retrieve boards")
 	}
 
@@ -166,22 +294,23 @@ func (h *BoardHandler) GetAllBoards(c *fiber.Ctx) error {
 	response := make([]dto.BoardWithTokensResponse, len(boards))
 	for i, board := range boards {
 		response[i] = dto.BoardWithTokensResponse{
-			ID:          board.ID,
-			Title:       board.Title,
-			Description: board.Description,
-			Skin:        board.Skin,
-			EditToken:   board.EditToken,
-			PublicToken: board.PublicToken,
-			CreatedAt:   board.CreatedAt,
-			UpdatedAt:   board.UpdatedAt,
-			PageCount:   len(board.Pages),
+			ID:                  board.ID,
+			Title:               board.Title,
+			Description:         board.Description,
+			Skin:                board.Skin,
+			EditToken:           knownToken(editTokens[board.ID]),
+			PublicToken:         board.PublicToken,
+			CreatedAt:           board.CreatedAt,
+			UpdatedAt:           board.UpdatedAt,
+			PageCount:           board.PageCount,
+			PassphraseProtected: board.PublicPassphraseHash != "",
 		}
 	}
 
-	return c.JSON(fiber.Map{"data": response})
+	return c.JSON(fiber.Map{"data": response, "next_cursor": nextCursorValue(nextCursor)})
 }
 
-// DeleteBoard deletes a board
+// DeleteBoard moves a board to the trash
 // DELETE /api/v1/boards/:boardId
 func (h *BoardHandler) DeleteBoard(c *fiber.Ctx) error {
 	boardIdStr := c.Params("boardId")
@@ -190,7 +319,18 @@ func (h *BoardHandler) DeleteBoard(c *fiber.Ctx) error {
 		return utils.SendValidationError(c, "Invalid board ID", nil)
 	}
 
-	// Delete the board
+	// Validate edit token and board access
+	if err := authorizeBoardEdit(c, h.boardService, boardId); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		return utils.SendDatabaseError(c, "Failed to validate board access")
+	}
+
+	// Move the board to the trash
 	err = h.boardService.DeleteBoard(boardId)
 	if err != nil {
 		if err == utils.ErrNotFound {
@@ -202,18 +342,105 @@ func (h *BoardHandler) DeleteBoard(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusNoContent).Send(nil)
 }
 
+// CloneBoard duplicates a board with its pages and elements under new tokens
+// POST /api/v1/boards/:boardId/clone
+func (h *BoardHandler) CloneBoard(c *fiber.Ctx) error {
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate edit token and board access
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		return utils.SendDatabaseError(c, "Failed to validate board access")
+	}
+
+	// The request body is optional
+	var req dto.CloneBoardRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.SendValidationError(c, "Invalid request body", nil)
+		}
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	// Clone board
+	board, err := h.boardService.CloneBoard(boardID, req.Title, req.CopyFiles)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		return utils.SendDatabaseError(c, "Failed to clone board")
+	}
+
+	// Convert to response DTO (include edit token for the new board)
+	response := convertToCreateBoardResponse(board)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
+}
+
 // Helper functions
 
+// knownToken returns a token for a response, or nil when only its hash is known
+func knownToken(token uuid.UUID) *uuid.UUID {
+	if token == uuid.Nil {
+		return nil
+	}
+	return &token
+}
+
+func convertToCreateBoardResponse(board *models.Board) dto.CreateBoardResponse {
+	// Build URLs - Point to frontend, not backend
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000" // Default frontend URL
+	}
+	var editURL string
+	if board.EditToken != uuid.Nil {
+		editURL = fmt.Sprintf("%s/board/%s/edit?edit_token=%s", frontendURL, board.ID, board.EditToken)
+	}
+	publicURL := fmt.Sprintf("%s/board/%s/public?public_token=%s", frontendURL, board.ID, board.PublicToken)
+
+	// Include edit token for board creation
+	return dto.CreateBoardResponse{
+		Board: dto.BoardWithTokensResponse{
+			ID:                  board.ID,
+			Title:               board.Title,
+			Description:         board.Description,
+			Skin:                board.Skin,
+			EditToken:           knownToken(board.EditToken),
+			PublicToken:         board.PublicToken,
+			CreatedAt:           board.CreatedAt,
+			UpdatedAt:           board.UpdatedAt,
+			PageCount:           len(board.Pages),
+			PassphraseProtected: board.PublicPassphraseHash != "",
+		},
+		EditURL:   editURL,
+		PublicURL: publicURL,
+	}
+}
+
 func convertToBoardResponse(board *models.Board) dto.BoardResponse {
 	response := dto.BoardResponse{
-		ID:          board.ID,
-		Title:       board.Title,
-		Description: board.Description,
-		Skin:        board.Skin,
-		PublicToken: board.PublicToken,
-		CreatedAt:   board.CreatedAt,
-		UpdatedAt:   board.UpdatedAt,
-		PageCount:   len(board.Pages),
+		ID:               board.ID,
+		Title:            board.Title,
+		Description:      board.Description,
+		Skin:             board.Skin,
+		PublicToken:      board.PublicToken,
+		PinnedSnapshotID: board.PinnedSnapshotID,
+		CreatedAt:        board.CreatedAt,
+		UpdatedAt:        board.UpdatedAt,
+		PageCount:        len(board.Pages),
 	}
 
 	// Convert pages if present
@@ -226,6 +453,7 @@ func convertToBoardResponse(board *models.Board) dto.BoardResponse {
 				Title:     page.Title,
 				Date:      page.Date,
 				OrderIdx:  page.OrderIdx,
+				Version:   page.Version,
 				CreatedAt: page.CreatedAt,
 				UpdatedAt: page.UpdatedAt,
 			}
@@ -241,7 +469,7 @@ func convertToBoardWithTokensResponse(board *models.Board) dto.BoardWithTokensRe
 		Title:       board.Title,
 		Description: board.Description,
 		Skin:        board.Skin,
-		EditToken:   board.EditToken,
+		EditToken:   knownToken(board.EditToken),
 		PublicToken: board.PublicToken,
 		CreatedAt:   board.CreatedAt,
 		UpdatedAt:   board.UpdatedAt,
@@ -258,6 +486,7 @@ func convertToBoardWithTokensResponse(board *models.Board) dto.BoardWithTokensRe
 				Title:     page.Title,
 				Date:      page.Date,
 				OrderIdx:  page.OrderIdx,
+				Version:   page.Version,
 				CreatedAt: page.CreatedAt,
 				UpdatedAt: page.UpdatedAt,
 			}
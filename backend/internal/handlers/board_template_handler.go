@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BoardTemplateHandler struct {
+	boardTemplateService *services.BoardTemplateService
+}
+
+func NewBoardTemplateHandler(db *gorm.DB) *BoardTemplateHandler {
+	return &BoardTemplateHandler{
+		boardTemplateService: services.NewBoardTemplateService(db),
+	}
+}
+
+// GetBoardTemplates lists the starter kits boards can be created from
+// GET /api/v1/board-templates
+func (h *BoardTemplateHandler) GetBoardTemplates(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	templates, err := h.boardTemplateService.ListTemplates()
+	if err != nil {
+		logger.Errorw("Failed to get board templates", "error", err)
+		return utils.SendInternalError(c, "Failed to get board templates", nil)
+	}
+
+	templateResponses := make([]dto.BoardTemplateResponse, len(templates))
+	for i := range templates {
+		templateResponses[i], err = convertToBoardTemplateResponse(&templates[i])
+		if err != nil {
+			logger.Errorw("Failed to decode board template", "error", err)
+			return utils.SendInternalError(c, "Failed to get board templates", nil)
+		}
+	}
+
+	response := dto.BoardTemplatesListResponse{
+		Templates: templateResponses,
+		Total:     len(templateResponses),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// GetBoardTemplate retrieves a board template with its page structure
+// GET /api/v1/board-templates/:templateId
+func (h *BoardTemplateHandler) GetBoardTemplate(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse template ID from URL
+	templateIDStr := c.Params("templateId")
+	templateID, err := uuid.Parse(templateIDStr)
+	if err != nil {
+		logger.Warnw("Invalid template ID", "templateId", templateIDStr)
+		return utils.SendValidationError(c, "Invalid template ID format", nil)
+	}
+
+	template, err := h.boardTemplateService.GetTemplate(templateID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board template not found")
+		}
+		logger.Errorw("Failed to get board template", "error", err)
+		return utils.SendInternalError(c, "Failed to get board template", nil)
+	}
+
+	response, err := convertToBoardTemplateResponse(template)
+	if err != nil {
+		logger.Errorw("Failed to decode board template", "error", err)
+		return utils.SendInternalError(c, "Failed to get board template", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// convertToBoardTemplateResponse converts a board template model to its response DTO
+func convertToBoardTemplateResponse(template *models.BoardTemplate) (dto.BoardTemplateResponse, error) {
+	pages, err := services.DecodeBoardTemplatePages(template)
+	if err != nil {
+		return dto.BoardTemplateResponse{}, err
+	}
+
+	return dto.BoardTemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		Skin:        template.Skin,
+		DatePattern: template.DatePattern,
+		DefaultDays: template.DefaultDays,
+		Pages:       pages,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"strings"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ways an unlock token of a passphrase-protected board can be sent, in order of precedence
+const (
+	BoardUnlockHeader       = "X-Board-Unlock"
+	boardUnlockCookiePrefix = "board_unlock_"
+	boardUnlockQueryParam   = "unlock_token" // for WebSocket clients, which cannot set headers
+)
+
+type BoardUnlockHandler struct {
+	unlockService *services.BoardUnlockService
+	boardService  *services.BoardService
+}
+
+func NewBoardUnlockHandler(db *gorm.DB, rateLimiter *services.RateLimiter) *BoardUnlockHandler {
+	return &BoardUnlockHandler{
+		unlockService: services.NewBoardUnlockService(db, rateLimiter),
+		boardService:  services.NewBoardService(db),
+	}
+}
+
+// SetPassphrase protects public access to a board with a passphrase
+// PUT /api/v1/boards/:boardId/passphrase
+func (h *BoardUnlockHandler) SetPassphrase(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateOwnerAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.SetBoardPassphraseRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	if err := h.unlockService.SetPassphrase(boardID, req.Passphrase); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to set board passphrase", "error", err)
+		return utils.SendInternalError(c, "Failed to set board passphrase", nil)
+	}
+
+	logger.Infow("Board passphrase set", "boardId", boardID)
+	return c.Status(204).Send(nil)
+}
+
+// RemovePassphrase makes public access to a board open again
+// DELETE /api/v1/boards/:boardId/passphrase
+func (h *BoardUnlockHandler) RemovePassphrase(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateOwnerAccess(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.unlockService.RemovePassphrase(boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to remove board passphrase", "error", err)
+		return utils.SendInternalError(c, "Failed to remove board passphrase", nil)
+	}
+
+	logger.Infow("Board passphrase removed", "boardId", boardID)
+	return c.Status(204).Send(nil)
+}
+
+// Unlock exchanges a board's passphrase for a short-lived unlock token, also set as a cookie.
+// Failed attempts are throttled per client, with a stricter backoff for failing clients while
+// the board sees many failures.
+// POST /api/v1/boards/:boardId/unlock
+func (h *BoardUnlockHandler) Unlock(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Parse request body
+	var req dto.UnlockBoardRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	token, expiresAt, retryAfter, err := h.unlockService.Unlock(boardID, req.Passphrase, c.IP())
+	if err != nil {
+		switch err {
+		case utils.ErrNotFound:
+			return utils.SendNotFoundError(c, "Board not found")
+		case services.ErrBoardNotProtected:
+			return utils.SendValidationError(c, "Board is not protected by a passphrase", nil)
+		case services.ErrInvalidPassphrase:
+			logger.Warnw("Invalid board passphrase", "boardId", boardID, "ip", c.IP())
+			return utils.SendUnauthorizedError(c, "Invalid passphrase")
+		case services.ErrTooManyUnlockTries:
+			logger.Warnw("Board unlock throttled", "boardId", boardID, "ip", c.IP())
+			return utils.SendTooManyRequests(c, "Too many unlock attempts, try again later", retryAfter)
+		}
+		logger.Errorw("Failed to unlock board", "error", err)
+		return utils.SendInternalError(c, "Failed to unlock board", nil)
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     boardUnlockCookiePrefix + boardID.String(),
+		Value:    token,
+		Path:     "/",
+		Expires:  expiresAt,
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	response := dto.UnlockBoardResponse{
+		UnlockToken: token,
+		ExpiresAt:   expiresAt,
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// validateOwnerAccess parses the board ID and checks that the request comes from the board's
+// owner: a signed-in owner or the holder of the board's own edit token.
+// When ok is false the error response has already been sent.
+func (h *BoardUnlockHandler) validateOwnerAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	isOwner, err := hasMemberRole(c, h.boardService, boardID, models.BoardRoleOwner)
+	if err == nil && !isOwner {
+		editToken, _ := c.Locals("edit_token").(uuid.UUID)
+		err = h.boardService.ValidateBoardEditToken(boardID, editToken)
+	}
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// unlockTokenFromRequest returns the unlock token a request carries for a board
+func unlockTokenFromRequest(c *fiber.Ctx, boardID uuid.UUID) string {
+	if token := strings.TrimSpace(c.Get(BoardUnlockHeader)); token != "" {
+		return token
+	}
+	if token := c.Cookies(boardUnlockCookiePrefix + boardID.String()); token != "" {
+		return token
+	}
+	return c.Query(boardUnlockQueryParam)
+}
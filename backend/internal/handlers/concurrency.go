@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseExpectedVersion returns the version a client expects to update.
+// An If-Match header takes precedence over the version field of the request body;
+// If-Match: * matches any current version, so it sets no version constraint.
+func parseExpectedVersion(c *fiber.Ctx, bodyVersion *int) (*int, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return bodyVersion, nil
+	}
+	if header == "*" {
+		return nil, nil
+	}
+
+	version, err := parseVersionTag(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// parseVersionTag parses an entity tag such as "3", W/"3" or 3 into a version number
+func parseVersionTag(tag string) (int, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	tag = strings.Trim(tag, `"`)
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, errors.New("If-Match must contain a positive version number")
+	}
+
+	return version, nil
+}
+
+// setVersionETag exposes a resource version as an entity tag
+func setVersionETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, `"`+strconv.Itoa(version)+`"`)
+}
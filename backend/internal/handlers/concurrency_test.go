@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseVersionTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected int
+		wantErr  bool
+	}{
+		{tag: `"3"`, expected: 3},
+		{tag: `W/"3"`, expected: 3},
+		{tag: "3", expected: 3},
+		{tag: ` "12" `, expected: 12},
+		{tag: `"0"`, wantErr: true},
+		{tag: `"-1"`, wantErr: true},
+		{tag: `"abc"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		version, err := parseVersionTag(tt.tag)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseVersionTag(%q): expected an error, got %d", tt.tag, version)
+			}
+			continue
+		}
+		if err != nil || version != tt.expected {
+			t.Errorf("parseVersionTag(%q): expected %d, got %d, %v", tt.tag, tt.expected, version, err)
+		}
+	}
+}
+
+func TestParseExpectedVersion(t *testing.T) {
+	bodyVersion := 2
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		bodyVersion *int
+		expected    *int
+		wantErr     bool
+	}{
+		{name: "Neither header nor body", expected: nil},
+		{name: "Body version only", bodyVersion: &bodyVersion, expected: &bodyVersion},
+		{name: "Header takes precedence over the body", ifMatch: `"5"`, bodyVersion: &bodyVersion, expected: intPtr(5)},
+		{name: "Blank header falls back to the body", ifMatch: "  ", bodyVersion: &bodyVersion, expected: &bodyVersion},
+		{name: "Any version matches a wildcard", ifMatch: "*", bodyVersion: &bodyVersion, expected: nil},
+		{name: "Invalid header is rejected", ifMatch: `"x"`, bodyVersion: &bodyVersion, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *int
+			var err error
+
+			app := fiber.New()
+			app.Put("/", func(c *fiber.Ctx) error {
+				got, err = parseExpectedVersion(c, tt.bodyVersion)
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set(fiber.HeaderIfMatch, tt.ifMatch)
+			}
+			if _, testErr := app.Test(req); testErr != nil {
+				t.Fatalf("Unexpected error: %v", testErr)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if (got == nil) != (tt.expected == nil) || (got != nil && *got != *tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
 	"junk-journal-board/internal/services"
 	"junk-journal-board/internal/utils"
 
@@ -11,16 +12,20 @@ import (
 )
 
 type ElementHandler struct {
-	elementService *services.ElementService
-	pageService    *services.PageService
-	boardService   *services.BoardService
+	elementService  *services.ElementService
+	pageService     *services.PageService
+	boardService    *services.BoardService
+	snapshotService *services.SnapshotService
+	liveHub         *services.LiveHub
 }
 
-func NewElementHandler(db *gorm.DB) *ElementHandler {
+func NewElementHandler(db *gorm.DB, liveHub *services.LiveHub) *ElementHandler {
 	return &ElementHandler{
-		elementService: services.NewElementService(db),
-		pageService:    services.NewPageService(db),
-		boardService:   services.NewBoardService(db),
+		elementService:  services.NewElementService(db),
+		pageService:     services.NewPageService(db),
+		boardService:    services.NewBoardService(db),
+		snapshotService: services.NewSnapshotService(db),
+		liveHub:         liveHub,
 	}
 }
 
@@ -46,8 +51,7 @@ func (h *ElementHandler) CreateElement(c *fiber.Ctx) error {
 	}
 
 	// Validate edit token and board access
-	editToken := c.Locals("edit_token").(uuid.UUID)
-	if err := h.boardService.ValidateBoardEditAccess(boardID, editToken); err != nil {
+	if err := authorizePageEdit(c, h.boardService, boardID, pageID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
@@ -87,28 +91,16 @@ func (h *ElementHandler) CreateElement(c *fiber.Ctx) error {
 	}
 
 	// Convert to response DTO
-	response := dto.ElementResponse{
-		ID:        element.ID,
-		PageID:    element.PageID,
-		Kind:      element.Kind,
-		X:         element.X,
-		Y:         element.Y,
-		W:         element.W,
-		H:         element.H,
-		Rotation:  element.Rotation,
-		Z:         element.Z,
-		Visible:   element.Visible,
-		Locked:    element.Locked,
-		Payload:   element.Payload,
-		CreatedAt: element.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: element.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	response := convertToElementResponse(element)
+
+	publishLiveEvent(c, h.liveHub, dto.LiveEventElementCreated, boardID, &pageID, response)
 
 	logger.Infow("Element created successfully", "elementId", element.ID, "pageId", pageID)
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
 }
 
-// GetElementsByPage lists all elements for a page
+// GetElementsByPage lists a page's elements, optionally paginated with ?limit=&cursor= and
+// filtered by ?kind= and a ?from=&to= creation date range
 // GET /api/v1/boards/:boardId/pages/:pageId/elements
 func (h *ElementHandler) GetElementsByPage(c *fiber.Ctx) error {
 	logger := c.Locals("logger").(*utils.Logger)
@@ -132,10 +124,13 @@ func (h *ElementHandler) GetElementsByPage(c *fiber.Ctx) error {
 	// Validate board access (both edit and public tokens are allowed for reading)
 	token := c.Locals("token")
 	if token != nil {
-		if err := h.boardService.ValidateBoardAccess(boardID, token.(uuid.UUID)); err != nil {
+		if err := h.boardService.ValidatePageAccess(boardID, pageID, token.(uuid.UUID)); err != nil {
 			if err == utils.ErrNotFound {
 				return utils.SendNotFoundError(c, "Board not found")
 			}
+			if err == utils.ErrLinkExpired {
+				return sendLinkExpired(c)
+			}
 			if err == utils.ErrUnauthorized {
 				return utils.SendUnauthorizedError(c, "Invalid token")
 			}
@@ -152,46 +147,70 @@ func (h *ElementHandler) GetElementsByPage(c *fiber.Ctx) error {
 		}
 	}
 
-	// Validate page belongs to board
-	if err := h.pageService.ValidatePageBelongsToBoard(pageID, boardID); err != nil {
-		if err == utils.ErrNotFound {
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
+	}
+
+	// Readers without the edit token see the pinned snapshot when there is one
+	pinned, err := pinnedBoardForReader(c, h.boardService, h.snapshotService, boardID)
+	if err != nil {
+		logger.Errorw("Failed to get pinned snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+	}
+
+	// Parse query parameters
+	var req dto.ListElementsRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	filter, err := newListFilter("", req.From, req.To)
+	if err != nil {
+		return utils.SendValidationError(c, "Invalid date range", nil)
+	}
+	params := newListParams(req.Limit, req.Cursor, req.Sort, req.Order)
+
+	var elements []models.Element
+	var nextCursor string
+	if pinned != nil {
+		page := findPinnedPage(pinned, pageID)
+		if page == nil {
 			return utils.SendNotFoundError(c, "Page not found")
 		}
-		logger.Errorw("Failed to validate page ownership", "error", err)
-		return utils.SendInternalError(c, "Failed to validate page", nil)
-	}
+		elements, nextCursor, err = services.ListPinnedElements(page.Elements, req.Kind, filter, params)
+	} else {
+		// Validate page belongs to board
+		if err := h.pageService.ValidatePageBelongsToBoard(pageID, boardID); err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendNotFoundError(c, "Page not found")
+			}
+			logger.Errorw("Failed to validate page ownership", "error", err)
+			return utils.SendInternalError(c, "Failed to validate page", nil)
+		}
 
-	// Get elements
-	elements, err := h.elementService.GetElementsByPage(pageID)
+		elements, nextCursor, err = h.elementService.ListElements(pageID, req.Kind, filter, params)
+	}
 	if err != nil {
+		if err == services.ErrInvalidCursor {
+			return utils.SendValidationError(c, "Invalid cursor", nil)
+		}
 		logger.Errorw("Failed to get elements", "error", err)
 		return utils.SendInternalError(c, "Failed to get elements", nil)
 	}
 
 	// Convert to response DTOs
-	elementResponses := make([]dto.ElementResponse, len(elements))
-	for i, element := range elements {
-		elementResponses[i] = dto.ElementResponse{
-			ID:        element.ID,
-			PageID:    element.PageID,
-			Kind:      element.Kind,
-			X:         element.X,
-			Y:         element.Y,
-			W:         element.W,
-			H:         element.H,
-			Rotation:  element.Rotation,
-			Z:         element.Z,
-			Visible:   element.Visible,
-			Locked:    element.Locked,
-			Payload:   element.Payload,
-			CreatedAt: element.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: element.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		}
-	}
+	elementResponses := convertToElementResponses(elements)
 
 	response := dto.ElementsListResponse{
-		Elements: elementResponses,
-		Total:    len(elementResponses),
+		Elements:   elementResponses,
+		Total:      len(elementResponses),
+		NextCursor: nextCursorValue(nextCursor),
 	}
 
 	return c.JSON(fiber.Map{"data": response})
@@ -226,8 +245,7 @@ func (h *ElementHandler) UpdateElement(c *fiber.Ctx) error {
 	}
 
 	// Validate edit token and board access
-	editToken := c.Locals("edit_token").(uuid.UUID)
-	if err := h.boardService.ValidateBoardEditAccess(boardID, editToken); err != nil {
+	if err := authorizePageEdit(c, h.boardService, boardID, pageID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
@@ -298,39 +316,37 @@ func (h *ElementHandler) UpdateElement(c *fiber.Ctx) error {
 		updates["payload"] = req.Payload
 	}
 
-	// Update element
-	element, err := h.elementService.UpdateElement(elementID, updates)
+	// Update element, guarded by the version the client last saw
+	expectedVersion, err := parseExpectedVersion(c, req.Version)
+	if err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	element, err := h.elementService.UpdateElement(elementID, updates, expectedVersion)
 	if err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Element not found")
 		}
+		if err == utils.ErrConflict {
+			logger.Infow("Element update conflict", "elementId", elementID, "expectedVersion", expectedVersion, "currentVersion", element.Version)
+			return utils.SendConflict(c, "Element was modified by someone else", convertToElementResponse(element))
+		}
 		logger.Errorw("Failed to update element", "error", err)
 		return utils.SendInternalError(c, "Failed to update element", nil)
 	}
 
 	// Convert to response DTO
-	response := dto.ElementResponse{
-		ID:        element.ID,
-		PageID:    element.PageID,
-		Kind:      element.Kind,
-		X:         element.X,
-		Y:         element.Y,
-		W:         element.W,
-		H:         element.H,
-		Rotation:  element.Rotation,
-		Z:         element.Z,
-		Visible:   element.Visible,
-		Locked:    element.Locked,
-		Payload:   element.Payload,
-		CreatedAt: element.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: element.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	response := convertToElementResponse(element)
+
+	publishLiveEvent(c, h.liveHub, dto.LiveEventElementUpdated, boardID, &pageID, response)
+
+	setVersionETag(c, element.Version)
 
 	logger.Infow("Element updated successfully", "elementId", elementID)
 	return c.JSON(fiber.Map{"data": response})
 }
 
-// DeleteElement deletes an element
+// DeleteElement moves an element to the trash
 // DELETE /api/v1/boards/:boardId/pages/:pageId/elements/:elementId
 func (h *ElementHandler) DeleteElement(c *fiber.Ctx) error {
 	logger := c.Locals("logger").(*utils.Logger)
@@ -359,8 +375,7 @@ func (h *ElementHandler) DeleteElement(c *fiber.Ctx) error {
 	}
 
 	// Validate edit token and board access
-	editToken := c.Locals("edit_token").(uuid.UUID)
-	if err := h.boardService.ValidateBoardEditAccess(boardID, editToken); err != nil {
+	if err := authorizePageEdit(c, h.boardService, boardID, pageID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
@@ -398,6 +413,8 @@ func (h *ElementHandler) DeleteElement(c *fiber.Ctx) error {
 		return utils.SendInternalError(c, "Failed to delete element", nil)
 	}
 
+	publishLiveEvent(c, h.liveHub, dto.LiveEventElementDeleted, boardID, &pageID, fiber.Map{"id": elementID})
+
 	logger.Infow("Element deleted successfully", "elementId", elementID)
 	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -424,8 +441,7 @@ func (h *ElementHandler) ReorderElements(c *fiber.Ctx) error {
 	}
 
 	// Validate edit token and board access
-	editToken := c.Locals("edit_token").(uuid.UUID)
-	if err := h.boardService.ValidateBoardEditAccess(boardID, editToken); err != nil {
+	if err := authorizePageEdit(c, h.boardService, boardID, pageID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
@@ -479,6 +495,38 @@ func (h *ElementHandler) ReorderElements(c *fiber.Ctx) error {
 		return utils.SendInternalError(c, "Failed to reorder elements", nil)
 	}
 
+	publishLiveEvent(c, h.liveHub, dto.LiveEventElementsReordered, boardID, &pageID, req.Elements)
+
 	logger.Infow("Elements reordered successfully", "pageId", pageID, "count", len(updates))
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// convertToElementResponse converts an element model to its response DTO
+func convertToElementResponse(element *models.Element) dto.ElementResponse {
+	return dto.ElementResponse{
+		ID:        element.ID,
+		PageID:    element.PageID,
+		Kind:      element.Kind,
+		X:         element.X,
+		Y:         element.Y,
+		W:         element.W,
+		H:         element.H,
+		Rotation:  element.Rotation,
+		Z:         element.Z,
+		Version:   element.Version,
+		Visible:   element.Visible,
+		Locked:    element.Locked,
+		Payload:   element.Payload,
+		CreatedAt: element.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: element.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// convertToElementResponses converts element models to response DTOs
+func convertToElementResponses(elements []models.Element) []dto.ElementResponse {
+	responses := make([]dto.ElementResponse, len(elements))
+	for i := range elements {
+		responses[i] = convertToElementResponse(&elements[i])
+	}
+	return responses
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type HistoryHandler struct {
+	historyService *services.HistoryService
+	elementService *services.ElementService
+	pageService    *services.PageService
+	boardService   *services.BoardService
+	liveHub        *services.LiveHub
+}
+
+func NewHistoryHandler(db *gorm.DB, liveHub *services.LiveHub) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: services.NewHistoryService(db),
+		elementService: services.NewElementService(db),
+		pageService:    services.NewPageService(db),
+		boardService:   services.NewBoardService(db),
+		liveHub:        liveHub,
+	}
+}
+
+// GetPageHistory lists the element revisions of a page, newest first
+// GET /api/v1/boards/:boardId/pages/:pageId/history?element_id=...&before=...&limit=50
+func (h *HistoryHandler) GetPageHistory(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	_, pageID, ok, err := h.validatePageEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse query parameters
+	var req dto.PageHistoryRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	var elementID *uuid.UUID
+	if req.ElementID != "" {
+		parsedID := uuid.MustParse(req.ElementID)
+		elementID = &parsedID
+	}
+
+	var before *time.Time
+	if req.Before != "" {
+		parsedBefore, err := time.Parse(time.RFC3339Nano, req.Before)
+		if err != nil {
+			logger.Warnw("Invalid before timestamp", "before", req.Before)
+			return utils.SendValidationError(c, "Invalid before timestamp. Use RFC 3339", nil)
+		}
+		before = &parsedBefore
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = services.DefaultHistoryLimit
+	}
+
+	revisions, err := h.historyService.ListPageHistory(pageID, elementID, before, limit)
+	if err != nil {
+		logger.Errorw("Failed to get page history", "error", err)
+		return utils.SendInternalError(c, "Failed to get page history", nil)
+	}
+
+	response := dto.PageHistoryResponse{
+		Revisions: convertToElementRevisionResponses(revisions),
+		Total:     len(revisions),
+	}
+	if len(revisions) == limit {
+		nextBefore := revisions[len(revisions)-1].CreatedAt
+		response.NextBefore = &nextBefore
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// GetPageSnapshot returns the elements of a page as they were at a point in time
+// GET /api/v1/boards/:boardId/pages/:pageId/history/snapshot?at=2024-01-15T10:00:00Z
+func (h *HistoryHandler) GetPageSnapshot(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	_, pageID, ok, err := h.validatePageEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse query parameters
+	var req dto.PageSnapshotRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	at, err := time.Parse(time.RFC3339Nano, req.At)
+	if err != nil {
+		logger.Warnw("Invalid at timestamp", "at", req.At)
+		return utils.SendValidationError(c, "Invalid at timestamp. Use RFC 3339", nil)
+	}
+
+	elements, err := h.historyService.GetPageAsOf(pageID, at)
+	if err != nil {
+		logger.Errorw("Failed to get page snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get page snapshot", nil)
+	}
+
+	response := dto.PageSnapshotResponse{
+		PageID:   pageID,
+		At:       at,
+		Elements: convertToElementResponses(elements),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// RestorePage restores all elements of a page to how they were at a point in time
+// POST /api/v1/boards/:boardId/pages/:pageId/history/restore
+func (h *HistoryHandler) RestorePage(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, pageID, ok, err := h.validatePageEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.RestorePageRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	changes, err := h.historyService.RestorePage(pageID, req.At)
+	if err != nil {
+		logger.Errorw("Failed to restore page", "error", err)
+		return utils.SendInternalError(c, "Failed to restore page", nil)
+	}
+
+	elements, err := h.elementService.GetElementsByPage(pageID)
+	if err != nil {
+		logger.Errorw("Failed to get restored page", "error", err)
+		return utils.SendInternalError(c, "Failed to get restored page", nil)
+	}
+
+	for i := range changes {
+		publishRevisionEvent(c, h.liveHub, boardID, &changes[i])
+	}
+
+	response := dto.RestorePageResponse{
+		PageID:     pageID,
+		RestoredTo: req.At,
+		Changes:    convertToElementRevisionResponses(changes),
+		Elements:   convertToElementResponses(elements),
+	}
+
+	logger.Infow("Page restored successfully", "pageId", pageID, "at", req.At, "changes", len(changes))
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// RestoreElement restores a single element to the state recorded by a revision
+// POST /api/v1/boards/:boardId/pages/:pageId/history/:revisionId/restore
+func (h *HistoryHandler) RestoreElement(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, pageID, ok, err := h.validatePageEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse revision ID from URL
+	revisionIDStr := c.Params("revisionId")
+	revisionID, err := uuid.Parse(revisionIDStr)
+	if err != nil {
+		logger.Warnw("Invalid revision ID", "revisionId", revisionIDStr)
+		return utils.SendValidationError(c, "Invalid revision ID format", nil)
+	}
+
+	element, revision, err := h.historyService.RestoreElement(pageID, revisionID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Revision not found")
+		}
+		logger.Errorw("Failed to restore element", "error", err)
+		return utils.SendInternalError(c, "Failed to restore element", nil)
+	}
+
+	if revision != nil {
+		publishRevisionEvent(c, h.liveHub, boardID, revision)
+	}
+
+	logger.Infow("Element restored successfully", "elementId", element.ID, "revisionId", revisionID)
+	return c.JSON(fiber.Map{"data": convertToElementResponse(element)})
+}
+
+// validatePageEditAccess parses the board and page IDs and checks the edit token.
+// When ok is false the error response has already been sent.
+func (h *HistoryHandler) validatePageEditAccess(c *fiber.Ctx) (boardID, pageID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse IDs from URL
+	boardIDStr := c.Params("boardId")
+	pageIDStr := c.Params("pageId")
+
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	pageID, err = uuid.Parse(pageIDStr)
+	if err != nil {
+		logger.Warnw("Invalid page ID", "pageId", pageIDStr)
+		return uuid.Nil, uuid.Nil, false, utils.SendValidationError(c, "Invalid page ID format", nil)
+	}
+
+	// Validate edit token and board access
+	if err := authorizePageEdit(c, h.boardService, boardID, pageID); err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	// Validate page belongs to board
+	if err := h.pageService.ValidatePageBelongsToBoard(pageID, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, uuid.Nil, false, utils.SendNotFoundError(c, "Page not found")
+		}
+		logger.Errorw("Failed to validate page ownership", "error", err)
+		return uuid.Nil, uuid.Nil, false, utils.SendInternalError(c, "Failed to validate page", nil)
+	}
+
+	return boardID, pageID, true, nil
+}
+
+// publishRevisionEvent broadcasts the element change recorded by a revision
+func publishRevisionEvent(c *fiber.Ctx, liveHub *services.LiveHub, boardID uuid.UUID, revision *models.ElementRevision) {
+	pageID := revision.PageID
+
+	if len(revision.After) == 0 {
+		publishLiveEvent(c, liveHub, dto.LiveEventElementDeleted, boardID, &pageID, fiber.Map{"id": revision.ElementID})
+		return
+	}
+
+	var element models.Element
+	if err := json.Unmarshal(revision.After, &element); err != nil {
+		return
+	}
+
+	eventType := dto.LiveEventElementUpdated
+	if len(revision.Before) == 0 {
+		eventType = dto.LiveEventElementCreated
+	}
+	publishLiveEvent(c, liveHub, eventType, boardID, &pageID, convertToElementResponse(&element))
+}
+
+// convertToElementRevisionResponses converts revision models to response DTOs
+func convertToElementRevisionResponses(revisions []models.ElementRevision) []dto.ElementRevisionResponse {
+	responses := make([]dto.ElementRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = dto.ElementRevisionResponse{
+			ID:        revision.ID,
+			ElementID: revision.ElementID,
+			PageID:    revision.PageID,
+			Action:    revision.Action,
+			Before:    revision.Before,
+			After:     revision.After,
+			CreatedAt: revision.CreatedAt,
+		}
+	}
+	return responses
+}
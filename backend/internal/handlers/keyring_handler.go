@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type KeyringHandler struct {
+	keyringService *services.KeyringService
+}
+
+func NewKeyringHandler(db *gorm.DB) *KeyringHandler {
+	return &KeyringHandler{
+		keyringService: services.NewKeyringService(db),
+	}
+}
+
+// CreateKeyring creates a keyring, optionally holding the boards of the given edit tokens
+// POST /api/v1/keyrings
+func (h *KeyringHandler) CreateKeyring(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// The request body is optional
+	var req dto.KeyringBoardsRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Warnw("Failed to parse request body", "error", err)
+			return utils.SendValidationError(c, "Invalid request body", nil)
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	keyring, count, err := h.keyringService.CreateKeyring(req.EditTokens)
+	if err != nil {
+		logger.Errorw("Failed to create keyring", "error", err)
+		return utils.SendInternalError(c, "Failed to create keyring", nil)
+	}
+
+	return c.Status(201).JSON(fiber.Map{"data": convertToKeyringResponse(keyring, count)})
+}
+
+// GetKeyring retrieves a keyring's board count
+// GET /api/v1/keyrings/:keyringToken
+func (h *KeyringHandler) GetKeyring(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	token, ok, err := parseKeyringToken(c)
+	if !ok {
+		return err
+	}
+
+	keyring, count, err := h.keyringService.GetKeyring(token)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Keyring not found")
+		}
+		logger.Errorw("Failed to get keyring", "error", err)
+		return utils.SendInternalError(c, "Failed to get keyring", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": convertToKeyringResponse(keyring, count)})
+}
+
+// AddBoards adds the boards of the given edit tokens to a keyring
+// POST /api/v1/keyrings/:keyringToken/boards
+func (h *KeyringHandler) AddBoards(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	token, ok, err := parseKeyringToken(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.KeyringBoardsRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	keyring, count, err := h.keyringService.AddBoards(token, req.EditTokens)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Keyring not found")
+		}
+		logger.Errorw("Failed to add boards to keyring", "error", err)
+		return utils.SendInternalError(c, "Failed to add boards to keyring", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": convertToKeyringResponse(keyring, count)})
+}
+
+// RemoveBoard takes a board off a keyring
+// DELETE /api/v1/keyrings/:keyringToken/boards/:boardId
+func (h *KeyringHandler) RemoveBoard(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	token, ok, err := parseKeyringToken(c)
+	if !ok {
+		return err
+	}
+
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	if err := h.keyringService.RemoveBoard(token, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Keyring or board not found")
+		}
+		logger.Errorw("Failed to remove board from keyring", "error", err)
+		return utils.SendInternalError(c, "Failed to remove board from keyring", nil)
+	}
+
+	return c.Status(204).Send(nil)
+}
+
+// DeleteKeyring deletes a keyring without touching its boards
+// DELETE /api/v1/keyrings/:keyringToken
+func (h *KeyringHandler) DeleteKeyring(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	token, ok, err := parseKeyringToken(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.keyringService.DeleteKeyring(token); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Keyring not found")
+		}
+		logger.Errorw("Failed to delete keyring", "error", err)
+		return utils.SendInternalError(c, "Failed to delete keyring", nil)
+	}
+
+	return c.Status(204).Send(nil)
+}
+
+// parseKeyringToken parses the keyring token from the URL.
+// When ok is false the error response has already been sent.
+func parseKeyringToken(c *fiber.Ctx) (uuid.UUID, bool, error) {
+	token, valid := utils.ValidateToken(c.Params("keyringToken"))
+	if !valid {
+		return uuid.Nil, false, utils.SendUnauthorized(c, "Invalid keyring token format")
+	}
+
+	return token, true, nil
+}
+
+// convertToKeyringResponse converts a keyring model to response DTO
+func convertToKeyringResponse(keyring *models.Keyring, boardCount int) dto.KeyringResponse {
+	return dto.KeyringResponse{
+		Token:      keyring.Token,
+		BoardCount: boardCount,
+		CreatedAt:  keyring.CreatedAt,
+	}
+}
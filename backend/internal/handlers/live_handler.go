@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LiveClientIDHeader lets a live client mark its own REST mutations so they are not echoed back
+const LiveClientIDHeader = "X-Live-Client-ID"
+
+// Keepalive settings for live connections
+const (
+	livePingInterval = 30 * time.Second
+	liveWriteTimeout = 10 * time.Second
+)
+
+type LiveHandler struct {
+	liveHub         *services.LiveHub
+	presenceService *services.PresenceService
+	boardService    *services.BoardService
+	snapshotService *services.SnapshotService
+}
+
+func NewLiveHandler(db *gorm.DB, liveHub *services.LiveHub, presenceService *services.PresenceService) *LiveHandler {
+	return &LiveHandler{
+		liveHub:         liveHub,
+		presenceService: presenceService,
+		boardService:    services.NewBoardService(db),
+		snapshotService: services.NewSnapshotService(db),
+	}
+}
+
+// Upgrade authenticates a live connection request before the WebSocket handshake
+// GET /api/v1/boards/:boardId/live?edit_token=...|public_token=...
+func (h *LiveHandler) Upgrade(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// A token or board membership is required; editors get read-write sessions, other readers read-only ones
+	if err := authorizeBoardRead(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrLinkExpired {
+			return sendLinkExpired(c)
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "A valid board token is required for live updates")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
+	}
+
+	readOnly := authorizeBoardEdit(c, h.boardService, boardID) != nil
+
+	// Readers without the edit token see the pinned snapshot when there is one
+	pinned, err := pinnedBoardForReader(c, h.boardService, h.snapshotService, boardID)
+	if err != nil {
+		logger.Errorw("Failed to get pinned snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+	}
+
+	c.Locals("live_board_id", boardID)
+	c.Locals("live_read_only", readOnly)
+	c.Locals("live_pinned", pinned != nil)
+	return c.Next()
+}
+
+// Live streams board events to an authenticated WebSocket connection
+func (h *LiveHandler) Live() fiber.Handler {
+	return websocket.New(func(conn *websocket.Conn) {
+		logger := conn.Locals("logger").(*utils.Logger)
+		boardID := conn.Locals("live_board_id").(uuid.UUID)
+		readOnly := conn.Locals("live_read_only").(bool)
+		pinned := conn.Locals("live_pinned").(bool)
+
+		client := h.liveHub.Register(boardID, readOnly, pinned)
+		logger.Infow("Live client connected", "boardId", boardID, "clientId", client.ID, "readOnly", readOnly, "pinned", pinned)
+
+		h.liveHub.Send(client, dto.LiveEvent{
+			Type:    dto.LiveEventWelcome,
+			BoardID: boardID,
+			Data: dto.LiveWelcomeData{
+				ClientID: client.ID,
+				ReadOnly: readOnly,
+				Pinned:   pinned,
+			},
+		})
+
+		// Writer: forward queued events and keep the connection alive
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			ticker := time.NewTicker(livePingInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case message, ok := <-client.Messages():
+					conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+					if !ok {
+						// The hub dropped the client; closing the connection also ends the reader
+						conn.WriteMessage(websocket.CloseMessage, []byte{})
+						conn.Close()
+						return
+					}
+					if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+						return
+					}
+				case <-ticker.C:
+					conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+					if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+						return
+					}
+				}
+			}
+		}()
+
+		h.presenceService.Join(client)
+
+		// Reader: mutations go through the REST handlers, so only presence messages are handled here
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				break
+			}
+			h.handleClientMessage(client, data, logger)
+		}
+
+		h.presenceService.Leave(client)
+		h.liveHub.Unregister(client)
+		<-done
+		logger.Infow("Live client disconnected", "boardId", boardID, "clientId", client.ID)
+	})
+}
+
+// handleClientMessage applies a message received from a live client
+func (h *LiveHandler) handleClientMessage(client *services.LiveClient, data []byte, logger *utils.Logger) {
+	var message dto.LiveClientMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		logger.Debugw("Ignoring malformed live message", "clientId", client.ID)
+		return
+	}
+
+	switch message.Type {
+	case dto.LiveMessagePresenceHeartbeat:
+		h.presenceService.Touch(client)
+
+	case dto.LiveMessagePresenceUpdate:
+		var update dto.PresenceUpdateRequest
+		if err := json.Unmarshal(message.Data, &update); err != nil {
+			logger.Debugw("Ignoring malformed presence update", "clientId", client.ID)
+			return
+		}
+		if err := utils.ValidateStruct(&update); err != nil {
+			logger.Debugw("Ignoring invalid presence update", "clientId", client.ID, "error", err)
+			return
+		}
+		h.presenceService.Update(client, update)
+
+	default:
+		logger.Debugw("Ignoring unknown live message", "clientId", client.ID, "type", message.Type)
+	}
+}
+
+// publishLiveEvent broadcasts a board change to live clients, skipping the originating client
+func publishLiveEvent(c *fiber.Ctx, liveHub *services.LiveHub, eventType string, boardID uuid.UUID, pageID *uuid.UUID, data interface{}) {
+	if liveHub == nil {
+		return
+	}
+
+	excludeClientID, _ := uuid.Parse(c.Get(LiveClientIDHeader))
+	liveHub.Broadcast(dto.LiveEvent{
+		Type:    eventType,
+		BoardID: boardID,
+		PageID:  pageID,
+		Data:    data,
+	}, excludeClientID)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
 	"junk-journal-board/internal/services"
 	"junk-journal-board/internal/utils"
 
@@ -11,14 +12,20 @@ import (
 )
 
 type PageHandler struct {
-	pageService  *services.PageService
-	boardService *services.BoardService
+	pageService     *services.PageService
+	boardService    *services.BoardService
+	snapshotService *services.SnapshotService
+	tagService      *services.TagService
+	liveHub         *services.LiveHub
 }
 
-func NewPageHandler(db *gorm.DB) *PageHandler {
+func NewPageHandler(db *gorm.DB, liveHub *services.LiveHub) *PageHandler {
 	return &PageHandler{
-		pageService:  services.NewPageService(db),
-		boardService: services.NewBoardService(db),
+		pageService:     services.NewPageService(db),
+		boardService:    services.NewBoardService(db),
+		snapshotService: services.NewSnapshotService(db),
+		tagService:      services.NewTagService(db),
+		liveHub:         liveHub,
 	}
 }
 
@@ -36,8 +43,7 @@ func (h *PageHandler) CreatePage(c *fiber.Ctx) error {
 	}
 
 	// Validate edit token and board existence
-	editToken := c.Locals("edit_token").(uuid.UUID)
-	if err := h.boardService.ValidateBoardEditAccess(boardID, editToken); err != nil {
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
@@ -61,29 +67,30 @@ func (h *PageHandler) CreatePage(c *fiber.Ctx) error {
 	}
 
 	// Create page
-	page, err := h.pageService.CreatePage(boardID, req.Title, req.Date)
+	page, err := h.pageService.CreatePage(boardID, req.Title, req.Date, req.TemplateID)
 	if err != nil {
+		if err == services.ErrTemplateNotFound {
+			return utils.SendNotFoundError(c, "Template not found")
+		}
 		logger.Errorw("Failed to create page", "error", err)
 		return utils.SendInternalError(c, "Failed to create page", nil)
 	}
 
-	// Convert to response DTO
-	response := dto.PageResponse{
-		ID:        page.ID,
-		BoardID:   page.BoardID,
-		Title:     page.Title,
-		Date:      page.Date,
-		OrderIdx:  page.OrderIdx,
-		CreatedAt: page.CreatedAt,
-		UpdatedAt: page.UpdatedAt,
+	// Convert to response DTO; pages created from a template come with their elements
+	var response interface{} = convertToPageResponse(page)
+	if req.TemplateID != nil {
+		response = convertToPageWithElementsResponse(page)
 	}
 
+	publishLiveEvent(c, h.liveHub, dto.LiveEventPageCreated, boardID, &page.ID, response)
+
 	logger.Infow("Page created successfully", "pageId", page.ID, "boardId", boardID)
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
 }
 
-// GetPagesByBoard lists all pages for a board
-// GET /api/v1/boards/:boardId/pages
+// GetPagesByBoard lists a board's pages, optionally paginated with ?limit=&cursor= and
+// filtered by ?title_prefix=, a ?from=&to= date range and ?tags=
+// GET /api/v1/boards/:boardId/pages?tags=...
 func (h *PageHandler) GetPagesByBoard(c *fiber.Ctx) error {
 	logger := c.Locals("logger").(*utils.Logger)
 
@@ -103,6 +110,9 @@ func (h *PageHandler) GetPagesByBoard(c *fiber.Ctx) error {
 			if err == utils.ErrNotFound {
 				return utils.SendNotFoundError(c, "Board not found")
 			}
+			if err == utils.ErrLinkExpired {
+				return sendLinkExpired(c)
+			}
 			if err == utils.ErrUnauthorized {
 				return utils.SendUnauthorizedError(c, "Invalid token")
 			}
@@ -120,48 +130,75 @@ func (h *PageHandler) GetPagesByBoard(c *fiber.Ctx) error {
 		}
 	}
 
-	// Get pages
-	pages, err := h.pageService.GetPagesByBoard(boardID)
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
+	}
+
+	// Readers without the edit token see the pinned snapshot when there is one
+	pinned, err := pinnedBoardForReader(c, h.boardService, h.snapshotService, boardID)
+	if err != nil {
+		logger.Errorw("Failed to get pinned snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+	}
+
+	// Parse query parameters
+	var req dto.ListPagesRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	filter, err := newListFilter(req.TitlePrefix, req.From, req.To)
+	if err != nil {
+		return utils.SendValidationError(c, "Invalid date range", nil)
+	}
+	params := newListParams(req.Limit, req.Cursor, req.Sort, req.Order)
+
+	// Optionally keep only pages tagged, on the page or one of its elements, with any of ?tags=a,b
+	var pageIDs []uuid.UUID
+	if tagNames := services.ParseTagFilter(req.Tags); len(tagNames) > 0 {
+		tagged, err := h.tagService.PageIDsWithTags(boardID, tagNames, pinned)
+		if err != nil {
+			logger.Errorw("Failed to filter pages by tag", "error", err)
+			return utils.SendInternalError(c, "Failed to get pages", nil)
+		}
+
+		pageIDs = make([]uuid.UUID, 0, len(tagged))
+		for pageID := range tagged {
+			pageIDs = append(pageIDs, pageID)
+		}
+	}
+
+	var pages []models.Page
+	var nextCursor string
+	if pinned != nil {
+		pages, nextCursor, err = services.ListPinnedPages(pinned.Pages, filter, pageIDs, params)
+	} else {
+		pages, nextCursor, err = h.pageService.ListPages(boardID, filter, pageIDs, params)
+	}
 	if err != nil {
+		if err == services.ErrInvalidCursor {
+			return utils.SendValidationError(c, "Invalid cursor", nil)
+		}
 		logger.Errorw("Failed to get pages", "error", err)
 		return utils.SendInternalError(c, "Failed to get pages", nil)
 	}
 
 	// Convert to response DTOs with elements
 	pageResponses := make([]dto.PageWithElementsResponse, len(pages))
-	for i, page := range pages {
-		// Convert elements to response DTOs
-		elementResponses := make([]dto.ElementResponse, len(page.Elements))
-		for j, element := range page.Elements {
-			elementResponses[j] = dto.ElementResponse{
-				ID:       element.ID,
-				PageID:   element.PageID,
-				Kind:     element.Kind,
-				X:        element.X,
-				Y:        element.Y,
-				W:        element.W,
-				H:        element.H,
-				Rotation: element.Rotation,
-				Z:        element.Z,
-				Payload:  element.Payload,
-			}
-		}
-
-		pageResponses[i] = dto.PageWithElementsResponse{
-			ID:        page.ID,
-			BoardID:   page.BoardID,
-			Title:     page.Title,
-			Date:      page.Date,
-			OrderIdx:  page.OrderIdx,
-			CreatedAt: page.CreatedAt,
-			UpdatedAt: page.UpdatedAt,
-			Elements:  elementResponses,
-		}
+	for i := range pages {
+		pageResponses[i] = convertToPageWithElementsResponse(&pages[i])
 	}
 
 	response := dto.PagesWithElementsListResponse{
-		Pages: pageResponses,
-		Total: len(pageResponses),
+		Pages:      pageResponses,
+		Total:      len(pageResponses),
+		NextCursor: nextCursorValue(nextCursor),
 	}
 
 	return c.JSON(fiber.Map{"data": response})
@@ -191,10 +228,13 @@ func (h *PageHandler) GetPage(c *fiber.Ctx) error {
 	// Validate board access
 	token := c.Locals("token")
 	if token != nil {
-		if err := h.boardService.ValidateBoardAccess(boardID, token.(uuid.UUID)); err != nil {
+		if err := h.boardService.ValidatePageAccess(boardID, pageID, token.(uuid.UUID)); err != nil {
 			if err == utils.ErrNotFound {
 				return utils.SendNotFoundError(c, "Board not found")
 			}
+			if err == utils.ErrLinkExpired {
+				return sendLinkExpired(c)
+			}
 			if err == utils.ErrUnauthorized {
 				return utils.SendUnauthorizedError(c, "Invalid token")
 			}
@@ -211,52 +251,45 @@ func (h *PageHandler) GetPage(c *fiber.Ctx) error {
 		}
 	}
 
-	// Validate page belongs to board
-	if err := h.pageService.ValidatePageBelongsToBoard(pageID, boardID); err != nil {
-		if err == utils.ErrNotFound {
-			return utils.SendNotFoundError(c, "Page not found")
-		}
-		logger.Errorw("Failed to validate page ownership", "error", err)
-		return utils.SendInternalError(c, "Failed to validate page", nil)
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
 	}
 
-	// Get page with elements
-	page, err := h.pageService.GetPageByID(pageID)
+	// Readers without the edit token see the pinned snapshot when there is one
+	pinned, err := pinnedBoardForReader(c, h.boardService, h.snapshotService, boardID)
 	if err != nil {
-		if err == utils.ErrNotFound {
+		logger.Errorw("Failed to get pinned snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+	}
+
+	var page *models.Page
+	if pinned != nil {
+		if page = findPinnedPage(pinned, pageID); page == nil {
 			return utils.SendNotFoundError(c, "Page not found")
 		}
-		logger.Errorw("Failed to get page", "error", err)
-		return utils.SendInternalError(c, "Failed to get page", nil)
-	}
-
-	// Convert elements to response DTOs
-	elementResponses := make([]dto.ElementResponse, len(page.Elements))
-	for i, element := range page.Elements {
-		elementResponses[i] = dto.ElementResponse{
-			ID:       element.ID,
-			PageID:   element.PageID,
-			Kind:     element.Kind,
-			X:        element.X,
-			Y:        element.Y,
-			W:        element.W,
-			H:        element.H,
-			Rotation: element.Rotation,
-			Z:        element.Z,
-			Payload:  element.Payload,
+	} else {
+		// Validate page belongs to board
+		if err := h.pageService.ValidatePageBelongsToBoard(pageID, boardID); err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendNotFoundError(c, "Page not found")
+			}
+			logger.Errorw("Failed to validate page ownership", "error", err)
+			return utils.SendInternalError(c, "Failed to validate page", nil)
+		}
+
+		// Get page with elements
+		page, err = h.pageService.GetPageByID(pageID)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendNotFoundError(c, "Page not found")
+			}
+			logger.Errorw("Failed to get page", "error", err)
+			return utils.SendInternalError(c, "Failed to get page", nil)
 		}
 	}
 
-	response := dto.PageWithElementsResponse{
-		ID:        page.ID,
-		BoardID:   page.BoardID,
-		Title:     page.Title,
-		Date:      page.Date,
-		OrderIdx:  page.OrderIdx,
-		CreatedAt: page.CreatedAt,
-		UpdatedAt: page.UpdatedAt,
-		Elements:  elementResponses,
-	}
+	response := convertToPageWithElementsResponse(page)
 
 	return c.JSON(fiber.Map{"data": response})
 }
@@ -283,8 +316,7 @@ func (h *PageHandler) UpdatePage(c *fiber.Ctx) error {
 	}
 
 	// Validate edit token and board access
-	editToken := c.Locals("edit_token").(uuid.UUID)
-	if err := h.boardService.ValidateBoardEditAccess(boardID, editToken); err != nil {
+	if err := authorizePageEdit(c, h.boardService, boardID, pageID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
@@ -316,32 +348,37 @@ func (h *PageHandler) UpdatePage(c *fiber.Ctx) error {
 		return utils.SendValidationError(c, err.Error(), nil)
 	}
 
-	// Update page
-	page, err := h.pageService.UpdatePage(pageID, req.Title, req.Date, req.OrderIdx)
+	// Update page, guarded by the version the client last saw
+	expectedVersion, err := parseExpectedVersion(c, req.Version)
+	if err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	page, err := h.pageService.UpdatePage(pageID, req.Title, req.Date, req.OrderIdx, expectedVersion)
 	if err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Page not found")
 		}
+		if err == utils.ErrConflict {
+			logger.Infow("Page update conflict", "pageId", pageID, "expectedVersion", expectedVersion, "currentVersion", page.Version)
+			return utils.SendConflict(c, "Page was modified by someone else", convertToPageResponse(page))
+		}
 		logger.Errorw("Failed to update page", "error", err)
 		return utils.SendInternalError(c, "Failed to update page", nil)
 	}
 
 	// Convert to response DTO
-	response := dto.PageResponse{
-		ID:        page.ID,
-		BoardID:   page.BoardID,
-		Title:     page.Title,
-		Date:      page.Date,
-		OrderIdx:  page.OrderIdx,
-		CreatedAt: page.CreatedAt,
-		UpdatedAt: page.UpdatedAt,
-	}
+	response := convertToPageResponse(page)
+
+	publishLiveEvent(c, h.liveHub, dto.LiveEventPageUpdated, boardID, &pageID, response)
+
+	setVersionETag(c, page.Version)
 
 	logger.Infow("Page updated successfully", "pageId", pageID)
 	return c.JSON(fiber.Map{"data": response})
 }
 
-// DeletePage deletes a page and all its elements
+// DeletePage moves a page and its elements to the trash
 // DELETE /api/v1/boards/:boardId/pages/:pageId
 func (h *PageHandler) DeletePage(c *fiber.Ctx) error {
 	logger := c.Locals("logger").(*utils.Logger)
@@ -363,8 +400,7 @@ func (h *PageHandler) DeletePage(c *fiber.Ctx) error {
 	}
 
 	// Validate edit token and board access
-	editToken := c.Locals("edit_token").(uuid.UUID)
-	if err := h.boardService.ValidateBoardEditAccess(boardID, editToken); err != nil {
+	if err := authorizePageEdit(c, h.boardService, boardID, pageID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Board not found")
 		}
@@ -384,7 +420,7 @@ func (h *PageHandler) DeletePage(c *fiber.Ctx) error {
 		return utils.SendInternalError(c, "Failed to validate page", nil)
 	}
 
-	// Delete page (cascade delete elements)
+	// Move page to the trash
 	if err := h.pageService.DeletePage(pageID); err != nil {
 		if err == utils.ErrNotFound {
 			return utils.SendNotFoundError(c, "Page not found")
@@ -393,6 +429,37 @@ func (h *PageHandler) DeletePage(c *fiber.Ctx) error {
 		return utils.SendInternalError(c, "Failed to delete page", nil)
 	}
 
+	publishLiveEvent(c, h.liveHub, dto.LiveEventPageDeleted, boardID, &pageID, fiber.Map{"id": pageID})
+
 	logger.Infow("Page deleted successfully", "pageId", pageID)
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// convertToPageResponse converts a page model to its response DTO
+func convertToPageResponse(page *models.Page) dto.PageResponse {
+	return dto.PageResponse{
+		ID:        page.ID,
+		BoardID:   page.BoardID,
+		Title:     page.Title,
+		Date:      page.Date,
+		OrderIdx:  page.OrderIdx,
+		Version:   page.Version,
+		CreatedAt: page.CreatedAt,
+		UpdatedAt: page.UpdatedAt,
+	}
+}
+
+// convertToPageWithElementsResponse converts a page model and its elements to a response DTO
+func convertToPageWithElementsResponse(page *models.Page) dto.PageWithElementsResponse {
+	return dto.PageWithElementsResponse{
+		ID:        page.ID,
+		BoardID:   page.BoardID,
+		Title:     page.Title,
+		Date:      page.Date,
+		OrderIdx:  page.OrderIdx,
+		Version:   page.Version,
+		CreatedAt: page.CreatedAt,
+		UpdatedAt: page.UpdatedAt,
+		Elements:  convertToElementResponses(page.Elements),
+	}
+}
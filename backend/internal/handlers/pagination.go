@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"time"
+
+	"junk-journal-board/internal/services"
+)
+
+// listDateLayout is the format of the from and to query parameters of list endpoints
+const listDateLayout = "2006-01-02"
+
+// newListParams builds the pagination parameters of a list request, defaulting the page size
+func newListParams(limit int, cursor, sort, order string) services.ListParams {
+	if limit <= 0 {
+		limit = services.DefaultListLimit
+	}
+	return services.ListParams{
+		Limit:  limit,
+		Cursor: cursor,
+		Sort:   sort,
+		Desc:   order == "desc",
+	}
+}
+
+// nextCursorValue returns the cursor of the next page, or nil on the last page
+func nextCursorValue(cursor string) *string {
+	if cursor == "" {
+		return nil
+	}
+	return &cursor
+}
+
+// newListFilter builds a list filter from a title prefix and an inclusive from/to day range
+func newListFilter(titlePrefix, from, to string) (services.ListFilter, error) {
+	filter := services.ListFilter{TitlePrefix: titlePrefix}
+
+	if from != "" {
+		day, err := time.Parse(listDateLayout, from)
+		if err != nil {
+			return filter, err
+		}
+		filter.From = &day
+	}
+
+	if to != "" {
+		day, err := time.Parse(listDateLayout, to)
+		if err != nil {
+			return filter, err
+		}
+		// Include the whole last day
+		end := day.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PresenceHandler struct {
+	presenceService *services.PresenceService
+	boardService    *services.BoardService
+}
+
+func NewPresenceHandler(db *gorm.DB, presenceService *services.PresenceService) *PresenceHandler {
+	return &PresenceHandler{
+		presenceService: presenceService,
+		boardService:    services.NewBoardService(db),
+	}
+}
+
+// GetPresence returns the current roster of live sessions on a board
+// GET /api/v1/boards/:boardId/presence?page_id=...
+func (h *PresenceHandler) GetPresence(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// A token is required, the roster reveals who is looking at the board
+	token := c.Locals("token")
+	if token == nil {
+		return utils.SendUnauthorized(c, "A board token is required to view presence")
+	}
+
+	if err := h.boardService.ValidateBoardAccess(boardID, token.(uuid.UUID)); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrLinkExpired {
+			return sendLinkExpired(c)
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "Invalid token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
+	}
+
+	// Optional page filter
+	var pageID *uuid.UUID
+	if pageIDStr := c.Query("page_id"); pageIDStr != "" {
+		parsed, err := uuid.Parse(pageIDStr)
+		if err != nil {
+			logger.Warnw("Invalid page ID", "pageId", pageIDStr)
+			return utils.SendValidationError(c, "Invalid page ID format", nil)
+		}
+		pageID = &parsed
+	}
+
+	sessions := h.presenceService.Snapshot(boardID, pageID)
+
+	// Convert to response DTOs
+	sessionResponses := make([]dto.PresenceSessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = services.ConvertPresenceSession(session)
+	}
+
+	response := dto.PresenceRosterResponse{
+		BoardID:  boardID,
+		Count:    len(sessionResponses),
+		Sessions: sessionResponses,
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
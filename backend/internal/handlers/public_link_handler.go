@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ways the view grant of a view-limited public link can be sent, in order of precedence
+const (
+	BoardViewHeader       = "X-Board-View"
+	boardViewCookiePrefix = "board_view_"
+	boardViewQueryParam   = "view_token" // for WebSocket clients, which cannot set headers
+)
+
+type PublicLinkHandler struct {
+	publicLinkService *services.PublicLinkService
+	boardService      *services.BoardService
+}
+
+func NewPublicLinkHandler(db *gorm.DB) *PublicLinkHandler {
+	return &PublicLinkHandler{
+		publicLinkService: services.NewPublicLinkService(db),
+		boardService:      services.NewBoardService(db),
+	}
+}
+
+// GetPublicLink returns the limits and view count of a board's public link
+// GET /api/v1/boards/:boardId/public-link
+func (h *PublicLinkHandler) GetPublicLink(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateManageAccess(c)
+	if !ok {
+		return err
+	}
+
+	board, err := h.publicLinkService.GetPublicLink(boardID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to get public link", "error", err)
+		return utils.SendInternalError(c, "Failed to get public link", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": h.convertToPublicLinkResponse(board)})
+}
+
+// UpdatePublicLink sets the expiry and view limit of a board's public link
+// PUT /api/v1/boards/:boardId/public-link
+func (h *PublicLinkHandler) UpdatePublicLink(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateManageAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.UpdatePublicLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	board, err := h.publicLinkService.UpdatePublicLink(boardID, req.ExpiresAt, req.MaxViews, req.ResetViews)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPublicLink) {
+			return utils.SendValidationError(c, err.Error(), nil)
+		}
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to update public link", "error", err)
+		return utils.SendInternalError(c, "Failed to update public link", nil)
+	}
+
+	logger.Infow("Public link updated", "boardId", boardID)
+	return c.JSON(fiber.Map{"data": h.convertToPublicLinkResponse(board)})
+}
+
+// validateManageAccess parses the board ID and checks that the request may manage the board's
+// public link: a signed-in owner or editor, or the holder of the board's own edit token.
+// When ok is false the error response has already been sent.
+func (h *PublicLinkHandler) validateManageAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	isMember, err := hasMemberRole(c, h.boardService, boardID, models.BoardRoleEditor)
+	if err == nil && !isMember {
+		editToken, _ := c.Locals("edit_token").(uuid.UUID)
+		err = h.boardService.ValidateBoardEditToken(boardID, editToken)
+	}
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// convertToPublicLinkResponse converts a board's public link settings to response DTO
+func (h *PublicLinkHandler) convertToPublicLinkResponse(board *models.Board) dto.PublicLinkResponse {
+	return dto.PublicLinkResponse{
+		BoardID:      board.ID,
+		PublicToken:  board.PublicToken,
+		ExpiresAt:    board.PublicExpiresAt,
+		MaxViews:     board.PublicMaxViews,
+		ViewCount:    board.PublicViewCount,
+		LastViewedAt: board.PublicLastViewedAt,
+		Expired:      !h.publicLinkService.IsOpen(board),
+	}
+}
+
+// viewGrantFromRequest returns the view grant a request carries for a board
+func viewGrantFromRequest(c *fiber.Ctx, boardID uuid.UUID) string {
+	if grant := strings.TrimSpace(c.Get(BoardViewHeader)); grant != "" {
+		return grant
+	}
+	if grant := c.Cookies(boardViewCookiePrefix + boardID.String()); grant != "" {
+		return grant
+	}
+	return c.Query(boardViewQueryParam)
+}
@@ -13,19 +13,21 @@ import (
 )
 
 type RecapHandler struct {
-	recapService *services.RecapService
-	boardService *services.BoardService
+	recapService    *services.RecapService
+	boardService    *services.BoardService
+	snapshotService *services.SnapshotService
 }
 
 func NewRecapHandler(db *gorm.DB) *RecapHandler {
 	return &RecapHandler{
-		recapService: services.NewRecapService(db),
-		boardService: services.NewBoardService(db),
+		recapService:    services.NewRecapService(db),
+		boardService:    services.NewBoardService(db),
+		snapshotService: services.NewSnapshotService(db),
 	}
 }
 
-// GetRecap retrieves recap data for a board with optional date filtering
-// GET /api/v1/boards/:boardId/recap?filter=day|week|month&date=2024-01-15
+// GetRecap retrieves recap data for a board with optional date and tag filtering
+// GET /api/v1/boards/:boardId/recap?filter=day|week|month&date=2024-01-15&tags=travel,gratitude
 func (h *RecapHandler) GetRecap(c *fiber.Ctx) error {
 	logger := c.Locals("logger").(*utils.Logger)
 
@@ -45,6 +47,9 @@ func (h *RecapHandler) GetRecap(c *fiber.Ctx) error {
 			if err == utils.ErrNotFound {
 				return utils.SendNotFoundError(c, "Board not found")
 			}
+			if err == utils.ErrLinkExpired {
+				return sendLinkExpired(c)
+			}
 			if err == utils.ErrUnauthorized {
 				return utils.SendUnauthorizedError(c, "Invalid token")
 			}
@@ -62,6 +67,18 @@ func (h *RecapHandler) GetRecap(c *fiber.Ctx) error {
 		}
 	}
 
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
+	}
+
+	// Readers without the edit token see the pinned snapshot when there is one
+	pinned, err := pinnedBoardForReader(c, h.boardService, h.snapshotService, boardID)
+	if err != nil {
+		logger.Errorw("Failed to get pinned snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+	}
+
 	// Parse query parameters
 	var req dto.RecapRequest
 	if err := c.QueryParser(&req); err != nil {
@@ -91,7 +108,7 @@ func (h *RecapHandler) GetRecap(c *fiber.Ctx) error {
 	}
 
 	// Get recap data
-	recap, err := h.recapService.GetRecapData(boardID, req.Filter, date)
+	recap, err := h.recapService.GetRecapData(boardID, req.Filter, date, services.ParseTagFilter(req.Tags), pinned)
 	if err != nil {
 		logger.Errorw("Failed to get recap data", "error", err)
 		return utils.SendInternalError(c, "Failed to get recap data", nil)
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecurrenceHandler struct {
+	recurrenceService *services.RecurrenceService
+	boardService      *services.BoardService
+}
+
+func NewRecurrenceHandler(db *gorm.DB) *RecurrenceHandler {
+	return &RecurrenceHandler{
+		recurrenceService: services.NewRecurrenceService(db),
+		boardService:      services.NewBoardService(db),
+	}
+}
+
+// GetRecurrence retrieves the recurring page rule of a board
+// GET /api/v1/boards/:boardId/recurrence
+func (h *RecurrenceHandler) GetRecurrence(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	recurrence, err := h.recurrenceService.GetRecurrence(boardID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Recurrence not found")
+		}
+		logger.Errorw("Failed to get recurrence", "error", err)
+		return utils.SendInternalError(c, "Failed to get recurrence", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": convertToRecurrenceResponse(recurrence)})
+}
+
+// SetRecurrence creates or replaces the recurring page rule of a board
+// PUT /api/v1/boards/:boardId/recurrence
+func (h *RecurrenceHandler) SetRecurrence(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.SetRecurrenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	recurrence, created, err := h.recurrenceService.SetRecurrence(boardID, req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRecurrence) {
+			return utils.SendValidationError(c, err.Error(), nil)
+		}
+		if err == services.ErrTemplateNotFound {
+			return utils.SendNotFoundError(c, "Template not found")
+		}
+		logger.Errorw("Failed to save recurrence", "error", err)
+		return utils.SendInternalError(c, "Failed to save recurrence", nil)
+	}
+
+	logger.Infow("Recurrence saved successfully", "boardId", boardID, "frequency", recurrence.Frequency, "pagesCreated", created)
+	return c.JSON(fiber.Map{"data": convertToRecurrenceResponse(recurrence)})
+}
+
+// DeleteRecurrence stops creating recurring pages for a board
+// DELETE /api/v1/boards/:boardId/recurrence
+func (h *RecurrenceHandler) DeleteRecurrence(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.recurrenceService.DeleteRecurrence(boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Recurrence not found")
+		}
+		logger.Errorw("Failed to delete recurrence", "error", err)
+		return utils.SendInternalError(c, "Failed to delete recurrence", nil)
+	}
+
+	logger.Infow("Recurrence deleted successfully", "boardId", boardID)
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// validateEditAccess parses the board ID and checks the edit token.
+// When ok is false the error response has already been sent.
+func (h *RecurrenceHandler) validateEditAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate edit token and board access
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// convertToRecurrenceResponse converts a recurrence model to its response DTO
+func convertToRecurrenceResponse(recurrence *models.PageRecurrence) dto.RecurrenceResponse {
+	return dto.RecurrenceResponse{
+		ID:                 recurrence.ID,
+		BoardID:            recurrence.BoardID,
+		Frequency:          recurrence.Frequency,
+		Interval:           recurrence.Interval,
+		TitlePattern:       recurrence.TitlePattern,
+		StartDate:          recurrence.StartDate,
+		Timezone:           recurrence.Timezone,
+		TemplateID:         recurrence.PageTemplateID,
+		Enabled:            recurrence.Enabled,
+		NextDate:           recurrence.NextDate,
+		LastOccurrenceDate: recurrence.LastOccurrenceDate,
+		CreatedAt:          recurrence.CreatedAt,
+		UpdatedAt:          recurrence.UpdatedAt,
+	}
+}
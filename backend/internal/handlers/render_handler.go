@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"fmt"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RenderHandler struct {
+	renderService   *services.RenderService
+	pageService     *services.PageService
+	boardService    *services.BoardService
+	snapshotService *services.SnapshotService
+}
+
+func NewRenderHandler(db *gorm.DB) *RenderHandler {
+	return &RenderHandler{
+		renderService:   services.NewRenderService(db),
+		pageService:     services.NewPageService(db),
+		boardService:    services.NewBoardService(db),
+		snapshotService: services.NewSnapshotService(db),
+	}
+}
+
+// RenderPage rasterizes a page to PNG or PDF on the server
+// GET /api/v1/boards/:boardId/pages/:pageId/render?format=png|pdf&scale=2
+func (h *RenderHandler) RenderPage(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse IDs from URL
+	boardIDStr := c.Params("boardId")
+	pageIDStr := c.Params("pageId")
+
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	pageID, err := uuid.Parse(pageIDStr)
+	if err != nil {
+		logger.Warnw("Invalid page ID", "pageId", pageIDStr)
+		return utils.SendValidationError(c, "Invalid page ID format", nil)
+	}
+
+	// Validate page access (edit, public and share link tokens, including links scoped to the page)
+	token := c.Locals("token")
+	if token != nil {
+		if err := h.boardService.ValidatePageAccess(boardID, pageID, token.(uuid.UUID)); err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendNotFoundError(c, "Board not found")
+			}
+			if err == utils.ErrLinkExpired {
+				return sendLinkExpired(c)
+			}
+			if err == utils.ErrUnauthorized {
+				return utils.SendUnauthorizedError(c, "Invalid token")
+			}
+			logger.Errorw("Failed to validate board access", "error", err)
+			return utils.SendInternalError(c, "Failed to validate board access", nil)
+		}
+	} else {
+		if err := h.boardService.ValidateBoardExists(boardID); err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendNotFoundError(c, "Board not found")
+			}
+			logger.Errorw("Failed to validate board existence", "error", err)
+			return utils.SendInternalError(c, "Failed to validate board", nil)
+		}
+	}
+
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
+	}
+
+	// Readers without the edit token see the pinned snapshot when there is one
+	pinned, err := pinnedBoardForReader(c, h.boardService, h.snapshotService, boardID)
+	if err != nil {
+		logger.Errorw("Failed to get pinned snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+	}
+
+	// Validate page belongs to board; pages of a pinned snapshot are looked up when rendering
+	if pinned == nil {
+		if err := h.pageService.ValidatePageBelongsToBoard(pageID, boardID); err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendNotFoundError(c, "Page not found")
+			}
+			logger.Errorw("Failed to validate page ownership", "error", err)
+			return utils.SendInternalError(c, "Failed to validate page", nil)
+		}
+	}
+
+	// Parse query parameters
+	var req dto.RenderPageRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	// Apply defaults
+	if req.Format == "" {
+		req.Format = services.RenderFormatPNG
+	}
+	if req.Scale == 0 {
+		req.Scale = 1
+	}
+
+	// Render page
+	data, err := h.renderService.RenderPage(boardID, pageID, req.Format, req.Scale, pinned)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Page not found")
+		}
+		logger.Errorw("Failed to render page", "error", err)
+		return utils.SendInternalError(c, "Failed to render page", nil)
+	}
+
+	contentType := "image/png"
+	if req.Format == services.RenderFormatPDF {
+		contentType = "application/pdf"
+	}
+
+	logger.Infow("Page rendered successfully", "pageId", pageID, "format", req.Format, "scale", req.Scale, "bytes", len(data))
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=\"page-%s.%s\"", pageID, req.Format))
+	return c.Send(data)
+}
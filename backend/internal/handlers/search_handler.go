@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SearchHandler struct {
+	searchService *services.SearchService
+	boardService  *services.BoardService
+}
+
+func NewSearchHandler(db *gorm.DB) *SearchHandler {
+	return &SearchHandler{
+		searchService: services.NewSearchService(db),
+		boardService:  services.NewBoardService(db),
+	}
+}
+
+// SearchBoard runs a full-text search over a board's pages and elements
+// GET /api/v1/boards/:boardId/search?q=...&edit_token=...|public_token=...
+func (h *SearchHandler) SearchBoard(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// A token or board membership is required; both edit and public tokens may search
+	if err := authorizeBoardRead(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrLinkExpired {
+			return sendLinkExpired(c)
+		}
+		if err == utils.ErrUnauthorized {
+			return utils.SendUnauthorizedError(c, "A valid board token is required to search")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return err
+	}
+
+	// Parse query parameters
+	var req dto.SearchRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	// Readers without the edit token search the pinned snapshot when there is one
+	hits, err := h.searchService.Search(boardID, req.Q, req.Limit, !isBoardEditor(c, h.boardService, boardID))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to search board", "error", err)
+		return utils.SendInternalError(c, "Failed to search board", nil)
+	}
+
+	response := dto.SearchResponse{
+		Query: req.Q,
+		Hits:  hits,
+		Total: len(hits),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"errors"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ShareLinkHandler struct {
+	shareLinkService *services.ShareLinkService
+	boardService     *services.BoardService
+	snapshotService  *services.SnapshotService
+	liveHub          *services.LiveHub
+}
+
+func NewShareLinkHandler(db *gorm.DB, liveHub *services.LiveHub) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		shareLinkService: services.NewShareLinkService(db),
+		boardService:     services.NewBoardService(db),
+		snapshotService:  services.NewSnapshotService(db),
+		liveHub:          liveHub,
+	}
+}
+
+// GetShareLinks lists a board's share links
+// GET /api/v1/boards/:boardId/share-links
+func (h *ShareLinkHandler) GetShareLinks(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateManageAccess(c)
+	if !ok {
+		return err
+	}
+
+	links, err := h.shareLinkService.ListShareLinks(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get share links", "error", err)
+		return utils.SendInternalError(c, "Failed to get share links", nil)
+	}
+
+	linkResponses := make([]dto.ShareLinkResponse, len(links))
+	for i := range links {
+		linkResponses[i] = h.convertToShareLinkResponse(&links[i])
+	}
+
+	response := dto.ShareLinksListResponse{
+		ShareLinks: linkResponses,
+		Total:      len(linkResponses),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// CreateShareLink creates a share link with a role, an optional page scope and expiry
+// POST /api/v1/boards/:boardId/share-links
+func (h *ShareLinkHandler) CreateShareLink(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateManageAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.CreateShareLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	link, err := h.shareLinkService.CreateShareLink(boardID, req.Role, req.PageID, req.Label, req.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidShareLink) {
+			return utils.SendValidationError(c, err.Error(), nil)
+		}
+		logger.Errorw("Failed to create share link", "error", err)
+		return utils.SendInternalError(c, "Failed to create share link", nil)
+	}
+
+	logger.Infow("Share link created", "boardId", boardID, "shareLinkId", link.ID, "role", link.Role)
+	return c.Status(201).JSON(fiber.Map{"data": h.convertToShareLinkResponse(link)})
+}
+
+// RevokeShareLink deletes a share link
+// DELETE /api/v1/boards/:boardId/share-links/:linkId
+func (h *ShareLinkHandler) RevokeShareLink(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateManageAccess(c)
+	if !ok {
+		return err
+	}
+
+	linkIDStr := c.Params("linkId")
+	linkID, err := uuid.Parse(linkIDStr)
+	if err != nil {
+		logger.Warnw("Invalid share link ID", "linkId", linkIDStr)
+		return utils.SendValidationError(c, "Invalid share link ID format", nil)
+	}
+
+	if err := h.shareLinkService.RevokeShareLink(boardID, linkID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Share link not found")
+		}
+		logger.Errorw("Failed to revoke share link", "error", err)
+		return utils.SendInternalError(c, "Failed to revoke share link", nil)
+	}
+
+	// Live sessions do not remember their token, so all of them reconnect and the revoked link is turned away
+	if h.liveHub != nil {
+		h.liveHub.DisconnectAll(boardID)
+	}
+
+	logger.Infow("Share link revoked", "boardId", boardID, "shareLinkId", linkID)
+	return c.Status(204).Send(nil)
+}
+
+// GetSharedBoard opens a board through a share link token
+// GET /api/v1/boards/share/:shareToken
+func (h *ShareLinkHandler) GetSharedBoard(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	token, valid := utils.ValidateToken(c.Params("shareToken"))
+	if !valid {
+		return utils.SendValidationError(c, "Invalid share token format", nil)
+	}
+
+	link, err := h.shareLinkService.ResolveShareLink(token)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Share link not found")
+		}
+		logger.Errorw("Failed to resolve share link", "error", err)
+		return utils.SendInternalError(c, "Failed to resolve share link", nil)
+	}
+
+	board, err := h.boardService.GetBoardByID(link.BoardID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to get board", "error", err)
+		return utils.SendInternalError(c, "Failed to get board", nil)
+	}
+
+	// Only editors see the live board when a snapshot is pinned
+	if link.Role != models.ShareRoleEditor && board.PinnedSnapshotID != nil {
+		pinned, err := h.snapshotService.GetPinnedBoard(board.ID)
+		if err != nil {
+			logger.Errorw("Failed to get pinned snapshot", "error", err)
+			return utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+		}
+		if pinned != nil {
+			board = pinned
+		}
+	}
+
+	pages := make([]dto.PageResponse, 0, len(board.Pages))
+	for i := range board.Pages {
+		if link.PageID != nil && board.Pages[i].ID != *link.PageID {
+			continue
+		}
+		pages = append(pages, convertToPageResponse(&board.Pages[i]))
+	}
+
+	response := dto.SharedBoardResponse{
+		ID:          board.ID,
+		Title:       board.Title,
+		Description: board.Description,
+		Skin:        board.Skin,
+		Role:        link.Role,
+		PageID:      link.PageID,
+		Label:       link.Label,
+		ExpiresAt:   link.ExpiresAt,
+		PageCount:   len(pages),
+		Pages:       pages,
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// validateManageAccess parses the board ID and checks that the request may manage share links:
+// the board's own edit token or a signed-in owner or editor. Share links cannot mint other links.
+// When ok is false the error response has already been sent.
+func (h *ShareLinkHandler) validateManageAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	isMember, err := hasMemberRole(c, h.boardService, boardID, models.BoardRoleEditor)
+	if err == nil && !isMember {
+		editToken, _ := c.Locals("edit_token").(uuid.UUID)
+		err = h.boardService.ValidateBoardEditToken(boardID, editToken)
+	}
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// convertToShareLinkResponse converts a share link model to response DTO
+func (h *ShareLinkHandler) convertToShareLinkResponse(link *models.ShareLink) dto.ShareLinkResponse {
+	return dto.ShareLinkResponse{
+		ID:        link.ID,
+		Token:     knownToken(link.Token),
+		Role:      link.Role,
+		PageID:    link.PageID,
+		Label:     link.Label,
+		ExpiresAt: link.ExpiresAt,
+		Expired:   h.shareLinkService.IsExpired(link),
+		CreatedAt: link.CreatedAt,
+	}
+}
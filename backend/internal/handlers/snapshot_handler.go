@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SnapshotHandler struct {
+	snapshotService *services.SnapshotService
+	boardService    *services.BoardService
+	liveHub         *services.LiveHub
+}
+
+func NewSnapshotHandler(db *gorm.DB, liveHub *services.LiveHub) *SnapshotHandler {
+	return &SnapshotHandler{
+		snapshotService: services.NewSnapshotService(db),
+		boardService:    services.NewBoardService(db),
+		liveHub:         liveHub,
+	}
+}
+
+// CreateSnapshot freezes the current board under a name
+// POST /api/v1/boards/:boardId/snapshots
+func (h *SnapshotHandler) CreateSnapshot(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.CreateSnapshotRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	snapshot, err := h.snapshotService.CreateSnapshot(boardID, req.Name)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to create snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to create snapshot", nil)
+	}
+
+	logger.Infow("Snapshot created successfully", "snapshotId", snapshot.ID, "boardId", boardID)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": convertToSnapshotResponse(snapshot, nil)})
+}
+
+// GetSnapshots lists a board's snapshots, newest first
+// GET /api/v1/boards/:boardId/snapshots
+func (h *SnapshotHandler) GetSnapshots(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	board, err := h.boardService.GetBoardByID(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get board", "error", err)
+		return utils.SendInternalError(c, "Failed to get board", nil)
+	}
+
+	snapshots, err := h.snapshotService.ListSnapshots(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get snapshots", "error", err)
+		return utils.SendInternalError(c, "Failed to get snapshots", nil)
+	}
+
+	snapshotResponses := make([]dto.SnapshotResponse, len(snapshots))
+	for i := range snapshots {
+		snapshotResponses[i] = convertToSnapshotResponse(&snapshots[i], board.PinnedSnapshotID)
+	}
+
+	response := dto.SnapshotsListResponse{
+		Snapshots: snapshotResponses,
+		Total:     len(snapshotResponses),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// GetSnapshot retrieves a snapshot with the pages and elements it captured
+// GET /api/v1/boards/:boardId/snapshots/:snapshotId
+func (h *SnapshotHandler) GetSnapshot(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, snapshotID, ok, err := h.validateSnapshotEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	board, err := h.boardService.GetBoardByID(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get board", "error", err)
+		return utils.SendInternalError(c, "Failed to get board", nil)
+	}
+
+	snapshot, content, err := h.snapshotService.GetSnapshot(boardID, snapshotID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Snapshot not found")
+		}
+		logger.Errorw("Failed to get snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to get snapshot", nil)
+	}
+
+	response := dto.SnapshotDetailResponse{
+		Snapshot: convertToSnapshotResponse(snapshot, board.PinnedSnapshotID),
+		Board:    *content,
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// DiffSnapshot compares a snapshot with the live board or another snapshot
+// GET /api/v1/boards/:boardId/snapshots/:snapshotId/diff?against=live|<snapshotId>
+func (h *SnapshotHandler) DiffSnapshot(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, snapshotID, ok, err := h.validateSnapshotEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse query parameters
+	var req dto.SnapshotDiffRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Warnw("Failed to parse query parameters", "error", err)
+		return utils.SendValidationError(c, "Invalid query parameters", nil)
+	}
+
+	var againstID *uuid.UUID
+	if req.Against != "" && req.Against != services.SnapshotLive {
+		parsedID, err := uuid.Parse(req.Against)
+		if err != nil {
+			logger.Warnw("Invalid against snapshot ID", "against", req.Against)
+			return utils.SendValidationError(c, "against must be \"live\" or a snapshot ID", nil)
+		}
+		againstID = &parsedID
+	}
+
+	diff, err := h.snapshotService.DiffSnapshot(boardID, snapshotID, againstID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Snapshot not found")
+		}
+		logger.Errorw("Failed to diff snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to diff snapshot", nil)
+	}
+
+	return c.JSON(fiber.Map{"data": diff})
+}
+
+// RestoreSnapshot restores a snapshot in place or as a new board
+// POST /api/v1/boards/:boardId/snapshots/:snapshotId/restore
+func (h *SnapshotHandler) RestoreSnapshot(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, snapshotID, ok, err := h.validateSnapshotEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.RestoreSnapshotRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Warnw("Failed to parse request body", "error", err)
+			return utils.SendValidationError(c, "Invalid request body", nil)
+		}
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	if req.Mode == dto.SnapshotRestoreNewBoard {
+		board, err := h.snapshotService.RestoreAsNewBoard(boardID, snapshotID)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				return utils.SendNotFoundError(c, "Snapshot not found")
+			}
+			logger.Errorw("Failed to restore snapshot as new board", "error", err)
+			return utils.SendInternalError(c, "Failed to restore snapshot", nil)
+		}
+
+		logger.Infow("Snapshot restored as new board", "snapshotId", snapshotID, "boardId", board.ID)
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": convertToCreateBoardResponse(board)})
+	}
+
+	board, err := h.snapshotService.RestoreInPlace(boardID, snapshotID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Snapshot not found")
+		}
+		logger.Errorw("Failed to restore snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to restore snapshot", nil)
+	}
+
+	response := convertToBoardResponse(board)
+
+	// Too many changes for fine-grained events; live clients reload the board instead
+	publishLiveEvent(c, h.liveHub, dto.LiveEventBoardRestored, boardID, nil, fiber.Map{"snapshot_id": snapshotID})
+
+	logger.Infow("Snapshot restored in place", "snapshotId", snapshotID, "boardId", boardID)
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// PinSnapshot makes readers without the edit token see a snapshot instead of the live board
+// PUT /api/v1/boards/:boardId/snapshots/pinned
+func (h *SnapshotHandler) PinSnapshot(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.PinSnapshotRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	if err := h.snapshotService.PinSnapshot(boardID, req.SnapshotID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Snapshot not found")
+		}
+		logger.Errorw("Failed to pin snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to pin snapshot", nil)
+	}
+
+	board, err := h.boardService.GetBoardByID(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get board", "error", err)
+		return utils.SendInternalError(c, "Failed to get board", nil)
+	}
+
+	// Readers on the live channel reconnect to the snapshot they see now
+	if h.liveHub != nil {
+		h.liveHub.DisconnectReaders(boardID)
+	}
+
+	logger.Infow("Pinned snapshot updated", "boardId", boardID, "snapshotId", req.SnapshotID)
+	return c.JSON(fiber.Map{"data": convertToBoardResponse(board)})
+}
+
+// DeleteSnapshot deletes a snapshot
+// DELETE /api/v1/boards/:boardId/snapshots/:snapshotId
+func (h *SnapshotHandler) DeleteSnapshot(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, snapshotID, ok, err := h.validateSnapshotEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.snapshotService.DeleteSnapshot(boardID, snapshotID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Snapshot not found")
+		}
+		logger.Errorw("Failed to delete snapshot", "error", err)
+		return utils.SendInternalError(c, "Failed to delete snapshot", nil)
+	}
+
+	// Readers of a deleted pinned snapshot fall back to the live board
+	if h.liveHub != nil {
+		h.liveHub.DisconnectReaders(boardID)
+	}
+
+	logger.Infow("Snapshot deleted successfully", "snapshotId", snapshotID)
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// validateEditAccess parses the board ID and checks the edit token.
+// When ok is false the error response has already been sent.
+func (h *SnapshotHandler) validateEditAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate edit token and board access
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// validateSnapshotEditAccess is validateEditAccess plus parsing the snapshot ID
+func (h *SnapshotHandler) validateSnapshotEditAccess(c *fiber.Ctx) (boardID, snapshotID uuid.UUID, ok bool, err error) {
+	boardID, ok, err = h.validateEditAccess(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false, err
+	}
+
+	// Parse snapshot ID from URL
+	snapshotIDStr := c.Params("snapshotId")
+	snapshotID, err = uuid.Parse(snapshotIDStr)
+	if err != nil {
+		c.Locals("logger").(*utils.Logger).Warnw("Invalid snapshot ID", "snapshotId", snapshotIDStr)
+		return uuid.Nil, uuid.Nil, false, utils.SendValidationError(c, "Invalid snapshot ID format", nil)
+	}
+
+	return boardID, snapshotID, true, nil
+}
+
+// pinnedBoardForReader returns the pinned snapshot of a board for requests without an edit token
+// or editor membership, or nil when the live board should be served
+func pinnedBoardForReader(c *fiber.Ctx, boardService *services.BoardService, snapshotService *services.SnapshotService, boardID uuid.UUID) (*models.Board, error) {
+	if isBoardEditor(c, boardService, boardID) {
+		return nil, nil
+	}
+	return snapshotService.GetPinnedBoard(boardID)
+}
+
+// findPinnedPage returns a page of a pinned snapshot by ID
+func findPinnedPage(board *models.Board, pageID uuid.UUID) *models.Page {
+	for i := range board.Pages {
+		if board.Pages[i].ID == pageID {
+			return &board.Pages[i]
+		}
+	}
+	return nil
+}
+
+// convertToSnapshotResponse converts a snapshot model to its response DTO
+func convertToSnapshotResponse(snapshot *models.BoardSnapshot, pinnedSnapshotID *uuid.UUID) dto.SnapshotResponse {
+	return dto.SnapshotResponse{
+		ID:           snapshot.ID,
+		BoardID:      snapshot.BoardID,
+		Name:         snapshot.Name,
+		PageCount:    snapshot.PageCount,
+		ElementCount: snapshot.ElementCount,
+		Pinned:       pinnedSnapshotID != nil && *pinnedSnapshotID == snapshot.ID,
+		CreatedAt:    snapshot.CreatedAt,
+	}
+}
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"errors"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TagHandler struct {
+	tagService      *services.TagService
+	boardService    *services.BoardService
+	snapshotService *services.SnapshotService
+}
+
+func NewTagHandler(db *gorm.DB) *TagHandler {
+	return &TagHandler{
+		tagService:      services.NewTagService(db),
+		boardService:    services.NewBoardService(db),
+		snapshotService: services.NewSnapshotService(db),
+	}
+}
+
+// GetTags lists a board's tags with usage counts
+// GET /api/v1/boards/:boardId/tags
+func (h *TagHandler) GetTags(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, pinned, ok, err := h.validateReadAccess(c)
+	if !ok {
+		return err
+	}
+
+	tags, err := h.tagService.ListTags(boardID, pinned)
+	if err != nil {
+		logger.Errorw("Failed to get tags", "error", err)
+		return utils.SendInternalError(c, "Failed to get tags", nil)
+	}
+
+	tagResponses := make([]dto.TagResponse, len(tags))
+	for i := range tags {
+		tagResponses[i] = convertToTagResponse(&tags[i])
+	}
+
+	response := dto.TagsListResponse{
+		Tags:  tagResponses,
+		Total: len(tagResponses),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// GetTag retrieves a tag with the pages and elements it is attached to
+// GET /api/v1/boards/:boardId/tags/:tagId
+func (h *TagHandler) GetTag(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, pinned, ok, err := h.validateReadAccess(c)
+	if !ok {
+		return err
+	}
+
+	tagID, ok, err := parseTagID(c)
+	if !ok {
+		return err
+	}
+
+	tag, pageIDs, elementIDs, err := h.tagService.GetTag(boardID, tagID, pinned)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Tag not found")
+		}
+		logger.Errorw("Failed to get tag", "error", err)
+		return utils.SendInternalError(c, "Failed to get tag", nil)
+	}
+
+	response := dto.TagDetailResponse{
+		Tag:        convertToTagResponse(tag),
+		PageIDs:    pageIDs,
+		ElementIDs: elementIDs,
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// CreateTag creates a tag on a board
+// POST /api/v1/boards/:boardId/tags
+func (h *TagHandler) CreateTag(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.CreateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	tag, err := h.tagService.CreateTag(boardID, req.Name, req.Color)
+	if err != nil {
+		return h.sendTagError(c, err, "Failed to create tag")
+	}
+
+	logger.Infow("Tag created successfully", "tagId", tag.ID, "boardId", boardID)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": convertToTagResponse(tag)})
+}
+
+// UpdateTag renames or recolors a tag
+// PUT /api/v1/boards/:boardId/tags/:tagId
+func (h *TagHandler) UpdateTag(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	tagID, ok, err := parseTagID(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.UpdateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	tag, err := h.tagService.UpdateTag(boardID, tagID, req.Name, req.Color)
+	if err != nil {
+		return h.sendTagError(c, err, "Failed to update tag")
+	}
+
+	logger.Infow("Tag updated successfully", "tagId", tagID)
+	return c.JSON(fiber.Map{"data": convertToTagResponse(tag)})
+}
+
+// DeleteTag deletes a tag and detaches it everywhere
+// DELETE /api/v1/boards/:boardId/tags/:tagId
+func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	tagID, ok, err := parseTagID(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.tagService.DeleteTag(boardID, tagID); err != nil {
+		return h.sendTagError(c, err, "Failed to delete tag")
+	}
+
+	logger.Infow("Tag deleted successfully", "tagId", tagID)
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// TagPage attaches a tag to a page
+// POST /api/v1/boards/:boardId/tags/:tagId/pages/:pageId
+func (h *TagHandler) TagPage(c *fiber.Ctx) error {
+	return h.updateLink(c, "pageId", "page", h.tagService.TagPage)
+}
+
+// UntagPage detaches a tag from a page
+// DELETE /api/v1/boards/:boardId/tags/:tagId/pages/:pageId
+func (h *TagHandler) UntagPage(c *fiber.Ctx) error {
+	return h.updateLink(c, "pageId", "page", h.tagService.UntagPage)
+}
+
+// TagElement attaches a tag to an element
+// POST /api/v1/boards/:boardId/tags/:tagId/elements/:elementId
+func (h *TagHandler) TagElement(c *fiber.Ctx) error {
+	return h.updateLink(c, "elementId", "element", h.tagService.TagElement)
+}
+
+// UntagElement detaches a tag from an element
+// DELETE /api/v1/boards/:boardId/tags/:tagId/elements/:elementId
+func (h *TagHandler) UntagElement(c *fiber.Ctx) error {
+	return h.updateLink(c, "elementId", "element", h.tagService.UntagElement)
+}
+
+// updateLink parses the IDs of a tag link request and applies the given link change
+func (h *TagHandler) updateLink(c *fiber.Ctx, targetParam, targetName string, apply func(boardID, tagID, targetID uuid.UUID) error) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	tagID, ok, err := parseTagID(c)
+	if !ok {
+		return err
+	}
+
+	targetIDStr := c.Params(targetParam)
+	targetID, err := uuid.Parse(targetIDStr)
+	if err != nil {
+		logger.Warnw("Invalid "+targetName+" ID", targetParam, targetIDStr)
+		return utils.SendValidationError(c, "Invalid "+targetName+" ID format", nil)
+	}
+
+	if err := apply(boardID, tagID, targetID); err != nil {
+		if err == services.ErrTagTargetNotFound {
+			return utils.SendNotFoundError(c, "Tagged "+targetName+" not found")
+		}
+		return h.sendTagError(c, err, "Failed to update tag")
+	}
+
+	logger.Infow("Tag link updated", "tagId", tagID, targetParam, targetID, "method", c.Method())
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// sendTagError maps tag service errors to responses
+func (h *TagHandler) sendTagError(c *fiber.Ctx, err error, message string) error {
+	if err == utils.ErrNotFound {
+		return utils.SendNotFoundError(c, "Tag not found")
+	}
+	if err == services.ErrTagExists {
+		return utils.SendConflict(c, "A tag with this name already exists", nil)
+	}
+	if errors.Is(err, services.ErrInvalidTag) {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+	c.Locals("logger").(*utils.Logger).Errorw(message, "error", err)
+	return utils.SendInternalError(c, message, nil)
+}
+
+// validateReadAccess parses the board ID, checks a read token when one is given and returns the
+// pinned snapshot the reader sees, if any. When ok is false the error response has already been sent.
+func (h *TagHandler) validateReadAccess(c *fiber.Ctx) (boardID uuid.UUID, pinned *models.Board, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate board access (both edit and public tokens are allowed for reading)
+	if token := c.Locals("token"); token != nil {
+		err = h.boardService.ValidateBoardAccess(boardID, token.(uuid.UUID))
+	} else {
+		err = h.boardService.ValidateBoardExists(boardID)
+	}
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrLinkExpired {
+			return uuid.Nil, nil, false, sendLinkExpired(c)
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, nil, false, utils.SendUnauthorizedError(c, "Invalid token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	// Public readers are held to the passphrase and limits of the public link
+	if ok, err := ensurePublicReadAllowed(c, h.boardService, boardID); !ok {
+		return uuid.Nil, nil, false, err
+	}
+
+	// Readers without the edit token see the pinned snapshot when there is one
+	pinned, err = pinnedBoardForReader(c, h.boardService, h.snapshotService, boardID)
+	if err != nil {
+		logger.Errorw("Failed to get pinned snapshot", "error", err)
+		return uuid.Nil, nil, false, utils.SendInternalError(c, "Failed to get pinned snapshot", nil)
+	}
+
+	return boardID, pinned, true, nil
+}
+
+// validateEditAccess parses the board ID and checks the edit token.
+// When ok is false the error response has already been sent.
+func (h *TagHandler) validateEditAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate edit token and board access
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// parseTagID parses the tag ID from the URL.
+// When ok is false the error response has already been sent.
+func parseTagID(c *fiber.Ctx) (uuid.UUID, bool, error) {
+	tagIDStr := c.Params("tagId")
+	tagID, err := uuid.Parse(tagIDStr)
+	if err != nil {
+		c.Locals("logger").(*utils.Logger).Warnw("Invalid tag ID", "tagId", tagIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid tag ID format", nil)
+	}
+
+	return tagID, true, nil
+}
+
+// convertToTagResponse converts a tag with usage counts to its response DTO
+func convertToTagResponse(tag *services.TagUsage) dto.TagResponse {
+	return dto.TagResponse{
+		ID:           tag.ID,
+		BoardID:      tag.BoardID,
+		Name:         tag.Name,
+		Color:        tag.Color,
+		PageCount:    tag.PageCount,
+		ElementCount: tag.ElementCount,
+		CreatedAt:    tag.CreatedAt,
+		UpdatedAt:    tag.UpdatedAt,
+	}
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TemplateHandler struct {
+	templateService *services.TemplateService
+	boardService    *services.BoardService
+}
+
+func NewTemplateHandler(db *gorm.DB) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: services.NewTemplateService(db),
+		boardService:    services.NewBoardService(db),
+	}
+}
+
+// CreateTemplate saves a page of the board as a template
+// POST /api/v1/boards/:boardId/templates
+func (h *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.CreateTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	global := req.Scope == dto.TemplateScopeGlobal
+	template, err := h.templateService.CreateTemplate(boardID, req.PageID, req.Name, req.Description, global)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Page not found")
+		}
+		logger.Errorw("Failed to create template", "error", err)
+		return utils.SendInternalError(c, "Failed to create template", nil)
+	}
+
+	logger.Infow("Template created successfully", "templateId", template.ID, "boardId", boardID, "global", global)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": convertToTemplateResponse(template)})
+}
+
+// GetTemplates lists the global templates and those scoped to the board
+// GET /api/v1/boards/:boardId/templates
+func (h *TemplateHandler) GetTemplates(c *fiber.Ctx) error {
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	return h.sendTemplates(c, &boardID)
+}
+
+// GetGlobalTemplates lists the templates available to every board
+// GET /api/v1/templates
+func (h *TemplateHandler) GetGlobalTemplates(c *fiber.Ctx) error {
+	return h.sendTemplates(c, nil)
+}
+
+// GetTemplate retrieves a template with its element layout
+// GET /api/v1/boards/:boardId/templates/:templateId
+func (h *TemplateHandler) GetTemplate(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, templateID, ok, err := h.validateTemplateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	template, elements, err := h.templateService.GetTemplate(&boardID, templateID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Template not found")
+		}
+		logger.Errorw("Failed to get template", "error", err)
+		return utils.SendInternalError(c, "Failed to get template", nil)
+	}
+
+	response := dto.TemplateDetailResponse{
+		Template: convertToTemplateResponse(template),
+		Elements: elements,
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// DeleteTemplate deletes a board template or a global template created from the board
+// DELETE /api/v1/boards/:boardId/templates/:templateId
+func (h *TemplateHandler) DeleteTemplate(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, templateID, ok, err := h.validateTemplateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	if err := h.templateService.DeleteTemplate(boardID, templateID); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Template not found")
+		}
+		logger.Errorw("Failed to delete template", "error", err)
+		return utils.SendInternalError(c, "Failed to delete template", nil)
+	}
+
+	logger.Infow("Template deleted successfully", "templateId", templateID)
+	return c.Status(fiber.StatusNoContent).Send(nil)
+}
+
+// sendTemplates responds with the templates usable on a board, or the global ones when boardID is nil
+func (h *TemplateHandler) sendTemplates(c *fiber.Ctx, boardID *uuid.UUID) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	templates, err := h.templateService.ListTemplates(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get templates", "error", err)
+		return utils.SendInternalError(c, "Failed to get templates", nil)
+	}
+
+	templateResponses := make([]dto.TemplateResponse, len(templates))
+	for i := range templates {
+		templateResponses[i] = convertToTemplateResponse(&templates[i])
+	}
+
+	response := dto.TemplatesListResponse{
+		Templates: templateResponses,
+		Total:     len(templateResponses),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// validateEditAccess parses the board ID and checks the edit token.
+// When ok is false the error response has already been sent.
+func (h *TemplateHandler) validateEditAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate edit token and board access
+	if err := authorizeBoardEdit(c, h.boardService, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
+
+// validateTemplateEditAccess is validateEditAccess plus parsing the template ID
+func (h *TemplateHandler) validateTemplateEditAccess(c *fiber.Ctx) (boardID, templateID uuid.UUID, ok bool, err error) {
+	boardID, ok, err = h.validateEditAccess(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false, err
+	}
+
+	// Parse template ID from URL
+	templateIDStr := c.Params("templateId")
+	templateID, err = uuid.Parse(templateIDStr)
+	if err != nil {
+		c.Locals("logger").(*utils.Logger).Warnw("Invalid template ID", "templateId", templateIDStr)
+		return uuid.Nil, uuid.Nil, false, utils.SendValidationError(c, "Invalid template ID format", nil)
+	}
+
+	return boardID, templateID, true, nil
+}
+
+// convertToTemplateResponse converts a page template model to its response DTO
+func convertToTemplateResponse(template *models.PageTemplate) dto.TemplateResponse {
+	scope := dto.TemplateScopeGlobal
+	if template.BoardID != nil {
+		scope = dto.TemplateScopeBoard
+	}
+
+	return dto.TemplateResponse{
+		ID:           template.ID,
+		BoardID:      template.BoardID,
+		Scope:        scope,
+		Name:         template.Name,
+		Description:  template.Description,
+		ElementCount: template.ElementCount,
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
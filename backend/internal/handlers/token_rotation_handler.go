@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TokenRotationHandler struct {
+	tokenRotationService *services.TokenRotationService
+	boardService         *services.BoardService
+	liveHub              *services.LiveHub
+}
+
+func NewTokenRotationHandler(db *gorm.DB, liveHub *services.LiveHub) *TokenRotationHandler {
+	return &TokenRotationHandler{
+		tokenRotationService: services.NewTokenRotationService(db),
+		boardService:         services.NewBoardService(db),
+		liveHub:              liveHub,
+	}
+}
+
+// RotateToken replaces a board's edit or public token
+// POST /api/v1/boards/:boardId/tokens/rotate
+func (h *TokenRotationHandler) RotateToken(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, editToken, ok, err := h.parseRotationAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var req dto.RotateBoardTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Warnw("Failed to parse request body", "error", err)
+		return utils.SendValidationError(c, "Invalid request body", nil)
+	}
+
+	// Validate request
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.SendValidationError(c, err.Error(), nil)
+	}
+
+	var userID *uuid.UUID
+	if id, ok := c.Locals("user_id").(uuid.UUID); ok {
+		userID = &id
+	}
+
+	gracePeriod := time.Duration(req.GracePeriodSeconds) * time.Second
+	board, rotation, err := h.tokenRotationService.RotateToken(boardID, req.Kind, editToken, gracePeriod, req.Reason, userID, c.IP())
+	if err != nil {
+		return h.sendRotationError(c, err, "Failed to rotate board token")
+	}
+
+	// Live sessions opened with the retired token end when it stops working: at once without a
+	// grace period, otherwise when the grace period ends
+	if rotation.GraceUntil == nil {
+		h.disconnectLiveSessions(boardID)
+	} else {
+		time.AfterFunc(time.Until(*rotation.GraceUntil), func() { h.disconnectLiveSessions(boardID) })
+	}
+
+	logger.Infow("Board token rotated", "boardId", boardID, "kind", rotation.Kind, "graceUntil", rotation.GraceUntil)
+
+	response := dto.RotateBoardTokenResponse{
+		CreateBoardResponse: convertToCreateBoardResponse(board),
+		Rotation:            h.convertToTokenRotationResponse(rotation),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// EndGracePeriods makes the board's retired tokens stop working immediately
+// DELETE /api/v1/boards/:boardId/tokens/grace
+func (h *TokenRotationHandler) EndGracePeriods(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, editToken, ok, err := h.parseRotationAccess(c)
+	if !ok {
+		return err
+	}
+
+	revoked, err := h.tokenRotationService.EndGracePeriods(boardID, editToken)
+	if err != nil {
+		return h.sendRotationError(c, err, "Failed to revoke retired tokens")
+	}
+
+	if revoked > 0 {
+		h.disconnectLiveSessions(boardID)
+	}
+
+	logger.Infow("Retired board tokens revoked", "boardId", boardID, "revoked", revoked)
+	return c.JSON(fiber.Map{"data": dto.EndGracePeriodsResponse{Revoked: revoked}})
+}
+
+// GetRotations lists the token rotation audit trail of a board
+// GET /api/v1/boards/:boardId/tokens/rotations
+func (h *TokenRotationHandler) GetRotations(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err := uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Owners and edit token holders may read the audit trail, share links may not
+	isOwner, err := hasMemberRole(c, h.boardService, boardID, models.BoardRoleOwner)
+	if err == nil && !isOwner {
+		editToken, _ := c.Locals("edit_token").(uuid.UUID)
+		err = h.boardService.ValidateBoardEditToken(boardID, editToken)
+	}
+	if err != nil {
+		return h.sendRotationError(c, err, "Failed to validate board access")
+	}
+
+	rotations, err := h.tokenRotationService.ListRotations(boardID)
+	if err != nil {
+		logger.Errorw("Failed to get token rotations", "error", err)
+		return utils.SendInternalError(c, "Failed to get token rotations", nil)
+	}
+
+	rotationResponses := make([]dto.TokenRotationResponse, len(rotations))
+	for i := range rotations {
+		rotationResponses[i] = h.convertToTokenRotationResponse(&rotations[i])
+	}
+
+	response := dto.TokenRotationsListResponse{
+		Rotations: rotationResponses,
+		Total:     len(rotationResponses),
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// parseRotationAccess parses the board ID and works out who may change the board's tokens:
+// a signed-in owner, or the holder of the current edit token which the service checks while
+// holding the board lock. editToken is nil for owners. When ok is false the error response
+// has already been sent.
+func (h *TokenRotationHandler) parseRotationAccess(c *fiber.Ctx) (boardID uuid.UUID, editToken *uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	isOwner, err := hasMemberRole(c, h.boardService, boardID, models.BoardRoleOwner)
+	if err != nil {
+		return uuid.Nil, nil, false, h.sendRotationError(c, err, "Failed to validate board access")
+	}
+	if isOwner {
+		return boardID, nil, true, nil
+	}
+
+	token, found := c.Locals("edit_token").(uuid.UUID)
+	if !found {
+		return uuid.Nil, nil, false, utils.SendUnauthorized(c, "The board's current edit token is required for this operation")
+	}
+
+	return boardID, &token, true, nil
+}
+
+// disconnectLiveSessions closes the board's live sessions, which do not remember the token they
+// were opened with, so that only those still holding a working token can reconnect
+func (h *TokenRotationHandler) disconnectLiveSessions(boardID uuid.UUID) {
+	if h.liveHub != nil {
+		h.liveHub.DisconnectAll(boardID)
+	}
+}
+
+// sendRotationError maps token rotation errors to responses
+func (h *TokenRotationHandler) sendRotationError(c *fiber.Ctx, err error, message string) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	if err == utils.ErrNotFound {
+		return utils.SendNotFoundError(c, "Board not found")
+	}
+	if err == utils.ErrUnauthorized {
+		return utils.SendUnauthorizedError(c, "Invalid edit token")
+	}
+	logger.Errorw(message, "error", err)
+	return utils.SendInternalError(c, message, nil)
+}
+
+// convertToTokenRotationResponse converts a token rotation model to response DTO
+func (h *TokenRotationHandler) convertToTokenRotationResponse(rotation *models.BoardTokenRotation) dto.TokenRotationResponse {
+	return dto.TokenRotationResponse{
+		ID:            rotation.ID,
+		Kind:          rotation.Kind,
+		GraceUntil:    rotation.GraceUntil,
+		InGracePeriod: h.tokenRotationService.IsInGracePeriod(rotation),
+		Reason:        rotation.Reason,
+		RotatedBy:     rotation.RotatedBy,
+		ClientIP:      rotation.ClientIP,
+		CreatedAt:     rotation.CreatedAt,
+	}
+}
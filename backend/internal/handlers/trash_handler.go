@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TrashHandler struct {
+	trashService *services.TrashService
+	liveHub      *services.LiveHub
+}
+
+func NewTrashHandler(db *gorm.DB, liveHub *services.LiveHub) *TrashHandler {
+	return &TrashHandler{
+		trashService: services.NewTrashService(db),
+		liveHub:      liveHub,
+	}
+}
+
+// GetTrash lists the trashed items of a board, including the board itself
+// GET /api/v1/boards/:boardId/trash
+func (h *TrashHandler) GetTrash(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	trash, err := h.trashService.ListTrash(boardID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board not found")
+		}
+		logger.Errorw("Failed to get trash", "error", err)
+		return utils.SendInternalError(c, "Failed to get trash", nil)
+	}
+
+	response := dto.TrashResponse{
+		Pages:    make([]dto.TrashedPageResponse, len(trash.Pages)),
+		Elements: make([]dto.TrashedElementResponse, len(trash.Elements)),
+	}
+	if trash.Board != nil {
+		deletedAt := trash.Board.DeletedAt.Time
+		response.Board = &dto.TrashedBoardResponse{
+			Board:     convertToBoardResponse(trash.Board),
+			DeletedAt: deletedAt,
+			PurgeAt:   h.trashService.PurgeAt(deletedAt),
+		}
+	}
+	for i := range trash.Pages {
+		deletedAt := trash.Pages[i].DeletedAt.Time
+		response.Pages[i] = dto.TrashedPageResponse{
+			Page:      convertToPageResponse(&trash.Pages[i]),
+			DeletedAt: deletedAt,
+			PurgeAt:   h.trashService.PurgeAt(deletedAt),
+		}
+	}
+	for i := range trash.Elements {
+		deletedAt := trash.Elements[i].DeletedAt.Time
+		response.Elements[i] = dto.TrashedElementResponse{
+			Element:   convertToElementResponse(&trash.Elements[i]),
+			DeletedAt: deletedAt,
+			PurgeAt:   h.trashService.PurgeAt(deletedAt),
+		}
+	}
+
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// RestoreBoard takes a board out of the trash
+// POST /api/v1/boards/:boardId/restore
+func (h *TrashHandler) RestoreBoard(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	board, err := h.trashService.RestoreBoard(boardID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Board is not in the trash")
+		}
+		logger.Errorw("Failed to restore board", "error", err)
+		return utils.SendInternalError(c, "Failed to restore board", nil)
+	}
+
+	logger.Infow("Board restored successfully", "boardId", boardID)
+	return c.JSON(fiber.Map{"data": convertToBoardResponse(board)})
+}
+
+// RestorePage takes a page and its elements out of the trash
+// POST /api/v1/boards/:boardId/trash/pages/:pageId/restore
+func (h *TrashHandler) RestorePage(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse page ID from URL
+	pageIDStr := c.Params("pageId")
+	pageID, err := uuid.Parse(pageIDStr)
+	if err != nil {
+		logger.Warnw("Invalid page ID", "pageId", pageIDStr)
+		return utils.SendValidationError(c, "Invalid page ID format", nil)
+	}
+
+	page, err := h.trashService.RestorePage(boardID, pageID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Page is not in the trash")
+		}
+		if err == services.ErrParentTrashed {
+			return utils.SendConflict(c, "Restore the board before restoring its pages", nil)
+		}
+		logger.Errorw("Failed to restore page", "error", err)
+		return utils.SendInternalError(c, "Failed to restore page", nil)
+	}
+
+	response := convertToPageResponse(page)
+
+	publishLiveEvent(c, h.liveHub, dto.LiveEventPageCreated, boardID, &page.ID, response)
+
+	logger.Infow("Page restored successfully", "pageId", pageID)
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// RestoreElement takes an element out of the trash
+// POST /api/v1/boards/:boardId/trash/elements/:elementId/restore
+func (h *TrashHandler) RestoreElement(c *fiber.Ctx) error {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	boardID, ok, err := h.validateEditAccess(c)
+	if !ok {
+		return err
+	}
+
+	// Parse element ID from URL
+	elementIDStr := c.Params("elementId")
+	elementID, err := uuid.Parse(elementIDStr)
+	if err != nil {
+		logger.Warnw("Invalid element ID", "elementId", elementIDStr)
+		return utils.SendValidationError(c, "Invalid element ID format", nil)
+	}
+
+	element, err := h.trashService.RestoreElement(boardID, elementID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return utils.SendNotFoundError(c, "Element is not in the trash")
+		}
+		if err == services.ErrParentTrashed {
+			return utils.SendConflict(c, "Restore the page and board before restoring their elements", nil)
+		}
+		logger.Errorw("Failed to restore element", "error", err)
+		return utils.SendInternalError(c, "Failed to restore element", nil)
+	}
+
+	response := convertToElementResponse(element)
+
+	publishLiveEvent(c, h.liveHub, dto.LiveEventElementCreated, boardID, &element.PageID, response)
+
+	logger.Infow("Element restored successfully", "elementId", elementID)
+	return c.JSON(fiber.Map{"data": response})
+}
+
+// validateEditAccess parses the board ID and checks the edit token, allowing trashed boards.
+// When ok is false the error response has already been sent.
+func (h *TrashHandler) validateEditAccess(c *fiber.Ctx) (boardID uuid.UUID, ok bool, err error) {
+	logger := c.Locals("logger").(*utils.Logger)
+
+	// Parse board ID from URL
+	boardIDStr := c.Params("boardId")
+	boardID, err = uuid.Parse(boardIDStr)
+	if err != nil {
+		logger.Warnw("Invalid board ID", "boardId", boardIDStr)
+		return uuid.Nil, false, utils.SendValidationError(c, "Invalid board ID format", nil)
+	}
+
+	// Validate edit token and board access
+	editToken, _ := c.Locals("edit_token").(uuid.UUID)
+	if err := h.trashService.ValidateEditAccess(boardID, editToken); err != nil {
+		if err == utils.ErrNotFound {
+			return uuid.Nil, false, utils.SendNotFoundError(c, "Board not found")
+		}
+		if err == utils.ErrUnauthorized {
+			return uuid.Nil, false, utils.SendUnauthorizedError(c, "Invalid edit token")
+		}
+		logger.Errorw("Failed to validate board access", "error", err)
+		return uuid.Nil, false, utils.SendInternalError(c, "Failed to validate board access", nil)
+	}
+
+	return boardID, true, nil
+}
@@ -1,18 +1,52 @@
 package middleware
 
 import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
 	"junk-journal-board/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-// TokenValidationMiddleware validates edit tokens for mutation operations
+// BoardTokenHeader carries a board token without putting it in the URL
+const BoardTokenHeader = "X-Board-Token"
+
+// headerBoardToken returns the board token sent in a request header. Authorization: Bearer takes
+// precedence over X-Board-Token, and either takes precedence over the token query parameters.
+func headerBoardToken(c *fiber.Ctx) (string, bool) {
+	if token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "); found {
+		if token = strings.TrimSpace(token); token != "" {
+			return token, true
+		}
+	}
+
+	if token := strings.TrimSpace(c.Get(BoardTokenHeader)); token != "" {
+		return token, true
+	}
+
+	return "", false
+}
+
+// TokenValidationMiddleware validates edit tokens for mutation operations. The token is read from
+// the Authorization or X-Board-Token header, falling back to the edit_token query parameter.
+// Signed-in requests without an edit token are let through so board members can be authorized by role.
 func TokenValidationMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Get edit_token from query parameters
-		editTokenStr := c.Query("edit_token")
+		editTokenStr, fromHeader := headerBoardToken(c)
+		if !fromHeader {
+			// Get edit_token from query parameters
+			editTokenStr = c.Query("edit_token")
+		}
 		if editTokenStr == "" {
+			// Signed-in board members may edit without the token; handlers check their role
+			if _, ok := GetUserIDFromContext(c); ok {
+				return c.Next()
+			}
 			return utils.SendUnauthorized(c, "Edit token is required for this operation")
 		}
 
@@ -29,9 +63,19 @@ func TokenValidationMiddleware() fiber.Handler {
 	}
 }
 
-// OptionalTokenMiddleware extracts tokens but doesn't require them
+// OptionalTokenMiddleware extracts tokens but doesn't require them. A header token may be an
+// edit or a public token, so it is offered as both and handlers validate it against the board;
+// when one is sent the token query parameters are ignored.
 func OptionalTokenMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if tokenStr, fromHeader := headerBoardToken(c); fromHeader {
+			if token, valid := utils.ValidateToken(tokenStr); valid {
+				c.Locals("edit_token", token)
+				c.Locals("token", token)
+			}
+			return c.Next()
+		}
+
 		// Get edit_token from query parameters
 		editTokenStr := c.Query("edit_token")
 		if editTokenStr != "" {
@@ -57,6 +101,62 @@ func OptionalTokenMiddleware() fiber.Handler {
 	}
 }
 
+// Response headers flagging a retired board token that still works during its grace period
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// TokenDeprecationMiddleware marks responses to requests made with a retired board token.
+// It looks at the token query parameters and, on routes taking the token in the path,
+// the editToken and publicToken parameters.
+func TokenDeprecationMiddleware(gracePeriodEnd func(token uuid.UUID) (*time.Time, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokens := make([]uuid.UUID, 0, 2)
+		for _, key := range []string{"edit_token", "public_token"} {
+			if token, ok := c.Locals(key).(uuid.UUID); ok {
+				tokens = append(tokens, token)
+			}
+		}
+		for _, param := range []string{"editToken", "publicToken"} {
+			if token, valid := utils.ValidateToken(c.Params(param)); valid {
+				tokens = append(tokens, token)
+			}
+		}
+
+		for _, token := range tokens {
+			end, err := gracePeriodEnd(token)
+			if err == nil && end != nil {
+				c.Set(DeprecationHeader, "true")
+				c.Set(SunsetHeader, end.UTC().Format(http.TimeFormat))
+				break
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// AdminMiddleware restricts a route to requests carrying the ADMIN_TOKEN as a bearer token.
+// The route is disabled when no admin token is configured.
+func AdminMiddleware() fiber.Handler {
+	adminToken := os.Getenv("ADMIN_TOKEN")
+
+	return func(c *fiber.Ctx) error {
+		if adminToken == "" {
+			return utils.SendForbidden(c, "This operation is disabled")
+		}
+
+		header := c.Get(fiber.HeaderAuthorization)
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(adminToken)) != 1 {
+			return utils.SendUnauthorized(c, "Admin credential is required for this operation")
+		}
+
+		return c.Next()
+	}
+}
+
 // GetEditTokenFromContext retrieves the edit token from fiber context
 func GetEditTokenFromContext(c *fiber.Ctx) (uuid.UUID, bool) {
 	token := c.Locals("edit_token")
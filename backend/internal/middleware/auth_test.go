@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		adminToken    string
+		authorization string
+		expected      int
+	}{
+		{name: "Matching token", adminToken: "admin-secret", authorization: "Bearer admin-secret", expected: fiber.StatusOK},
+		{name: "Token with surrounding spaces", adminToken: "admin-secret", authorization: "Bearer  admin-secret ", expected: fiber.StatusOK},
+		{name: "Disabled without admin token", adminToken: "", authorization: "Bearer admin-secret", expected: fiber.StatusForbidden},
+		{name: "Disabled even for an empty bearer", adminToken: "", authorization: "Bearer ", expected: fiber.StatusForbidden},
+		{name: "Wrong token", adminToken: "admin-secret", authorization: "Bearer guess", expected: fiber.StatusUnauthorized},
+		{name: "Token prefix", adminToken: "admin-secret", authorization: "Bearer admin", expected: fiber.StatusUnauthorized},
+		{name: "Missing header", adminToken: "admin-secret", authorization: "", expected: fiber.StatusUnauthorized},
+		{name: "Not a bearer token", adminToken: "admin-secret", authorization: "admin-secret", expected: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ADMIN_TOKEN", tt.adminToken)
+
+			app := fiber.New()
+			app.Get("/boards", AdminMiddleware(), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/boards", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// tokenTestRequest sends a request through a token middleware and returns the status and the
+// tokens it stored, written as "<edit_token> <public_token>"
+func tokenTestRequest(t *testing.T, handler fiber.Handler, target string, headers map[string]string) (int, string) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/boards", handler, func(c *fiber.Ctx) error {
+		editToken, _ := GetEditTokenFromContext(c)
+		publicToken, _ := GetPublicTokenFromContext(c)
+		return c.SendString(editToken.String() + " " + publicToken.String())
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, target, nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestTokenValidationMiddlewarePrecedence(t *testing.T) {
+	bearer := uuid.New()
+	header := uuid.New()
+	query := uuid.New()
+
+	tests := []struct {
+		name     string
+		target   string
+		headers  map[string]string
+		status   int
+		expected uuid.UUID
+	}{
+		{
+			name:     "Bearer wins over X-Board-Token and query",
+			target:   "/boards?edit_token=" + query.String(),
+			headers:  map[string]string{fiber.HeaderAuthorization: "Bearer " + bearer.String(), BoardTokenHeader: header.String()},
+			status:   fiber.StatusOK,
+			expected: bearer,
+		},
+		{
+			name:     "X-Board-Token wins over query",
+			target:   "/boards?edit_token=" + query.String(),
+			headers:  map[string]string{BoardTokenHeader: header.String()},
+			status:   fiber.StatusOK,
+			expected: header,
+		},
+		{
+			name:     "Empty bearer falls back to X-Board-Token",
+			target:   "/boards",
+			headers:  map[string]string{fiber.HeaderAuthorization: "Bearer ", BoardTokenHeader: header.String()},
+			status:   fiber.StatusOK,
+			expected: header,
+		},
+		{
+			name:     "Other schemes fall back to the query",
+			target:   "/boards?edit_token=" + query.String(),
+			headers:  map[string]string{fiber.HeaderAuthorization: "Basic " + bearer.String()},
+			status:   fiber.StatusOK,
+			expected: query,
+		},
+		{
+			name:     "Query without headers",
+			target:   "/boards?edit_token=" + query.String(),
+			status:   fiber.StatusOK,
+			expected: query,
+		},
+		{
+			name:    "Invalid header token is not replaced by the query",
+			target:  "/boards?edit_token=" + query.String(),
+			headers: map[string]string{BoardTokenHeader: "not-a-token"},
+			status:  fiber.StatusUnauthorized,
+		},
+		{
+			name:   "No token",
+			target: "/boards",
+			status: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := tokenTestRequest(t, TokenValidationMiddleware(), tt.target, tt.headers)
+			if status != tt.status {
+				t.Fatalf("Expected %d, got %d", tt.status, status)
+			}
+			if tt.status == fiber.StatusOK && body != tt.expected.String()+" "+uuid.Nil.String() {
+				t.Errorf("Expected edit token %s, got %s", tt.expected, body)
+			}
+		})
+	}
+}
+
+func TestOptionalTokenMiddlewarePrecedence(t *testing.T) {
+	header := uuid.New()
+	editQuery := uuid.New()
+	publicQuery := uuid.New()
+
+	tests := []struct {
+		name     string
+		target   string
+		headers  map[string]string
+		expected string
+	}{
+		{
+			name:     "Header token is offered as both kinds and query tokens are ignored",
+			target:   "/boards?edit_token=" + editQuery.String() + "&public_token=" + publicQuery.String(),
+			headers:  map[string]string{fiber.HeaderAuthorization: "Bearer " + header.String()},
+			expected: header.String() + " " + uuid.Nil.String(),
+		},
+		{
+			name:     "Invalid header token ignores the query too",
+			target:   "/boards?edit_token=" + editQuery.String(),
+			headers:  map[string]string{BoardTokenHeader: "not-a-token"},
+			expected: uuid.Nil.String() + " " + uuid.Nil.String(),
+		},
+		{
+			name:     "Query tokens without headers",
+			target:   "/boards?edit_token=" + editQuery.String() + "&public_token=" + publicQuery.String(),
+			expected: editQuery.String() + " " + publicQuery.String(),
+		},
+		{
+			name:     "No token",
+			target:   "/boards",
+			expected: uuid.Nil.String() + " " + uuid.Nil.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := tokenTestRequest(t, OptionalTokenMiddleware(), tt.target, tt.headers)
+			if status != fiber.StatusOK {
+				t.Fatalf("Expected %d, got %d", fiber.StatusOK, status)
+			}
+			if body != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, body)
+			}
+		})
+	}
+}
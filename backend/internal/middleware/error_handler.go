@@ -9,6 +9,8 @@ import (
 
 // ErrorHandler creates a custom error handler that returns standardized error responses
 func ErrorHandler(logger *utils.Logger) fiber.ErrorHandler {
+	redaction := tokenRedactionFromEnv()
+
 	return func(c *fiber.Ctx, err error) error {
 		// Get request logger if available
 		reqLogger := logger
@@ -36,6 +38,8 @@ func ErrorHandler(logger *utils.Logger) fiber.ErrorHandler {
 				errorCode = utils.ErrCodeForbidden
 			case fiber.StatusNotFound:
 				errorCode = utils.ErrCodeNotFound
+			case fiber.StatusConflict:
+				errorCode = utils.ErrCodeConflict
 			case fiber.StatusUnprocessableEntity:
 				errorCode = utils.ErrCodeValidationError
 			default:
@@ -44,9 +48,10 @@ func ErrorHandler(logger *utils.Logger) fiber.ErrorHandler {
 		}
 
 		// Log the error
+		path, _ := redactRequestURI(c.Path(), "", redaction)
 		reqLogger.LogError(err, "Request error occurred",
 			zap.String("method", c.Method()),
-			zap.String("path", c.Path()),
+			zap.String("path", path),
 			zap.Int("status_code", code),
 			zap.String("error_code", string(errorCode)),
 		)
@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
 	"junk-journal-board/internal/utils"
@@ -8,8 +11,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// Modes for LOG_TOKEN_REDACTION, which controls how board tokens appear in request logs
+const (
+	TokenRedactionRedact = "redact" // replace token values with a placeholder (default)
+	TokenRedactionStrip  = "strip"  // drop token query parameters altogether
+	TokenRedactionOff    = "off"    // log tokens as sent
+)
+
+// redactedToken replaces token values in request logs
+const redactedToken = "REDACTED"
+
+// tokenQueryParams are the query parameters carrying board tokens, unlock tokens and view grants
+var tokenQueryParams = []string{"edit_token", "public_token", "unlock_token", "view_token"}
+
 // LoggingMiddleware creates a custom logging middleware using structured logging
 func LoggingMiddleware(logger *utils.Logger) fiber.Handler {
+	redaction := tokenRedactionFromEnv()
+
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
 
@@ -28,10 +46,12 @@ func LoggingMiddleware(logger *utils.Logger) fiber.Handler {
 		// Calculate duration
 		duration := time.Since(start).Milliseconds()
 
-		// Log the request
+		// Log the request without the tokens it carried in the URL
+		path, query := redactRequestURI(c.Path(), string(c.Request().URI().QueryString()), redaction)
 		reqLogger.LogRequest(
 			c.Method(),
-			c.Path(),
+			path,
+			query,
 			c.Get("User-Agent"),
 			c.IP(),
 			c.Response().StatusCode(),
@@ -42,6 +62,65 @@ func LoggingMiddleware(logger *utils.Logger) fiber.Handler {
 	}
 }
 
+// tokenRedactionFromEnv reads the LOG_TOKEN_REDACTION mode, defaulting to redact
+func tokenRedactionFromEnv() string {
+	switch mode := os.Getenv("LOG_TOKEN_REDACTION"); mode {
+	case TokenRedactionStrip, TokenRedactionOff:
+		return mode
+	default:
+		return TokenRedactionRedact
+	}
+}
+
+// redactRequestURI hides the board tokens of a request path and query string according to the
+// redaction mode. Tokens in the path are redacted in both the redact and strip modes.
+func redactRequestURI(path, query, mode string) (string, string) {
+	if mode == TokenRedactionOff {
+		return path, query
+	}
+
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		previous := segments[i-1]
+		switch {
+		case previous == "keyrings",
+			i >= 2 && segments[i-2] == "boards" && (previous == "edit" || previous == "public" || previous == "share"):
+			if segments[i] != "" {
+				segments[i] = redactedToken
+			}
+		}
+	}
+	path = strings.Join(segments, "/")
+
+	if query == "" {
+		return path, query
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		// Do not risk logging a token from a query string that cannot be parsed
+		return path, redactedToken
+	}
+
+	changed := false
+	for _, param := range tokenQueryParams {
+		if _, ok := values[param]; !ok {
+			continue
+		}
+		changed = true
+		if mode == TokenRedactionStrip {
+			values.Del(param)
+		} else {
+			values.Set(param, redactedToken)
+		}
+	}
+	if !changed {
+		return path, query
+	}
+
+	return path, values.Encode()
+}
+
 // GetLoggerFromContext retrieves the logger from fiber context
 func GetLoggerFromContext(c *fiber.Ctx) *utils.Logger {
 	logger := c.Locals("logger")
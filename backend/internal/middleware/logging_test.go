@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// loggedRequest sends a request through LoggingMiddleware and returns the logged path and query
+func loggedRequest(t *testing.T, target string) (string, string) {
+	t.Helper()
+
+	core, logs := observer.New(zap.InfoLevel)
+	app := fiber.New()
+	app.Use(requestid.New())
+	app.Use(LoggingMiddleware(&utils.Logger{Logger: zap.New(core)}))
+	app.Get("/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	entries := logs.FilterMessage("HTTP Request").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 request log, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	path, _ := fields["path"].(string)
+	query, _ := fields["query"].(string)
+	return path, query
+}
+
+func TestLoggingMiddlewareRedactsPathTokens(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/api/v1/boards/edit/secret", expected: "/api/v1/boards/edit/REDACTED"},
+		{path: "/api/v1/boards/public/secret/pages", expected: "/api/v1/boards/public/REDACTED/pages"},
+		{path: "/api/v1/boards/share/secret", expected: "/api/v1/boards/share/REDACTED"},
+		{path: "/api/v1/keyrings/secret/boards/abc", expected: "/api/v1/keyrings/REDACTED/boards/abc"},
+		{path: "/api/v1/keyrings", expected: "/api/v1/keyrings"},
+		{path: "/api/v1/boards/abc/pages/edit", expected: "/api/v1/boards/abc/pages/edit"},
+	}
+
+	for _, mode := range []string{TokenRedactionRedact, TokenRedactionStrip, TokenRedactionOff} {
+		t.Setenv("LOG_TOKEN_REDACTION", mode)
+		for _, tt := range tests {
+			expected := tt.expected
+			if mode == TokenRedactionOff {
+				expected = tt.path
+			}
+			if path, _ := loggedRequest(t, tt.path); path != expected {
+				t.Errorf("%s %s: expected %s, got %s", mode, tt.path, expected, path)
+			}
+		}
+	}
+}
+
+func TestLoggingMiddlewareRedactsQueryTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		query    string
+		expected url.Values
+		raw      string
+	}{
+		{
+			name:     "Redacts every token parameter by default",
+			mode:     "",
+			query:    "edit_token=a&public_token=b&unlock_token=c&view_token=d&limit=10",
+			expected: url.Values{"edit_token": {"REDACTED"}, "public_token": {"REDACTED"}, "unlock_token": {"REDACTED"}, "view_token": {"REDACTED"}, "limit": {"10"}},
+		},
+		{
+			name:     "Redacts repeated token parameters",
+			mode:     TokenRedactionRedact,
+			query:    "edit_token=a&edit_token=b",
+			expected: url.Values{"edit_token": {"REDACTED"}},
+		},
+		{
+			name:     "Strips token parameters",
+			mode:     TokenRedactionStrip,
+			query:    "edit_token=a&view_token=d&limit=10",
+			expected: url.Values{"limit": {"10"}},
+		},
+		{
+			name:  "Unknown modes redact",
+			mode:  "none",
+			query: "edit_token=a",
+			raw:   "edit_token=REDACTED",
+		},
+		{
+			name:  "Keeps queries without tokens as sent",
+			mode:  TokenRedactionRedact,
+			query: "limit=10&cursor=abc%3D",
+			raw:   "limit=10&cursor=abc%3D",
+		},
+		{
+			name:  "Logs tokens when redaction is off",
+			mode:  TokenRedactionOff,
+			query: "edit_token=a",
+			raw:   "edit_token=a",
+		},
+		{
+			name:  "Hides unparseable queries",
+			mode:  TokenRedactionRedact,
+			query: "edit_token=%zz",
+			raw:   "REDACTED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LOG_TOKEN_REDACTION", tt.mode)
+			_, query := loggedRequest(t, "/api/v1/boards/abc?"+tt.query)
+
+			if tt.expected == nil {
+				if query != tt.raw {
+					t.Errorf("Expected %q, got %q", tt.raw, query)
+				}
+				return
+			}
+
+			values, err := url.ParseQuery(query)
+			if err != nil {
+				t.Fatalf("Expected a valid query, got %q: %v", query, err)
+			}
+			if len(values) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, values)
+			}
+			for key, expected := range tt.expected {
+				if got := values[key]; len(got) != len(expected) || got[0] != expected[0] {
+					t.Errorf("Expected %s=%v, got %v", key, expected, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"junk-journal-board/internal/services"
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Rate limit headers, as in the IETF RateLimit header fields draft
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+)
+
+// createRoutes are the API paths creating boards or other top-level resources
+var createRoutes = []string{
+	"/api/v1/boards",
+	"/api/v1/boards/import",
+	"/api/v1/keyrings",
+	"/api/v1/auth/register",
+}
+
+// RateLimitMiddleware counts API requests against the create, mutate, upload or read budget of
+// the route, once per client IP and, for edit tokens that isEditToken confirms, once per token.
+// Public tokens are shared by every reader of a board, so they get no budget of their own.
+// Failed lookups of a token in the URL also count against the lookup budget of the client IP.
+// Requests are let through when the limiter is nil or its store fails.
+func RateLimitMiddleware(limiter *services.RateLimiter, isEditToken func(token uuid.UUID) (bool, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if limiter == nil || c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+		logger := GetLoggerFromContext(c)
+		ipKey := "ip:" + c.IP()
+
+		keys := []string{ipKey}
+		if token, ok := c.Locals("token").(uuid.UUID); ok {
+			if valid, err := isEditToken(token); err != nil {
+				logger.Errorw("Failed to check edit token for rate limiting", "error", err)
+			} else if valid {
+				// Stored keys must not reveal the token
+				keys = append(keys, "token:"+utils.HashToken(token))
+			}
+		}
+
+		result, err := limiter.Allow(rateLimitBudget(c.Method(), c.Path()), keys...)
+		if err != nil {
+			logger.Errorw("Failed to apply rate limit", "error", err)
+			return c.Next()
+		}
+
+		setRateLimitHeaders(c, result)
+		if !result.Allowed {
+			logger.Warnw("Rate limit exceeded", "ip", c.IP(), "method", c.Method())
+			return utils.SendTooManyRequests(c, "Too many requests, try again later", result.Reset)
+		}
+
+		if !isTokenLookup(c.Path()) {
+			return c.Next()
+		}
+
+		// Clients that keep looking up unknown tokens are turned away before the lookup
+		failures, err := limiter.Check(services.RateLimitLookup, ipKey)
+		if err != nil {
+			logger.Errorw("Failed to apply rate limit", "error", err)
+			return c.Next()
+		}
+		if !failures.Allowed {
+			setRateLimitHeaders(c, failures)
+			logger.Warnw("Token lookup rate limit exceeded", "ip", c.IP())
+			return utils.SendTooManyRequests(c, "Too many failed lookups, try again later", failures.Reset)
+		}
+
+		err = c.Next()
+		if lookupFailed(c, err) {
+			if _, err := limiter.Allow(services.RateLimitLookup, ipKey); err != nil {
+				logger.Errorw("Failed to count failed lookup", "error", err)
+			}
+		}
+		return err
+	}
+}
+
+// setRateLimitHeaders describes the budget of a request in the response headers
+func setRateLimitHeaders(c *fiber.Ctx, result services.RateLimitResult) {
+	c.Set(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+	c.Set(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+	c.Set(RateLimitResetHeader, strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+}
+
+// isTokenLookup reports whether a path looks a board or keyring up by a token in the URL
+func isTokenLookup(path string) bool {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/v1"), "/"), "/")
+	switch {
+	case len(segments) >= 2 && segments[0] == "keyrings":
+		return true
+	case len(segments) == 3 && segments[0] == "boards":
+		return segments[1] == "edit" || segments[1] == "public" || segments[1] == "share"
+	}
+	return false
+}
+
+// lookupFailed reports whether a token lookup ended without finding a board or keyring for the token
+func lookupFailed(c *fiber.Ctx, err error) bool {
+	if fiberErr, ok := err.(*fiber.Error); ok {
+		return fiberErr.Code == fiber.StatusNotFound || fiberErr.Code == fiber.StatusUnauthorized
+	}
+	status := c.Response().StatusCode()
+	return err == nil && (status == fiber.StatusNotFound || status == fiber.StatusUnauthorized)
+}
+
+// rateLimitBudget returns the budget a request counts against
+func rateLimitBudget(method, path string) string {
+	if method == fiber.MethodGet || method == fiber.MethodHead {
+		return services.RateLimitRead
+	}
+
+	path = strings.TrimSuffix(path, "/")
+	if strings.HasSuffix(path, "/upload") {
+		return services.RateLimitUpload
+	}
+	if method == fiber.MethodPost {
+		if strings.HasSuffix(path, "/clone") {
+			return services.RateLimitCreate
+		}
+		for _, route := range createRoutes {
+			if path == route {
+				return services.RateLimitCreate
+			}
+		}
+	}
+
+	return services.RateLimitMutate
+}
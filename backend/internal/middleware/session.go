@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"strings"
+
+	"junk-journal-board/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// SessionTokenHeader carries the session token of a signed-in account
+const SessionTokenHeader = "X-Session-Token"
+
+// OptionalSessionMiddleware resolves the session token of signed-in requests to a user ID.
+// Requests without a valid session continue anonymously.
+func OptionalSessionMiddleware(authenticate func(token string) (uuid.UUID, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := strings.TrimSpace(c.Get(SessionTokenHeader))
+		if token == "" {
+			return c.Next()
+		}
+
+		if userID, err := authenticate(token); err == nil {
+			c.Locals("user_id", userID)
+			c.Locals("session_token", token)
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireSessionMiddleware rejects requests that are not signed in
+func RequireSessionMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := GetUserIDFromContext(c); !ok {
+			return utils.SendUnauthorized(c, "Sign-in is required for this operation")
+		}
+		return c.Next()
+	}
+}
+
+// GetUserIDFromContext retrieves the signed-in user's ID from fiber context
+func GetUserIDFromContext(c *fiber.Ctx) (uuid.UUID, bool) {
+	userID, ok := c.Locals("user_id").(uuid.UUID)
+	return userID, ok
+}
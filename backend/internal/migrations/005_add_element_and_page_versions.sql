@@ -0,0 +1,15 @@
+-- Add version columns for optimistic concurrency control (only if they don't exist)
+DO $$
+BEGIN
+    -- Add version column to elements if it doesn't exist
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name = 'elements' AND column_name = 'version') THEN
+        ALTER TABLE elements ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+    END IF;
+    
+    -- Add version column to pages if it doesn't exist
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name = 'pages' AND column_name = 'version') THEN
+        ALTER TABLE pages ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+    END IF;
+END $$;
@@ -0,0 +1,14 @@
+-- Create element revisions table (append-only element history)
+CREATE TABLE IF NOT EXISTS element_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    element_id UUID NOT NULL,
+    page_id UUID NOT NULL REFERENCES pages(id) ON DELETE CASCADE,
+    action TEXT NOT NULL CHECK (action IN ('create','update','delete','reorder','restore')),
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_element_revisions_element_id ON element_revisions(element_id);
+CREATE INDEX IF NOT EXISTS idx_element_revisions_page_created ON element_revisions(page_id, created_at);
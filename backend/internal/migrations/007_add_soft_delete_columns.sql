@@ -0,0 +1,26 @@
+-- Add deleted_at columns so boards, pages and elements can be moved to the trash (only if they don't exist)
+DO $$
+BEGIN
+    -- Add deleted_at column to boards if it doesn't exist
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name = 'boards' AND column_name = 'deleted_at') THEN
+        ALTER TABLE boards ADD COLUMN deleted_at TIMESTAMPTZ;
+    END IF;
+    
+    -- Add deleted_at column to pages if it doesn't exist
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name = 'pages' AND column_name = 'deleted_at') THEN
+        ALTER TABLE pages ADD COLUMN deleted_at TIMESTAMPTZ;
+    END IF;
+    
+    -- Add deleted_at column to elements if it doesn't exist
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name = 'elements' AND column_name = 'deleted_at') THEN
+        ALTER TABLE elements ADD COLUMN deleted_at TIMESTAMPTZ;
+    END IF;
+END $$;
+
+-- Create indexes for trash lookups and purging
+CREATE INDEX IF NOT EXISTS idx_boards_deleted_at ON boards(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_pages_deleted_at ON pages(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_elements_deleted_at ON elements(deleted_at);
@@ -0,0 +1,22 @@
+-- Create board snapshots table
+CREATE TABLE IF NOT EXISTS board_snapshots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    page_count INTEGER NOT NULL DEFAULT 0,
+    element_count INTEGER NOT NULL DEFAULT 0,
+    content JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_board_snapshots_board_created ON board_snapshots(board_id, created_at);
+
+-- Add pinned snapshot column to boards (only if it doesn't exist)
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
+                   WHERE table_name = 'boards' AND column_name = 'pinned_snapshot_id') THEN
+        ALTER TABLE boards ADD COLUMN pinned_snapshot_id UUID REFERENCES board_snapshots(id) ON DELETE SET NULL;
+    END IF;
+END $$;
@@ -0,0 +1,16 @@
+-- Create page templates table
+CREATE TABLE IF NOT EXISTS page_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID REFERENCES boards(id) ON DELETE CASCADE,
+    owner_board_id UUID REFERENCES boards(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    element_count INTEGER NOT NULL DEFAULT 0,
+    elements JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_page_templates_board_id ON page_templates(board_id);
+CREATE INDEX IF NOT EXISTS idx_page_templates_owner_board_id ON page_templates(owner_board_id);
@@ -0,0 +1,22 @@
+-- Create board templates table
+CREATE TABLE IF NOT EXISTS board_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    description TEXT,
+    skin TEXT DEFAULT 'default',
+    date_pattern TEXT NOT NULL DEFAULT 'none',
+    default_days INTEGER NOT NULL DEFAULT 1,
+    pages JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Seed starter kits
+INSERT INTO board_templates (id, name, description, skin, date_pattern, default_days, pages) VALUES
+    ('6f1c9a52-3d4e-4b8a-9c21-7e5d0b1a2f01', 'Bullet journal month', 'An index, a monthly log and one spread per day of the month', 'notebook', 'month', 1,
+     '[{"title": "Index"}, {"title": "Monthly log"}, {"title": "{weekday}, {date}", "repeat": "daily"}]'),
+    ('6f1c9a52-3d4e-4b8a-9c21-7e5d0b1a2f02', 'Travel log', 'An itinerary followed by one page per day of the trip', 'wood', 'range', 7,
+     '[{"title": "Itinerary"}, {"title": "Day {day}: {date}", "repeat": "daily"}]'),
+    ('6f1c9a52-3d4e-4b8a-9c21-7e5d0b1a2f03', 'Weekly planner', 'Month goals and one page per week', 'cork', 'month', 1,
+     '[{"title": "Goals for {month}"}, {"title": "Week of {date}", "repeat": "weekly"}]')
+ON CONFLICT (id) DO NOTHING;
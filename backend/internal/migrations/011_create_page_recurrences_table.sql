@@ -0,0 +1,20 @@
+-- Create page recurrences table
+CREATE TABLE IF NOT EXISTS page_recurrences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL UNIQUE REFERENCES boards(id) ON DELETE CASCADE,
+    frequency TEXT NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    repeat_interval INTEGER NOT NULL DEFAULT 1 CHECK (repeat_interval >= 1),
+    title_pattern TEXT NOT NULL,
+    start_date TIMESTAMPTZ NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    page_template_id UUID REFERENCES page_templates(id) ON DELETE SET NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    occurrence_count INTEGER NOT NULL DEFAULT 0,
+    next_date TIMESTAMPTZ NOT NULL,
+    last_occurrence_date TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_page_recurrences_due ON page_recurrences(next_date) WHERE enabled;
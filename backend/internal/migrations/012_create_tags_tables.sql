@@ -0,0 +1,29 @@
+-- Create tags table
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    color TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create page and element tag link tables
+CREATE TABLE IF NOT EXISTS page_tags (
+    page_id UUID NOT NULL REFERENCES pages(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (page_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS element_tags (
+    element_id UUID NOT NULL REFERENCES elements(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (element_id, tag_id)
+);
+
+-- Create indexes for performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_board_name ON tags(board_id, name);
+CREATE INDEX IF NOT EXISTS idx_page_tags_tag_id ON page_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_element_tags_tag_id ON element_tags(tag_id);
@@ -0,0 +1,18 @@
+-- Create keyrings table
+CREATE TABLE IF NOT EXISTS keyrings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    token UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create keyring board link table
+CREATE TABLE IF NOT EXISTS keyring_boards (
+    keyring_id UUID NOT NULL REFERENCES keyrings(id) ON DELETE CASCADE,
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (keyring_id, board_id)
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_keyring_boards_board_id ON keyring_boards(board_id);
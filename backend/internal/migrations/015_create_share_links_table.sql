@@ -0,0 +1,15 @@
+-- Create share links table
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    token UUID UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+    role TEXT NOT NULL CHECK (role IN ('viewer', 'commenter', 'editor')),
+    page_id UUID REFERENCES pages(id) ON DELETE CASCADE,
+    label TEXT,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_share_links_board_id ON share_links(board_id);
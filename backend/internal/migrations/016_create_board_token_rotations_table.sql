@@ -0,0 +1,16 @@
+-- Create board token rotations table (audit trail and grace periods of retired tokens)
+CREATE TABLE IF NOT EXISTS board_token_rotations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('edit', 'public')),
+    retired_token UUID NOT NULL,
+    grace_until TIMESTAMPTZ,
+    reason TEXT,
+    rotated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    client_ip TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_board_token_rotations_board_id ON board_token_rotations(board_id);
+CREATE INDEX IF NOT EXISTS idx_board_token_rotations_retired_token ON board_token_rotations(retired_token);
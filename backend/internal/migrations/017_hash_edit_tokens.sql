@@ -0,0 +1,14 @@
+-- Store keyed hashes of edit tokens instead of the tokens themselves. The hash secret is not
+-- available to SQL, so existing tokens are hashed by the server at startup, which then clears
+-- the plaintext columns. Existing edit URLs keep working.
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS edit_token_hash TEXT;
+ALTER TABLE boards ALTER COLUMN edit_token DROP DEFAULT;
+ALTER TABLE boards ALTER COLUMN edit_token DROP NOT NULL;
+
+-- Retired tokens of the rotation audit trail are hashed the same way
+ALTER TABLE board_token_rotations ADD COLUMN IF NOT EXISTS retired_token_hash TEXT;
+ALTER TABLE board_token_rotations ALTER COLUMN retired_token DROP NOT NULL;
+
+-- Create indexes for performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_boards_edit_token_hash ON boards(edit_token_hash);
+CREATE INDEX IF NOT EXISTS idx_board_token_rotations_retired_token_hash ON board_token_rotations(retired_token_hash);
@@ -0,0 +1,2 @@
+-- Add optional passphrase (bcrypt hash) protecting public access to a board
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS public_passphrase_hash TEXT;
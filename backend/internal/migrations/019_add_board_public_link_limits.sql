@@ -0,0 +1,5 @@
+-- Add optional expiry and view limit to a board's public link, with its view count
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS public_expires_at TIMESTAMPTZ;
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS public_max_views INTEGER;
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS public_view_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE boards ADD COLUMN IF NOT EXISTS public_last_viewed_at TIMESTAMPTZ;
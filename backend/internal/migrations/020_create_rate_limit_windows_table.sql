@@ -0,0 +1,9 @@
+-- Create rate_limit_windows table, shared by instances using the Postgres rate limit backend
+CREATE TABLE IF NOT EXISTS rate_limit_windows (
+    key TEXT PRIMARY KEY,
+    count INTEGER NOT NULL,
+    reset_at TIMESTAMPTZ NOT NULL
+);
+
+-- Index for pruning ended windows
+CREATE INDEX IF NOT EXISTS idx_rate_limit_windows_reset_at ON rate_limit_windows(reset_at);
@@ -0,0 +1,4 @@
+-- Snapshots only freeze board content. Remove the public token and public link settings that
+-- older snapshots stored along with it; pinned snapshots take them from the live board.
+UPDATE board_snapshots
+SET content = content - 'public_token' - 'public_expires_at' - 'public_max_views' - 'public_view_count' - 'pinned_snapshot_id';
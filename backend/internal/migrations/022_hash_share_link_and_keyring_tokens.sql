@@ -0,0 +1,13 @@
+-- Store keyed hashes of share link and keyring tokens, as for edit tokens. Existing tokens are
+-- hashed by the server at startup, which then clears the plaintext columns. Existing links keep working.
+ALTER TABLE share_links ADD COLUMN IF NOT EXISTS token_hash TEXT;
+ALTER TABLE share_links ALTER COLUMN token DROP DEFAULT;
+ALTER TABLE share_links ALTER COLUMN token DROP NOT NULL;
+
+ALTER TABLE keyrings ADD COLUMN IF NOT EXISTS token_hash TEXT;
+ALTER TABLE keyrings ALTER COLUMN token DROP DEFAULT;
+ALTER TABLE keyrings ALTER COLUMN token DROP NOT NULL;
+
+-- Create indexes for performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_share_links_token_hash ON share_links(token_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_keyrings_token_hash ON keyrings(token_hash);
@@ -0,0 +1,13 @@
+-- Full-text search indexes on the searchable text of live boards. The expressions must match the
+-- documents of the search query, including the 'english' text search configuration.
+CREATE INDEX IF NOT EXISTS idx_boards_description_search ON boards
+    USING GIN (to_tsvector('english', description));
+
+CREATE INDEX IF NOT EXISTS idx_pages_title_search ON pages
+    USING GIN (to_tsvector('english', title));
+
+CREATE INDEX IF NOT EXISTS idx_elements_content_search ON elements
+    USING GIN (to_tsvector('english', payload->>'content')) WHERE kind = 'text';
+
+CREATE INDEX IF NOT EXISTS idx_elements_description_search ON elements
+    USING GIN (to_tsvector('english', payload->>'description')) WHERE kind = 'image';
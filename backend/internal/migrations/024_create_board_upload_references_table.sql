@@ -0,0 +1,19 @@
+-- Create board upload references table (upload directories of other boards a board's elements point at)
+CREATE TABLE IF NOT EXISTS board_upload_references (
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    upload_board_id UUID NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (board_id, upload_board_id)
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_board_upload_references_upload_board_id ON board_upload_references(upload_board_id);
+
+-- Record the references of boards cloned or built from templates before references were tracked
+INSERT INTO board_upload_references (board_id, upload_board_id)
+SELECT DISTINCT p.board_id, substring(e.payload->>'url' from '/uploads/boards/([0-9a-f-]{36})/')::uuid
+FROM elements e
+JOIN pages p ON p.id = e.page_id
+WHERE e.payload->>'url' ~ '/uploads/boards/[0-9a-f-]{36}/'
+  AND substring(e.payload->>'url' from '/uploads/boards/([0-9a-f-]{36})/')::uuid <> p.board_id
+ON CONFLICT DO NOTHING;
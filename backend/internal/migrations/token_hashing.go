@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"fmt"
+
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// hashedTokenColumns are the plaintext token columns HashPlaintextTokens converts, with the
+// columns receiving their keyed hashes
+var hashedTokenColumns = []struct {
+	table     string
+	plaintext string
+	hash      string
+}{
+	{table: "boards", plaintext: "edit_token", hash: "edit_token_hash"},
+	{table: "board_token_rotations", plaintext: "retired_token", hash: "retired_token_hash"},
+	{table: "share_links", plaintext: "token", hash: "token_hash"},
+	{table: "keyrings", plaintext: "token", hash: "token_hash"},
+}
+
+// HashPlaintextTokens replaces plaintext edit tokens, retired tokens of the rotation audit trail,
+// share link tokens and keyring tokens with their keyed hashes so existing URLs keep working.
+// Rows already converted are skipped.
+func (m *Migrator) HashPlaintextTokens() error {
+	type plaintextToken struct {
+		ID    uuid.UUID
+		Token uuid.UUID
+	}
+
+	pending := make([][]plaintextToken, len(hashedTokenColumns))
+	total := 0
+	for i, column := range hashedTokenColumns {
+		query := fmt.Sprintf("SELECT id, %s AS token FROM %s WHERE %s IS NOT NULL", column.plaintext, column.table, column.plaintext)
+		if err := m.db.Raw(query).Scan(&pending[i]).Error; err != nil {
+			return fmt.Errorf("failed to load plaintext tokens of %s: %w", column.table, err)
+		}
+		total += len(pending[i])
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		for i, column := range hashedTokenColumns {
+			update := fmt.Sprintf("UPDATE %s SET %s = ?, %s = NULL WHERE id = ?", column.table, column.hash, column.plaintext)
+			for _, row := range pending[i] {
+				if err := tx.Exec(update, utils.HashToken(row.Token), row.ID).Error; err != nil {
+					return fmt.Errorf("failed to hash token of %s %s: %w", column.table, row.ID, err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fields := make([]zap.Field, len(hashedTokenColumns))
+	for i, column := range hashedTokenColumns {
+		fields[i] = zap.Int(column.table, len(pending[i]))
+	}
+	m.logger.Info("Hashed stored tokens", fields...)
+	return nil
+}
@@ -0,0 +1,117 @@
+package migrations
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+func TestHashPlaintextTokens(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	type row struct {
+		id    uuid.UUID
+		token uuid.UUID
+	}
+	plaintext := make(map[string]row, len(hashedTokenColumns))
+	converted := make(map[string]uuid.UUID, len(hashedTokenColumns))
+
+	for _, column := range hashedTokenColumns {
+		ddl := fmt.Sprintf("CREATE TABLE %s (id TEXT PRIMARY KEY, %s TEXT, %s TEXT)", column.table, column.plaintext, column.hash)
+		if err := db.Exec(ddl).Error; err != nil {
+			t.Fatalf("Failed to create %s: %v", column.table, err)
+		}
+
+		// One row still holding its plaintext token, one converted before
+		plaintext[column.table] = row{id: uuid.New(), token: uuid.New()}
+		insert := fmt.Sprintf("INSERT INTO %s (id, %s) VALUES (?, ?)", column.table, column.plaintext)
+		if err := db.Exec(insert, plaintext[column.table].id, plaintext[column.table].token).Error; err != nil {
+			t.Fatalf("Failed to insert into %s: %v", column.table, err)
+		}
+
+		converted[column.table] = uuid.New()
+		insert = fmt.Sprintf("INSERT INTO %s (id, %s) VALUES (?, 'existing-hash')", column.table, column.hash)
+		if err := db.Exec(insert, converted[column.table]).Error; err != nil {
+			t.Fatalf("Failed to insert into %s: %v", column.table, err)
+		}
+	}
+
+	migrator := NewMigrator(db, zap.NewNop())
+	// Running again finds nothing left to convert
+	for run := 0; run < 2; run++ {
+		if err := migrator.HashPlaintextTokens(); err != nil {
+			t.Fatalf("Run %d: expected no error, got %v", run+1, err)
+		}
+	}
+
+	for _, column := range hashedTokenColumns {
+		var stored struct {
+			Plaintext *string
+			Hash      *string
+		}
+		query := fmt.Sprintf("SELECT %s AS plaintext, %s AS hash FROM %s WHERE id = ?", column.plaintext, column.hash, column.table)
+
+		if err := db.Raw(query, plaintext[column.table].id).Scan(&stored).Error; err != nil {
+			t.Fatalf("Failed to read %s: %v", column.table, err)
+		}
+		if stored.Plaintext != nil {
+			t.Errorf("Expected the plaintext token of %s to be cleared, got %s", column.table, *stored.Plaintext)
+		}
+		if expected := utils.HashToken(plaintext[column.table].token); stored.Hash == nil || *stored.Hash != expected {
+			t.Errorf("Expected the token of %s to be stored as %s, got %v", column.table, expected, stored.Hash)
+		}
+
+		if err := db.Raw(query, converted[column.table]).Scan(&stored).Error; err != nil {
+			t.Fatalf("Failed to read %s: %v", column.table, err)
+		}
+		if stored.Plaintext != nil || stored.Hash == nil || *stored.Hash != "existing-hash" {
+			t.Errorf("Expected converted rows of %s to be left alone, got %v, %v", column.table, stored.Plaintext, stored.Hash)
+		}
+	}
+}
+
+func TestHashedTokenColumnsMatchModels(t *testing.T) {
+	// Every model storing a token hash must have its plaintext column converted
+	hashModels := []interface{}{
+		&models.Board{},
+		&models.BoardTokenRotation{},
+		&models.ShareLink{},
+		&models.Keyring{},
+	}
+
+	if len(hashedTokenColumns) != len(hashModels) {
+		t.Fatalf("Expected %d hashed token columns, got %d", len(hashModels), len(hashedTokenColumns))
+	}
+
+	cache := &sync.Map{}
+	for i, model := range hashModels {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatalf("Failed to parse model schema: %v", err)
+		}
+
+		column := hashedTokenColumns[i]
+		if column.table != s.Table {
+			t.Errorf("Expected table %s, got %s", s.Table, column.table)
+		}
+		if _, ok := s.FieldsByDBName[column.hash]; !ok {
+			t.Errorf("Expected %s to have a %s column", s.Table, column.hash)
+		}
+		if _, ok := s.FieldsByDBName[column.plaintext]; ok {
+			t.Errorf("Expected %s to no longer write a plaintext %s column", s.Table, column.plaintext)
+		}
+	}
+}
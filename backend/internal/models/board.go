@@ -3,28 +3,41 @@ package models
 import (
 	"time"
 
+	"junk-journal-board/internal/utils"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Board struct {
-	ID          uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
-	Title       string    `gorm:"not null" json:"title"`
-	Description string    `gorm:"type:text" json:"description"`
-	Skin        string    `gorm:"default:'default'" json:"skin"`
-	EditToken   uuid.UUID `gorm:"type:uuid;unique;not null" json:"-"`
-	PublicToken uuid.UUID `gorm:"type:uuid;unique;not null" json:"public_token"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Pages       []Page    `gorm:"foreignKey:BoardID" json:"pages,omitempty"`
+	ID                   uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	Title                string         `gorm:"not null" json:"title"`
+	Description          string         `gorm:"type:text" json:"description"`
+	Skin                 string         `gorm:"default:'default'" json:"skin"`
+	EditToken            uuid.UUID      `gorm:"-" json:"-"` // only known right after creation or rotation
+	EditTokenHash        string         `gorm:"uniqueIndex;not null" json:"-"`
+	PublicToken          uuid.UUID      `gorm:"type:uuid;unique;not null" json:"public_token"`
+	PublicPassphraseHash string         `json:"-"` // empty when public access needs no passphrase
+	PublicExpiresAt      *time.Time     `json:"public_expires_at,omitempty"`
+	PublicMaxViews       *int           `json:"public_max_views,omitempty"`
+	PublicViewCount      int            `gorm:"not null;default:0" json:"public_view_count"`
+	PublicLastViewedAt   *time.Time     `json:"-"`
+	PinnedSnapshotID     *uuid.UUID     `gorm:"type:uuid" json:"pinned_snapshot_id,omitempty"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
+	Pages                []Page         `gorm:"foreignKey:BoardID" json:"pages,omitempty"`
 }
 
 func (b *Board) BeforeCreate(tx *gorm.DB) error {
 	if b.ID == uuid.Nil {
 		b.ID = uuid.New()
 	}
-	if b.EditToken == uuid.Nil {
-		b.EditToken = uuid.New()
+	if b.EditTokenHash == "" {
+		if b.EditToken == uuid.Nil {
+			b.EditToken = uuid.New()
+		}
+		b.EditTokenHash = utils.HashToken(b.EditToken)
 	}
 	if b.PublicToken == uuid.Nil {
 		b.PublicToken = uuid.New()
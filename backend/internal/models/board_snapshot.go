@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// BoardSnapshot is a named, frozen copy of a board with all of its pages and elements
+type BoardSnapshot struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	BoardID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"board_id"`
+	Name         string         `gorm:"not null" json:"name"`
+	PageCount    int            `gorm:"not null;default:0" json:"page_count"`
+	ElementCount int            `gorm:"not null;default:0" json:"element_count"`
+	Content      datatypes.JSON `gorm:"type:jsonb;not null" json:"-"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+func (s *BoardSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Board template date patterns decide which dates a new board's pages span
+const (
+	BoardTemplateDateMonth = "month" // a whole calendar month
+	BoardTemplateDateRange = "range" // a start date and a number of days
+	BoardTemplateDateNone  = "none"  // a single start date
+)
+
+// BoardTemplate is a starter kit for new boards: a skin, a page structure and a date pattern
+type BoardTemplate struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description"`
+	Skin        string         `gorm:"default:'default'" json:"skin"`
+	DatePattern string         `gorm:"not null;default:'none'" json:"date_pattern"`
+	DefaultDays int            `gorm:"not null;default:1" json:"default_days"`
+	Pages       datatypes.JSON `gorm:"type:jsonb;not null" json:"-"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+func (t *BoardTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
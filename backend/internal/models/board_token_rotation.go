@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Board token kinds that can be rotated
+const (
+	BoardTokenEdit   = "edit"
+	BoardTokenPublic = "public"
+)
+
+// BoardTokenRotation is an audit record of a board token being replaced. The retired token, kept
+// as a keyed hash, keeps working until GraceUntil, when set, so shared URLs can be updated.
+type BoardTokenRotation struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	BoardID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"board_id"`
+	Kind             string     `gorm:"not null;check:kind IN ('edit','public')" json:"kind"`
+	RetiredTokenHash string     `gorm:"not null;index" json:"-"`
+	GraceUntil       *time.Time `json:"grace_until,omitempty"`
+	Reason           string     `json:"reason"`
+	RotatedBy        *uuid.UUID `gorm:"type:uuid" json:"rotated_by,omitempty"`
+	ClientIP         string     `json:"client_ip"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func (r *BoardTokenRotation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
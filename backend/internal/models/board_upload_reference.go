@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BoardUploadReference records that elements of a board point at files in another board's
+// upload directory, as left by clones without copied files and by applied templates.
+// The directory is kept while any board still references it.
+type BoardUploadReference struct {
+	BoardID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"board_id"`
+	UploadBoardID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"upload_board_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -21,13 +21,18 @@ type Element struct {
 	Visible   bool           `gorm:"default:true" json:"visible"`
 	Locked    bool           `gorm:"default:false" json:"locked"`
 	Payload   datatypes.JSON `gorm:"type:jsonb" json:"payload"`
+	Version   int            `gorm:"not null;default:1" json:"version"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 func (e *Element) BeforeCreate(tx *gorm.DB) error {
 	if e.ID == uuid.Nil {
 		e.ID = uuid.New()
 	}
+	if e.Version == 0 {
+		e.Version = 1
+	}
 	return nil
 }
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Element revision actions
+const (
+	ElementActionCreate  = "create"
+	ElementActionUpdate  = "update"
+	ElementActionDelete  = "delete"
+	ElementActionReorder = "reorder"
+	ElementActionRestore = "restore"
+)
+
+// ElementRevision is an append-only record of a single element mutation.
+// Before is empty for creations and After is empty for deletions.
+type ElementRevision struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	ElementID uuid.UUID      `gorm:"type:uuid;not null;index" json:"element_id"`
+	PageID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"page_id"`
+	Action    string         `gorm:"not null;check:action IN ('create','update','delete','reorder','restore')" json:"action"`
+	Before    datatypes.JSON `gorm:"type:jsonb" json:"before"`
+	After     datatypes.JSON `gorm:"type:jsonb" json:"after"`
+	CreatedAt time.Time      `gorm:"index" json:"created_at"`
+}
+
+func (r *ElementRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Keyring groups boards under a single secret token so their owner can list them together
+type Keyring struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	Token     uuid.UUID `gorm:"-" json:"-"` // only known right after creation or when presented
+	TokenHash string    `gorm:"uniqueIndex;not null" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (k *Keyring) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	if k.TokenHash == "" {
+		if k.Token == uuid.Nil {
+			k.Token = uuid.New()
+		}
+		k.TokenHash = utils.HashToken(k.Token)
+	}
+	return nil
+}
+
+// KeyringBoard links a board to a keyring
+type KeyringBoard struct {
+	KeyringID uuid.UUID `gorm:"type:uuid;primaryKey" json:"keyring_id"`
+	BoardID   uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"board_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
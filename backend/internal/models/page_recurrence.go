@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Page recurrence frequencies
+const (
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// PageRecurrence is a board's rule for creating a page every day, week or month.
+// OccurrenceCount counts the occurrences already materialized and NextDate is the
+// date of the next one, so the scheduler never creates the same occurrence twice.
+type PageRecurrence struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	BoardID            uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"board_id"`
+	Frequency          string     `gorm:"not null" json:"frequency"`
+	Interval           int        `gorm:"column:repeat_interval;not null;default:1" json:"interval"`
+	TitlePattern       string     `gorm:"not null" json:"title_pattern"`
+	StartDate          time.Time  `gorm:"not null" json:"start_date"`
+	Timezone           string     `gorm:"not null;default:'UTC'" json:"timezone"`
+	PageTemplateID     *uuid.UUID `gorm:"type:uuid" json:"page_template_id,omitempty"`
+	Enabled            bool       `gorm:"not null;default:true" json:"enabled"`
+	OccurrenceCount    int        `gorm:"not null;default:0" json:"occurrence_count"`
+	NextDate           time.Time  `gorm:"not null;index" json:"next_date"`
+	LastOccurrenceDate *time.Time `json:"last_occurrence_date,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func (r *PageRecurrence) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
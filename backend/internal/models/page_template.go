@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// PageTemplate is a reusable page layout. Templates without a BoardID are global and can be
+// used on any board; OwnerBoardID records which board may delete a global template.
+type PageTemplate struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key" json:"id"`
+	BoardID      *uuid.UUID     `gorm:"type:uuid;index" json:"board_id,omitempty"`
+	OwnerBoardID *uuid.UUID     `gorm:"type:uuid;index" json:"-"`
+	Name         string         `gorm:"not null" json:"name"`
+	Description  string         `gorm:"type:text" json:"description"`
+	ElementCount int            `gorm:"not null;default:0" json:"element_count"`
+	Elements     datatypes.JSON `gorm:"type:jsonb;not null" json:"-"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+func (t *PageTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
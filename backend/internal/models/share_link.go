@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Share link roles. Commenter links currently read the board like viewer links.
+const (
+	ShareRoleViewer    = "viewer"
+	ShareRoleCommenter = "commenter"
+	ShareRoleEditor    = "editor"
+)
+
+// ShareLink is an extra, individually revocable token granting a role on a board or on one page
+type ShareLink struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	BoardID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"board_id"`
+	Token     uuid.UUID  `gorm:"-" json:"-"` // only known right after creation
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	Role      string     `gorm:"not null;check:role IN ('viewer','commenter','editor')" json:"role"`
+	PageID    *uuid.UUID `gorm:"type:uuid" json:"page_id,omitempty"`
+	Label     string     `json:"label"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (l *ShareLink) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	if l.TokenHash == "" {
+		if l.Token == uuid.Nil {
+			l.Token = uuid.New()
+		}
+		l.TokenHash = utils.HashToken(l.Token)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tag is a board-scoped label that can be attached to pages and elements
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	BoardID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tags_board_name" json:"board_id"`
+	Name      string    `gorm:"not null;uniqueIndex:idx_tags_board_name" json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// PageTag links a tag to a page
+type PageTag struct {
+	PageID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"page_id"`
+	TagID     uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"tag_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ElementTag links a tag to an element
+type ElementTag struct {
+	ElementID uuid.UUID `gorm:"type:uuid;primaryKey" json:"element_id"`
+	TagID     uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"tag_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupAccountRoutes sets up optional account, sign-in and board membership routes
+func SetupAccountRoutes(api fiber.Router, db *gorm.DB) {
+	accountHandler := handlers.NewAccountHandler(db)
+
+	// Sign-up and sign-in routes
+	auth := api.Group("/auth")
+	auth.Post("/register", accountHandler.Register)                                    // POST /api/v1/auth/register
+	auth.Post("/login", accountHandler.Login)                                          // POST /api/v1/auth/login
+	auth.Post("/logout", middleware.RequireSessionMiddleware(), accountHandler.Logout) // POST /api/v1/auth/logout
+	auth.Get("/me", middleware.RequireSessionMiddleware(), accountHandler.GetMe)       // GET /api/v1/auth/me
+
+	// Boards of the signed-in account
+	api.Get("/me/boards", middleware.RequireSessionMiddleware(), accountHandler.GetMyBoards) // GET /api/v1/me/boards
+
+	// Claiming a board requires both a session and the board's edit token
+	api.Post("/boards/:boardId/claim", middleware.RequireSessionMiddleware(), middleware.TokenValidationMiddleware(), accountHandler.ClaimBoard) // POST /api/v1/boards/:boardId/claim
+
+	// Member management routes (board owner only)
+	members := api.Group("/boards/:boardId/members", middleware.RequireSessionMiddleware())
+	members.Get("/", accountHandler.GetMembers)             // GET /api/v1/boards/:boardId/members
+	members.Put("/", accountHandler.SetMember)              // PUT /api/v1/boards/:boardId/members
+	members.Delete("/:userId", accountHandler.RemoveMember) // DELETE /api/v1/boards/:boardId/members/:userId
+}
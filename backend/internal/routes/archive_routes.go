@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupArchiveRoutes sets up board export and import routes
+func SetupArchiveRoutes(api fiber.Router, db *gorm.DB) {
+	archiveHandler := handlers.NewArchiveHandler(db)
+
+	// Import an archive as a new board (no token required, like board creation)
+	api.Post("/boards/import", archiveHandler.ImportBoard) // POST /api/v1/boards/import
+
+	// Export a board as a zip archive (requires edit token)
+	api.Get("/boards/:boardId/export", middleware.TokenValidationMiddleware(), archiveHandler.ExportBoard) // GET /api/v1/boards/:boardId/export
+}
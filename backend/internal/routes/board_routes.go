@@ -3,6 +3,7 @@ package routes
 import (
 	"junk-journal-board/internal/handlers"
 	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -11,18 +12,25 @@ import (
 func SetupBoardRoutes(api fiber.Router, db *gorm.DB) {
 	boardHandler := handlers.NewBoardHandler(db)
 
-	// Board listing route (no token required)
-	api.Get("/boards", boardHandler.GetAllBoards) // GET /api/v1/boards
+	// Global board listing route (requires admin credential)
+	api.Get("/boards", middleware.AdminMiddleware(), boardHandler.GetAllBoards) // GET /api/v1/boards
+
+	// Owner-scoped board listing route (edit tokens or keyring token in the body)
+	api.Post("/boards/directory", boardHandler.GetBoardDirectory) // POST /api/v1/boards/directory
 
 	// Board creation route (no token required)
 	api.Post("/boards", boardHandler.CreateBoard) // POST /api/v1/boards
 
-	// Board retrieval routes by token
-	api.Get("/boards/edit/:editToken", boardHandler.GetBoardByEditToken)       // GET /api/v1/boards/edit/:editToken
-	api.Get("/boards/public/:publicToken", boardHandler.GetBoardByPublicToken) // GET /api/v1/boards/public/:publicToken
+	// Board retrieval routes by token (retired tokens in their grace period are flagged)
+	tokenDeprecation := middleware.TokenDeprecationMiddleware(services.NewTokenRotationService(db).GracePeriodEnd)
+	api.Get("/boards/edit/:editToken", tokenDeprecation, boardHandler.GetBoardByEditToken)       // GET /api/v1/boards/edit/:editToken
+	api.Get("/boards/public/:publicToken", tokenDeprecation, boardHandler.GetBoardByPublicToken) // GET /api/v1/boards/public/:publicToken
 
 	// Board update and delete routes (require edit token)
 	boards := api.Group("/boards/:boardId")
 	boards.Put("/", middleware.TokenValidationMiddleware(), boardHandler.UpdateBoard)    // PUT /api/v1/boards/:boardId
 	boards.Delete("/", middleware.TokenValidationMiddleware(), boardHandler.DeleteBoard) // DELETE /api/v1/boards/:boardId
+
+	// Board duplication route (requires edit token)
+	boards.Post("/clone", middleware.TokenValidationMiddleware(), boardHandler.CloneBoard) // POST /api/v1/boards/:boardId/clone
 }
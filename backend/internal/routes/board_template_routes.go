@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupBoardTemplateRoutes sets up board template routes; boards are created from a
+// template through POST /api/v1/boards with a template_id
+func SetupBoardTemplateRoutes(api fiber.Router, db *gorm.DB) {
+	boardTemplateHandler := handlers.NewBoardTemplateHandler(db)
+
+	// Board template routes (no token required)
+	api.Get("/board-templates", boardTemplateHandler.GetBoardTemplates)            // GET /api/v1/board-templates
+	api.Get("/board-templates/:templateId", boardTemplateHandler.GetBoardTemplate) // GET /api/v1/board-templates/:templateId
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupBoardUnlockRoutes sets up passphrase protection routes for public board access
+func SetupBoardUnlockRoutes(api fiber.Router, db *gorm.DB, rateLimiter *services.RateLimiter) {
+	boardUnlockHandler := handlers.NewBoardUnlockHandler(db, rateLimiter)
+
+	// Unlocking a protected board (the passphrase is the credential)
+	api.Post("/boards/:boardId/unlock", boardUnlockHandler.Unlock) // POST /api/v1/boards/:boardId/unlock
+
+	// Managing the passphrase requires the board's edit token or a signed-in owner
+	passphrase := api.Group("/boards/:boardId/passphrase", middleware.TokenValidationMiddleware())
+	passphrase.Put("/", boardUnlockHandler.SetPassphrase)       // PUT /api/v1/boards/:boardId/passphrase
+	passphrase.Delete("/", boardUnlockHandler.RemovePassphrase) // DELETE /api/v1/boards/:boardId/passphrase
+}
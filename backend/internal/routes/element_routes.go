@@ -3,13 +3,14 @@ package routes
 import (
 	"junk-journal-board/internal/handlers"
 	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func SetupElementRoutes(api fiber.Router, db *gorm.DB) {
-	elementHandler := handlers.NewElementHandler(db)
+func SetupElementRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub) {
+	elementHandler := handlers.NewElementHandler(db, liveHub)
 
 	// All element routes require authentication
 	elements := api.Group("/boards/:boardId/pages/:pageId/elements")
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupHistoryRoutes sets up element history and restore routes
+func SetupHistoryRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub) {
+	historyHandler := handlers.NewHistoryHandler(db, liveHub)
+
+	// History can reveal deleted content, so all history routes require an edit token
+	history := api.Group("/boards/:boardId/pages/:pageId/history", middleware.TokenValidationMiddleware())
+
+	history.Get("/", historyHandler.GetPageHistory)                     // GET /api/v1/boards/:boardId/pages/:pageId/history
+	history.Get("/snapshot", historyHandler.GetPageSnapshot)            // GET /api/v1/boards/:boardId/pages/:pageId/history/snapshot
+	history.Post("/restore", historyHandler.RestorePage)                // POST /api/v1/boards/:boardId/pages/:pageId/history/restore
+	history.Post("/:revisionId/restore", historyHandler.RestoreElement) // POST /api/v1/boards/:boardId/pages/:pageId/history/:revisionId/restore
+}
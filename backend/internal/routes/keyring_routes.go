@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupKeyringRoutes sets up keyring routes; the keyring token in the URL is the credential
+func SetupKeyringRoutes(api fiber.Router, db *gorm.DB) {
+	keyringHandler := handlers.NewKeyringHandler(db)
+
+	api.Post("/keyrings", keyringHandler.CreateKeyring) // POST /api/v1/keyrings
+
+	keyrings := api.Group("/keyrings/:keyringToken")
+	keyrings.Get("/", keyringHandler.GetKeyring)                    // GET /api/v1/keyrings/:keyringToken
+	keyrings.Delete("/", keyringHandler.DeleteKeyring)              // DELETE /api/v1/keyrings/:keyringToken
+	keyrings.Post("/boards", keyringHandler.AddBoards)              // POST /api/v1/keyrings/:keyringToken/boards
+	keyrings.Delete("/boards/:boardId", keyringHandler.RemoveBoard) // DELETE /api/v1/keyrings/:keyringToken/boards/:boardId
+}
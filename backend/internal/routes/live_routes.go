@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupLiveRoutes sets up the real-time WebSocket channel for boards
+func SetupLiveRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub, presenceService *services.PresenceService) {
+	liveHandler := handlers.NewLiveHandler(db, liveHub, presenceService)
+
+	// Live channel (edit token for read-write sessions, public token for read-only sessions)
+	api.Get("/boards/:boardId/live", liveHandler.Upgrade, liveHandler.Live()) // GET /api/v1/boards/:boardId/live
+}
@@ -3,14 +3,15 @@ package routes
 import (
 	"junk-journal-board/internal/handlers"
 	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 // SetupPageRoutes sets up all page-related routes
-func SetupPageRoutes(api fiber.Router, db *gorm.DB) {
-	pageHandler := handlers.NewPageHandler(db)
+func SetupPageRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub) {
+	pageHandler := handlers.NewPageHandler(db, liveHub)
 
 	// Page routes under /boards/:boardId/pages
 	pages := api.Group("/boards/:boardId/pages")
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupPresenceRoutes sets up the presence roster routes
+func SetupPresenceRoutes(api fiber.Router, db *gorm.DB, presenceService *services.PresenceService) {
+	presenceHandler := handlers.NewPresenceHandler(db, presenceService)
+
+	// Presence snapshot (edit or public token required)
+	api.Get("/boards/:boardId/presence", presenceHandler.GetPresence) // GET /api/v1/boards/:boardId/presence
+}
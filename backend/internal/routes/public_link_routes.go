@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupPublicLinkRoutes sets up routes limiting a board's public link
+func SetupPublicLinkRoutes(api fiber.Router, db *gorm.DB) {
+	publicLinkHandler := handlers.NewPublicLinkHandler(db)
+
+	// Public link limits require the board's edit token or a signed-in editor
+	publicLink := api.Group("/boards/:boardId/public-link", middleware.TokenValidationMiddleware())
+	publicLink.Get("/", publicLinkHandler.GetPublicLink)    // GET /api/v1/boards/:boardId/public-link
+	publicLink.Put("/", publicLinkHandler.UpdatePublicLink) // PUT /api/v1/boards/:boardId/public-link
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupRecurrenceRoutes sets up recurring page rule routes
+func SetupRecurrenceRoutes(api fiber.Router, db *gorm.DB) {
+	recurrenceHandler := handlers.NewRecurrenceHandler(db)
+
+	// All recurrence routes require an edit token
+	recurrence := api.Group("/boards/:boardId/recurrence", middleware.TokenValidationMiddleware())
+
+	recurrence.Get("/", recurrenceHandler.GetRecurrence)       // GET /api/v1/boards/:boardId/recurrence
+	recurrence.Put("/", recurrenceHandler.SetRecurrence)       // PUT /api/v1/boards/:boardId/recurrence
+	recurrence.Delete("/", recurrenceHandler.DeleteRecurrence) // DELETE /api/v1/boards/:boardId/recurrence
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupRenderRoutes sets up server-side page rendering routes
+func SetupRenderRoutes(api fiber.Router, db *gorm.DB) {
+	renderHandler := handlers.NewRenderHandler(db)
+
+	// Public route (no token required, but token can be provided for validation)
+	api.Get("/boards/:boardId/pages/:pageId/render", renderHandler.RenderPage) // GET /api/v1/boards/:boardId/pages/:pageId/render
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupSearchRoutes sets up board search routes
+func SetupSearchRoutes(api fiber.Router, db *gorm.DB) {
+	searchHandler := handlers.NewSearchHandler(db)
+
+	// Search route (edit or public token required)
+	api.Get("/boards/:boardId/search", searchHandler.SearchBoard) // GET /api/v1/boards/:boardId/search
+}
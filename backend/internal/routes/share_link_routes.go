@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupShareLinkRoutes sets up per-collaborator share link routes
+func SetupShareLinkRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub) {
+	shareLinkHandler := handlers.NewShareLinkHandler(db, liveHub)
+
+	// Opening a board through a share link (the share token is the credential)
+	api.Get("/boards/share/:shareToken", shareLinkHandler.GetSharedBoard) // GET /api/v1/boards/share/:shareToken
+
+	// Managing share links requires the board's edit token
+	shareLinks := api.Group("/boards/:boardId/share-links", middleware.TokenValidationMiddleware())
+	shareLinks.Get("/", shareLinkHandler.GetShareLinks)             // GET /api/v1/boards/:boardId/share-links
+	shareLinks.Post("/", shareLinkHandler.CreateShareLink)          // POST /api/v1/boards/:boardId/share-links
+	shareLinks.Delete("/:linkId", shareLinkHandler.RevokeShareLink) // DELETE /api/v1/boards/:boardId/share-links/:linkId
+}
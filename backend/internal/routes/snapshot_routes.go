@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupSnapshotRoutes sets up board snapshot routes
+func SetupSnapshotRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub) {
+	snapshotHandler := handlers.NewSnapshotHandler(db, liveHub)
+
+	// All snapshot routes require an edit token
+	snapshots := api.Group("/boards/:boardId/snapshots", middleware.TokenValidationMiddleware())
+
+	snapshots.Post("/", snapshotHandler.CreateSnapshot)                     // POST /api/v1/boards/:boardId/snapshots
+	snapshots.Get("/", snapshotHandler.GetSnapshots)                        // GET /api/v1/boards/:boardId/snapshots
+	snapshots.Put("/pinned", snapshotHandler.PinSnapshot)                   // PUT /api/v1/boards/:boardId/snapshots/pinned
+	snapshots.Get("/:snapshotId", snapshotHandler.GetSnapshot)              // GET /api/v1/boards/:boardId/snapshots/:snapshotId
+	snapshots.Get("/:snapshotId/diff", snapshotHandler.DiffSnapshot)        // GET /api/v1/boards/:boardId/snapshots/:snapshotId/diff
+	snapshots.Post("/:snapshotId/restore", snapshotHandler.RestoreSnapshot) // POST /api/v1/boards/:boardId/snapshots/:snapshotId/restore
+	snapshots.Delete("/:snapshotId", snapshotHandler.DeleteSnapshot)        // DELETE /api/v1/boards/:boardId/snapshots/:snapshotId
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupTagRoutes sets up tag routes for pages and elements
+func SetupTagRoutes(api fiber.Router, db *gorm.DB) {
+	tagHandler := handlers.NewTagHandler(db)
+
+	// Tag routes under /boards/:boardId/tags
+	tags := api.Group("/boards/:boardId/tags")
+
+	// Public routes (no token required, but token can be provided for validation)
+	tags.Get("/", tagHandler.GetTags)      // GET /api/v1/boards/:boardId/tags
+	tags.Get("/:tagId", tagHandler.GetTag) // GET /api/v1/boards/:boardId/tags/:tagId
+
+	// Protected routes (require edit token)
+	tags.Post("/", middleware.TokenValidationMiddleware(), tagHandler.CreateTag)                                // POST /api/v1/boards/:boardId/tags
+	tags.Put("/:tagId", middleware.TokenValidationMiddleware(), tagHandler.UpdateTag)                           // PUT /api/v1/boards/:boardId/tags/:tagId
+	tags.Delete("/:tagId", middleware.TokenValidationMiddleware(), tagHandler.DeleteTag)                        // DELETE /api/v1/boards/:boardId/tags/:tagId
+	tags.Post("/:tagId/pages/:pageId", middleware.TokenValidationMiddleware(), tagHandler.TagPage)              // POST /api/v1/boards/:boardId/tags/:tagId/pages/:pageId
+	tags.Delete("/:tagId/pages/:pageId", middleware.TokenValidationMiddleware(), tagHandler.UntagPage)          // DELETE /api/v1/boards/:boardId/tags/:tagId/pages/:pageId
+	tags.Post("/:tagId/elements/:elementId", middleware.TokenValidationMiddleware(), tagHandler.TagElement)     // POST /api/v1/boards/:boardId/tags/:tagId/elements/:elementId
+	tags.Delete("/:tagId/elements/:elementId", middleware.TokenValidationMiddleware(), tagHandler.UntagElement) // DELETE /api/v1/boards/:boardId/tags/:tagId/elements/:elementId
+}
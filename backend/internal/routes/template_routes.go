@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupTemplateRoutes sets up page template routes
+func SetupTemplateRoutes(api fiber.Router, db *gorm.DB) {
+	templateHandler := handlers.NewTemplateHandler(db)
+
+	// Global template library (no token required)
+	api.Get("/templates", templateHandler.GetGlobalTemplates) // GET /api/v1/templates
+
+	// Board template routes require an edit token
+	templates := api.Group("/boards/:boardId/templates", middleware.TokenValidationMiddleware())
+
+	templates.Post("/", templateHandler.CreateTemplate)              // POST /api/v1/boards/:boardId/templates
+	templates.Get("/", templateHandler.GetTemplates)                 // GET /api/v1/boards/:boardId/templates
+	templates.Get("/:templateId", templateHandler.GetTemplate)       // GET /api/v1/boards/:boardId/templates/:templateId
+	templates.Delete("/:templateId", templateHandler.DeleteTemplate) // DELETE /api/v1/boards/:boardId/templates/:templateId
+}
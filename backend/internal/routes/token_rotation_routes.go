@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupTokenRotationRoutes sets up board token rotation and revocation routes
+func SetupTokenRotationRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub) {
+	tokenRotationHandler := handlers.NewTokenRotationHandler(db, liveHub)
+
+	// Token routes require the board's edit token or a signed-in owner
+	tokens := api.Group("/boards/:boardId/tokens", middleware.TokenValidationMiddleware())
+	tokens.Post("/rotate", tokenRotationHandler.RotateToken)      // POST /api/v1/boards/:boardId/tokens/rotate
+	tokens.Delete("/grace", tokenRotationHandler.EndGracePeriods) // DELETE /api/v1/boards/:boardId/tokens/grace
+	tokens.Get("/rotations", tokenRotationHandler.GetRotations)   // GET /api/v1/boards/:boardId/tokens/rotations
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"junk-journal-board/internal/handlers"
+	"junk-journal-board/internal/middleware"
+	"junk-journal-board/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// SetupTrashRoutes sets up routes for listing and restoring trashed items
+func SetupTrashRoutes(api fiber.Router, db *gorm.DB, liveHub *services.LiveHub) {
+	trashHandler := handlers.NewTrashHandler(db, liveHub)
+
+	// All trash routes require an edit token
+	boards := api.Group("/boards/:boardId")
+	boards.Get("/trash", middleware.TokenValidationMiddleware(), trashHandler.GetTrash)                                    // GET /api/v1/boards/:boardId/trash
+	boards.Post("/restore", middleware.TokenValidationMiddleware(), trashHandler.RestoreBoard)                             // POST /api/v1/boards/:boardId/restore
+	boards.Post("/trash/pages/:pageId/restore", middleware.TokenValidationMiddleware(), trashHandler.RestorePage)          // POST /api/v1/boards/:boardId/trash/pages/:pageId/restore
+	boards.Post("/trash/elements/:elementId/restore", middleware.TokenValidationMiddleware(), trashHandler.RestoreElement) // POST /api/v1/boards/:boardId/trash/elements/:elementId/restore
+}
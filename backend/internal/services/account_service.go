@@ -0,0 +1,358 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultSessionTTL is how long a sign-in stays valid
+const defaultSessionTTL = 30 * 24 * time.Hour
+
+// Account errors
+var (
+	ErrEmailTaken          = errors.New("email is already registered")
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrBoardAlreadyClaimed = errors.New("board is already claimed by another account")
+	ErrCannotChangeOwner   = errors.New("the board owner cannot be changed")
+)
+
+// MemberBoard is a board a user has access to together with the user's role
+type MemberBoard struct {
+	BoardSummary
+	Role string
+}
+
+// BoardMemberUser is a board member with the member's account
+type BoardMemberUser struct {
+	models.BoardMember
+	Email       string
+	DisplayName string
+}
+
+// AccountService manages optional user accounts, their sessions and board memberships
+type AccountService struct {
+	db           *gorm.DB
+	boardService *BoardService
+	sessionTTL   time.Duration
+	now          func() time.Time
+}
+
+func NewAccountService(db *gorm.DB) *AccountService {
+	return &AccountService{
+		db:           db,
+		boardService: NewBoardService(db),
+		sessionTTL:   parseSessionTTL(os.Getenv("SESSION_TTL_HOURS")),
+		now:          time.Now,
+	}
+}
+
+// parseSessionTTL reads a session lifetime in hours, falling back to the default when it is
+// unset or not a positive number
+func parseSessionTTL(value string) time.Duration {
+	if hours, err := strconv.Atoi(value); err == nil && hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return defaultSessionTTL
+}
+
+// Register creates an account and signs it in, returning the new session token
+func (s *AccountService) Register(email, password, displayName string) (*models.User, string, *models.Session, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &models.User{
+		Email:        NormalizeEmail(email),
+		PasswordHash: string(hash),
+		DisplayName:  strings.TrimSpace(displayName),
+	}
+
+	var existing int64
+	if err := s.db.Model(&models.User{}).Where("email = ?", user.Email).Count(&existing).Error; err != nil {
+		return nil, "", nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if existing > 0 {
+		return nil, "", nil, ErrEmailTaken
+	}
+
+	if err := s.db.Create(user).Error; err != nil {
+		return nil, "", nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	token, session, err := s.createSession(user.ID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return user, token, session, nil
+}
+
+// Login checks an email and password and opens a new session
+func (s *AccountService) Login(email, password string) (*models.User, string, *models.Session, error) {
+	var user models.User
+	if err := s.db.Where("email = ?", NormalizeEmail(email)).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, "", nil, ErrInvalidCredentials
+		}
+		return nil, "", nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, "", nil, ErrInvalidCredentials
+	}
+
+	// Drop the user's expired sessions while we are here
+	if err := s.db.Where("user_id = ? AND expires_at <= ?", user.ID, s.now()).Delete(&models.Session{}).Error; err != nil {
+		return nil, "", nil, fmt.Errorf("failed to clean up sessions: %w", err)
+	}
+
+	token, session, err := s.createSession(user.ID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return &user, token, session, nil
+}
+
+// Logout ends the session of a token
+func (s *AccountService) Logout(token string) error {
+	if err := s.db.Where("token_hash = ?", hashSessionToken(token)).Delete(&models.Session{}).Error; err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateSession returns the user a session token belongs to
+func (s *AccountService) AuthenticateSession(token string) (uuid.UUID, error) {
+	var session models.Session
+	err := s.db.Where("token_hash = ?", hashSessionToken(token)).First(&session).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return uuid.Nil, utils.ErrUnauthorized
+		}
+		return uuid.Nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if sessionExpired(&session, s.now()) {
+		return uuid.Nil, utils.ErrUnauthorized
+	}
+
+	return session.UserID, nil
+}
+
+// sessionExpired reports whether a session has ended; it ends at its expiry time
+func sessionExpired(session *models.Session, now time.Time) bool {
+	return !now.Before(session.ExpiresAt)
+}
+
+// GetUser retrieves an account by ID
+func (s *AccountService) GetUser(userID uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := s.db.Where("id = ?", userID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// ClaimBoard makes a user the owner of a board; the caller must hold the board's edit token.
+// Claiming a board the user already owns is a no-op.
+func (s *AccountService) ClaimBoard(userID, boardID, editToken uuid.UUID) error {
+	if err := s.boardService.ValidateBoardEditToken(boardID, editToken); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var owner models.BoardMember
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("board_id = ? AND role = ?", boardID, models.BoardRoleOwner).
+			First(&owner).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to check board owner: %w", err)
+		}
+
+		var currentOwner *models.BoardMember
+		if err == nil {
+			currentOwner = &owner
+		}
+		claim, err := claimBoardOwner(currentOwner, userID)
+		if !claim || err != nil {
+			return err
+		}
+
+		member := models.BoardMember{BoardID: boardID, UserID: userID, Role: models.BoardRoleOwner}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "board_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+		}).Create(&member).Error
+		if err != nil {
+			return fmt.Errorf("failed to claim board: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// claimBoardOwner decides a claim given the board's current owner, if any. It reports whether
+// the user becomes the owner; claiming an owned board fails unless the user already owns it.
+func claimBoardOwner(owner *models.BoardMember, userID uuid.UUID) (bool, error) {
+	if owner == nil {
+		return true, nil
+	}
+	if owner.UserID != userID {
+		return false, ErrBoardAlreadyClaimed
+	}
+	return false, nil
+}
+
+// ListMemberBoards returns a page of the boards a user is a member of with the user's roles
+func (s *AccountService) ListMemberBoards(userID uuid.UUID, filter ListFilter, params ListParams) ([]MemberBoard, string, error) {
+	var members []models.BoardMember
+	if err := s.db.Where("user_id = ?", userID).Find(&members).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to get memberships: %w", err)
+	}
+
+	roles := make(map[uuid.UUID]string, len(members))
+	boardIDs := make([]uuid.UUID, len(members))
+	for i, member := range members {
+		roles[member.BoardID] = member.Role
+		boardIDs[i] = member.BoardID
+	}
+
+	summaries, next, err := s.boardService.ListBoards(boardIDs, filter, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	boards := make([]MemberBoard, len(summaries))
+	for i, summary := range summaries {
+		boards[i] = MemberBoard{BoardSummary: summary, Role: roles[summary.ID]}
+	}
+
+	return boards, next, nil
+}
+
+// ListMembers returns the members of a board, owner first
+func (s *AccountService) ListMembers(boardID uuid.UUID) ([]BoardMemberUser, error) {
+	var members []BoardMemberUser
+	err := s.db.Table("board_members").
+		Select("board_members.*, users.email, users.display_name").
+		Joins("JOIN users ON users.id = board_members.user_id").
+		Where("board_members.board_id = ?", boardID).
+		Order("CASE board_members.role WHEN 'owner' THEN 0 WHEN 'editor' THEN 1 ELSE 2 END, board_members.created_at ASC").
+		Scan(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board members: %w", err)
+	}
+
+	return members, nil
+}
+
+// SetMember grants the account with the given email a non-owner role on a board
+func (s *AccountService) SetMember(boardID uuid.UUID, email, role string) (*BoardMemberUser, error) {
+	var user models.User
+	if err := s.db.Where("email = ?", NormalizeEmail(email)).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	member := models.BoardMember{BoardID: boardID, UserID: user.ID, Role: role}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var existing models.BoardMember
+		err := tx.Where("board_id = ? AND user_id = ?", boardID, user.ID).First(&existing).Error
+		if err == nil && existing.Role == models.BoardRoleOwner {
+			return ErrCannotChangeOwner
+		}
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to get board member: %w", err)
+		}
+
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "board_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+		}).Create(&member).Error
+		if err != nil {
+			return fmt.Errorf("failed to set board member: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoardMemberUser{BoardMember: member, Email: user.Email, DisplayName: user.DisplayName}, nil
+}
+
+// RemoveMember revokes a user's non-owner membership of a board
+func (s *AccountService) RemoveMember(boardID, userID uuid.UUID) error {
+	var member models.BoardMember
+	if err := s.db.Where("board_id = ? AND user_id = ?", boardID, userID).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("failed to get board member: %w", err)
+	}
+
+	if member.Role == models.BoardRoleOwner {
+		return ErrCannotChangeOwner
+	}
+
+	if err := s.db.Where("board_id = ? AND user_id = ?", boardID, userID).Delete(&models.BoardMember{}).Error; err != nil {
+		return fmt.Errorf("failed to remove board member: %w", err)
+	}
+
+	return nil
+}
+
+// createSession opens a session for a user and returns its token, which is never stored
+func (s *AccountService) createSession(userID uuid.UUID) (string, *models.Session, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, fmt.Errorf("failed to generate session token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	session := &models.Session{
+		UserID:    userID,
+		TokenHash: hashSessionToken(token),
+		ExpiresAt: s.now().Add(s.sessionTTL),
+	}
+	if err := s.db.Create(session).Error; err != nil {
+		return "", nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return token, session, nil
+}
+
+// hashSessionToken returns the hex SHA-256 of a session token as stored in the database
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeEmail trims and lowercases an email address
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
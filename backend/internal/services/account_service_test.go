@@ -0,0 +1,119 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	if got := NormalizeEmail("  Ada.Lovelace@Example.COM "); got != "ada.lovelace@example.com" {
+		t.Errorf("unexpected email %q", got)
+	}
+}
+
+func TestHashSessionToken(t *testing.T) {
+	hash := hashSessionToken("token")
+	if len(hash) != 64 {
+		t.Fatalf("expected a hex SHA-256, got %q", hash)
+	}
+	if hash != hashSessionToken("token") {
+		t.Error("expected hashing to be deterministic")
+	}
+	if hash == hashSessionToken("other") {
+		t.Error("expected different tokens to hash differently")
+	}
+}
+
+func TestParseSessionTTL(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", defaultSessionTTL},
+		{"12", 12 * time.Hour},
+		{"0", defaultSessionTTL},
+		{"-3", defaultSessionTTL},
+		{"a day", defaultSessionTTL},
+	}
+
+	for _, tt := range tests {
+		if got := parseSessionTTL(tt.value); got != tt.expected {
+			t.Errorf("parseSessionTTL(%q): expected %v, got %v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestSessionExpired(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		expected  bool
+	}{
+		{name: "Expires later", expiresAt: now.Add(time.Minute), expected: false},
+		{name: "Expires now", expiresAt: now, expected: true},
+		{name: "Expired earlier", expiresAt: now.Add(-time.Minute), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &models.Session{ExpiresAt: tt.expiresAt}
+			if got := sessionExpired(session, now); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClaimBoardOwner(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		owner         *models.BoardMember
+		expectedClaim bool
+		expectedErr   error
+	}{
+		{name: "Unclaimed board", owner: nil, expectedClaim: true},
+		{name: "Already owned by the user", owner: &models.BoardMember{UserID: userID, Role: models.BoardRoleOwner}},
+		{name: "Owned by another account", owner: &models.BoardMember{UserID: uuid.New(), Role: models.BoardRoleOwner}, expectedErr: ErrBoardAlreadyClaimed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim, err := claimBoardOwner(tt.owner, userID)
+			if claim != tt.expectedClaim || err != tt.expectedErr {
+				t.Errorf("Expected %v, %v, got %v, %v", tt.expectedClaim, tt.expectedErr, claim, err)
+			}
+		})
+	}
+}
+
+func TestCheckMemberRole(t *testing.T) {
+	tests := []struct {
+		role     string
+		minRole  string
+		expected error
+	}{
+		{models.BoardRoleViewer, models.BoardRoleViewer, nil},
+		{models.BoardRoleViewer, models.BoardRoleEditor, utils.ErrForbidden},
+		{models.BoardRoleEditor, models.BoardRoleViewer, nil},
+		{models.BoardRoleEditor, models.BoardRoleEditor, nil},
+		{models.BoardRoleEditor, models.BoardRoleOwner, utils.ErrForbidden},
+		{models.BoardRoleOwner, models.BoardRoleOwner, nil},
+		{"admin", models.BoardRoleViewer, utils.ErrForbidden},
+		{"", models.BoardRoleViewer, utils.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		if got := checkMemberRole(tt.role, tt.minRole); got != tt.expected {
+			t.Errorf("checkMemberRole(%q, %q): expected %v, got %v", tt.role, tt.minRole, tt.expected, got)
+		}
+	}
+}
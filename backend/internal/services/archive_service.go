@@ -0,0 +1,521 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Archive layout constants. Version 2 manifests carry an explicit board DTO; version 1 manifests
+// embedded the board model, whose content fields have the same names, so both are importable.
+const (
+	ArchiveFormatVersion = 2
+	ArchiveManifestName  = "manifest.json"
+	ArchiveAssetsDir     = "assets"
+)
+
+// Import limits, checked before entries are read and again while they are
+const (
+	MaxArchiveEntries      = 1000
+	MaxArchiveManifestSize = 20 * 1024 * 1024  // 20MB in bytes
+	MaxArchiveTotalSize    = 200 * 1024 * 1024 // 200MB in bytes, manifest and assets together
+)
+
+// ErrInvalidArchive is returned when an uploaded archive cannot be imported
+var ErrInvalidArchive = errors.New("invalid board archive")
+
+// Errors of entryReader when an archive holds more data than allowed
+var (
+	errEntryTooLarge   = errors.New("archive entry too large")
+	errArchiveTooLarge = errors.New("archive too large")
+)
+
+// BoardArchive holds everything needed to stream a board export
+type BoardArchive struct {
+	Manifest dto.ArchiveManifest
+	// files maps archive-relative asset paths to local file system paths
+	files map[string]string
+}
+
+type ArchiveService struct {
+	db            *gorm.DB
+	uploadService *UploadService
+}
+
+func NewArchiveService(db *gorm.DB) *ArchiveService {
+	return &ArchiveService{
+		db:            db,
+		uploadService: NewUploadService(),
+	}
+}
+
+// PrepareExport loads a board with pages and elements and builds its archive manifest
+func (s *ArchiveService) PrepareExport(boardID uuid.UUID) (*BoardArchive, error) {
+	var board models.Board
+	err := s.db.Preload("Pages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_idx ASC")
+	}).Preload("Pages.Elements", func(db *gorm.DB) *gorm.DB {
+		return db.Order("z ASC")
+	}).Where("id = ?", boardID).First(&board).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load board for export: %w", err)
+	}
+
+	archive := &BoardArchive{
+		files: make(map[string]string),
+	}
+	boardDir := filepath.Clean(s.uploadService.GetBoardUploadDir(boardID))
+	assets := make([]dto.ArchiveAsset, 0)
+
+	for i := range board.Pages {
+		for j := range board.Pages[i].Elements {
+			element := &board.Pages[i].Elements[j]
+
+			payload, err := rewritePayloadURL(element.Payload, func(rawURL string) (string, bool) {
+				localPath, ok := s.uploadService.ResolveLocalPath(rawURL)
+				if !ok || filepath.Dir(localPath) != boardDir {
+					return "", false
+				}
+
+				archivePath := path.Join(ArchiveAssetsDir, filepath.Base(localPath))
+				if _, seen := archive.files[archivePath]; !seen {
+					info, err := os.Stat(localPath)
+					if err != nil {
+						// Keep the original URL when the file is gone
+						return "", false
+					}
+					archive.files[archivePath] = localPath
+					assets = append(assets, dto.ArchiveAsset{
+						Path:     archivePath,
+						Filename: filepath.Base(localPath),
+						Size:     info.Size(),
+						MimeType: s.uploadService.GetMimeType(localPath),
+					})
+				}
+				return archivePath, true
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewrite payload for element %s: %w", element.ID, err)
+			}
+			element.Payload = payload
+		}
+	}
+
+	archive.Manifest = dto.ArchiveManifest{
+		Version:    ArchiveFormatVersion,
+		ExportedAt: time.Now().UTC(),
+		Board:      newArchiveBoard(&board),
+		Assets:     assets,
+	}
+
+	return archive, nil
+}
+
+// WriteArchive streams the manifest and referenced asset files as a zip
+func (s *ArchiveService) WriteArchive(archive *BoardArchive, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	manifestWriter, err := zw.Create(ArchiveManifestName)
+	if err != nil {
+		return fmt.Errorf("failed to create manifest entry: %w", err)
+	}
+	encoder := json.NewEncoder(manifestWriter)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(archive.Manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	for _, asset := range archive.Manifest.Assets {
+		if err := s.writeArchiveFile(zw, asset.Path, archive.files[asset.Path]); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchiveFile copies a single local file into the zip
+func (s *ArchiveService) writeArchiveFile(zw *zip.Writer, archivePath, localPath string) error {
+	src, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open asset %s: %w", localPath, err)
+	}
+	defer src.Close()
+
+	dst, err := zw.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to create archive entry %s: %w", archivePath, err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("failed to write asset %s: %w", archivePath, err)
+	}
+
+	return nil
+}
+
+// ImportBoard creates a brand-new board from an export archive with fresh IDs and tokens
+func (s *ArchiveService) ImportBoard(r io.ReaderAt, size int64) (*models.Board, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a zip file", ErrInvalidArchive)
+	}
+
+	if len(zr.File) > MaxArchiveEntries {
+		return nil, fmt.Errorf("%w: more than %d entries", ErrInvalidArchive, MaxArchiveEntries)
+	}
+
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, file := range zr.File {
+		entries[file.Name] = file
+	}
+
+	// Uncompressed bytes left for the entries read, so lying headers cannot exceed the total either
+	remaining := int64(MaxArchiveTotalSize)
+
+	manifest, err := readArchiveManifest(entries[ArchiveManifestName], &remaining)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkArchiveAssetsSize(manifest.Assets, entries, remaining); err != nil {
+		return nil, err
+	}
+
+	boardID := uuid.New()
+
+	// Re-upload assets under the new board; the files are removed again if the import fails
+	assetURLs, err := s.importAssets(manifest.Assets, entries, boardID, &remaining)
+	if err != nil {
+		s.removeBoardUploads(boardID)
+		return nil, err
+	}
+
+	var board *models.Board
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		board, err = copyBoardContent(tx, archiveBoardModel(&manifest.Board), boardID, func(rawURL string) (string, bool) {
+			newURL, ok := assetURLs[rawURL]
+			return newURL, ok
+		})
+		return err
+	})
+	if err != nil {
+		s.removeBoardUploads(boardID)
+		return nil, err
+	}
+
+	// Reload the board to get pages for the response
+	if err := s.db.Preload("Pages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_idx ASC")
+	}).Where("id = ?", boardID).First(board).Error; err != nil {
+		return nil, fmt.Errorf("failed to reload board: %w", err)
+	}
+
+	return board, nil
+}
+
+// readArchiveManifest decodes and validates the manifest entry of an archive, counting the bytes
+// read against the archive's remaining total
+func readArchiveManifest(entry *zip.File, remaining *int64) (*dto.ArchiveManifest, error) {
+	if entry == nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidArchive, ArchiveManifestName)
+	}
+	if entry.UncompressedSize64 > MaxArchiveManifestSize {
+		return nil, fmt.Errorf("%w: manifest too large", ErrInvalidArchive)
+	}
+
+	src, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: unreadable manifest", ErrInvalidArchive)
+	}
+	defer src.Close()
+
+	var manifest dto.ArchiveManifest
+	if err := json.NewDecoder(newEntryReader(src, MaxArchiveManifestSize, remaining)).Decode(&manifest); err != nil {
+		if errors.Is(err, errEntryTooLarge) {
+			return nil, fmt.Errorf("%w: manifest too large", ErrInvalidArchive)
+		}
+		if errors.Is(err, errArchiveTooLarge) {
+			return nil, fmt.Errorf("%w: archive too large", ErrInvalidArchive)
+		}
+		return nil, fmt.Errorf("%w: malformed manifest", ErrInvalidArchive)
+	}
+
+	if manifest.Version < 1 || manifest.Version > ArchiveFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, manifest.Version)
+	}
+	if strings.TrimSpace(manifest.Board.Title) == "" {
+		return nil, fmt.Errorf("%w: board title is required", ErrInvalidArchive)
+	}
+	switch manifest.Board.Skin {
+	case "default", "wood", "notebook", "cork":
+	default:
+		manifest.Board.Skin = "default"
+	}
+
+	for _, page := range manifest.Board.Pages {
+		for _, element := range page.Elements {
+			switch element.Kind {
+			case "text", "image", "sticker", "shape":
+			default:
+				return nil, fmt.Errorf("%w: unknown element kind %q", ErrInvalidArchive, element.Kind)
+			}
+		}
+	}
+
+	return &manifest, nil
+}
+
+// checkArchiveAssetsSize rejects archives whose assets declare more data than the remaining total
+// before any of them is saved
+func checkArchiveAssetsSize(assets []dto.ArchiveAsset, entries map[string]*zip.File, remaining int64) error {
+	counted := make(map[string]bool, len(assets))
+	var total uint64
+	for _, asset := range assets {
+		entry, ok := entries[asset.Path]
+		if !ok || counted[asset.Path] {
+			continue
+		}
+		counted[asset.Path] = true
+
+		total += entry.UncompressedSize64
+		if total > uint64(remaining) {
+			return fmt.Errorf("%w: archive too large", ErrInvalidArchive)
+		}
+	}
+	return nil
+}
+
+// importAssets saves the archive's asset files for a board and maps archive paths to new URLs,
+// counting the bytes read against the archive's remaining total
+func (s *ArchiveService) importAssets(assets []dto.ArchiveAsset, entries map[string]*zip.File, boardID uuid.UUID, remaining *int64) (map[string]string, error) {
+	urls := make(map[string]string, len(assets))
+
+	for _, asset := range assets {
+		if _, seen := urls[asset.Path]; seen {
+			continue
+		}
+
+		entry, ok := entries[asset.Path]
+		if !ok || !strings.HasPrefix(asset.Path, ArchiveAssetsDir+"/") {
+			return nil, fmt.Errorf("%w: missing asset %s", ErrInvalidArchive, asset.Path)
+		}
+
+		if entry.UncompressedSize64 > MaxUploadFileSize {
+			return nil, fmt.Errorf("%w: %s: file size exceeds maximum allowed size", ErrInvalidArchive, asset.Path)
+		}
+		if err := s.uploadService.ValidateFileInfo(entry.Name, int64(entry.UncompressedSize64)); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, asset.Path, err)
+		}
+
+		src, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%w: unreadable asset %s", ErrInvalidArchive, asset.Path)
+		}
+
+		// Reject entries whose header understates their real size rather than truncating them
+		publicURL, err := s.uploadService.SaveReader(newEntryReader(src, MaxUploadFileSize, remaining), entry.Name, boardID)
+		src.Close()
+		if err != nil {
+			if errors.Is(err, errEntryTooLarge) {
+				return nil, fmt.Errorf("%w: %s: file size exceeds maximum allowed size", ErrInvalidArchive, asset.Path)
+			}
+			if errors.Is(err, errArchiveTooLarge) {
+				return nil, fmt.Errorf("%w: archive too large", ErrInvalidArchive)
+			}
+			if errors.Is(err, zip.ErrChecksum) || errors.Is(err, zip.ErrFormat) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("%w: corrupt asset %s", ErrInvalidArchive, asset.Path)
+			}
+			return nil, fmt.Errorf("failed to save asset %s: %w", asset.Path, err)
+		}
+
+		urls[asset.Path] = publicURL
+	}
+
+	return urls, nil
+}
+
+// newArchiveBoard copies the content of a board with its pages and elements into an archive DTO
+func newArchiveBoard(board *models.Board) dto.ArchiveBoard {
+	archiveBoard := dto.ArchiveBoard{
+		Title:       board.Title,
+		Description: board.Description,
+		Skin:        board.Skin,
+		Pages:       make([]dto.ArchivePage, 0, len(board.Pages)),
+	}
+
+	for _, page := range board.Pages {
+		archivePage := dto.ArchivePage{
+			Title:    page.Title,
+			Date:     page.Date,
+			OrderIdx: page.OrderIdx,
+			Elements: make([]dto.ArchiveElement, 0, len(page.Elements)),
+		}
+		for _, element := range page.Elements {
+			archivePage.Elements = append(archivePage.Elements, dto.ArchiveElement{
+				Kind:     element.Kind,
+				X:        element.X,
+				Y:        element.Y,
+				W:        element.W,
+				H:        element.H,
+				Rotation: element.Rotation,
+				Z:        element.Z,
+				Visible:  element.Visible,
+				Locked:   element.Locked,
+				Payload:  element.Payload,
+			})
+		}
+		archiveBoard.Pages = append(archiveBoard.Pages, archivePage)
+	}
+
+	return archiveBoard
+}
+
+// archiveBoardModel turns the board of an archive into an unsaved board model for copying
+func archiveBoardModel(archiveBoard *dto.ArchiveBoard) *models.Board {
+	board := &models.Board{
+		Title:       archiveBoard.Title,
+		Description: archiveBoard.Description,
+		Skin:        archiveBoard.Skin,
+		Pages:       make([]models.Page, 0, len(archiveBoard.Pages)),
+	}
+
+	for _, archivePage := range archiveBoard.Pages {
+		page := models.Page{
+			Title:    archivePage.Title,
+			Date:     archivePage.Date,
+			OrderIdx: archivePage.OrderIdx,
+			Elements: make([]models.Element, 0, len(archivePage.Elements)),
+		}
+		for _, archiveElement := range archivePage.Elements {
+			page.Elements = append(page.Elements, models.Element{
+				Kind:     archiveElement.Kind,
+				X:        archiveElement.X,
+				Y:        archiveElement.Y,
+				W:        archiveElement.W,
+				H:        archiveElement.H,
+				Rotation: archiveElement.Rotation,
+				Z:        archiveElement.Z,
+				Visible:  archiveElement.Visible,
+				Locked:   archiveElement.Locked,
+				Payload:  archiveElement.Payload,
+			})
+		}
+		board.Pages = append(board.Pages, page)
+	}
+
+	return board
+}
+
+// entryReader reads an archive entry up to a limit and fails with errEntryTooLarge when the
+// entry holds more data. The bytes read are also taken from the archive's remaining total,
+// shared by the readers of all its entries, which fails with errArchiveTooLarge once used up.
+type entryReader struct {
+	src       io.Reader
+	limit     int64
+	remaining *int64
+}
+
+func newEntryReader(src io.Reader, limit int64, remaining *int64) *entryReader {
+	return &entryReader{src: src, limit: limit, remaining: remaining}
+}
+
+func (r *entryReader) Read(p []byte) (int, error) {
+	allowed := min(r.limit, *r.remaining)
+	if allowed <= 0 {
+		// Any byte past a limit means the entry or the archive is too large
+		var probe [1]byte
+		n, err := r.src.Read(probe[:])
+		if n > 0 {
+			if r.limit <= 0 {
+				return 0, errEntryTooLarge
+			}
+			return 0, errArchiveTooLarge
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > allowed {
+		p = p[:allowed]
+	}
+	n, err := r.src.Read(p)
+	r.limit -= int64(n)
+	*r.remaining -= int64(n)
+	return n, err
+}
+
+// removeBoardUploads deletes all uploaded files of a board
+func (s *ArchiveService) removeBoardUploads(boardID uuid.UUID) {
+	os.RemoveAll(s.uploadService.GetBoardUploadDir(boardID))
+}
+
+// payloadURL returns the "url" field of an element payload, if it has a non-empty one
+func payloadURL(payload datatypes.JSON) (string, bool) {
+	if len(payload) == 0 {
+		return "", false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return "", false
+	}
+
+	rawURL, ok := fields["url"].(string)
+	if !ok || strings.TrimSpace(rawURL) == "" {
+		return "", false
+	}
+	return rawURL, true
+}
+
+// rewritePayloadURL rewrites the "url" field of an element payload when rewrite returns true
+func rewritePayloadURL(payload datatypes.JSON, rewrite func(string) (string, bool)) (datatypes.JSON, error) {
+	if len(payload) == 0 {
+		return payload, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		// Non-object payloads have no URL to rewrite
+		return payload, nil
+	}
+
+	rawURL, ok := fields["url"].(string)
+	if !ok || strings.TrimSpace(rawURL) == "" {
+		return payload, nil
+	}
+
+	rewritten, ok := rewrite(rawURL)
+	if !ok {
+		return payload, nil
+	}
+
+	fields["url"] = rewritten
+	updated, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return datatypes.JSON(updated), nil
+}
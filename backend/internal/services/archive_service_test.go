@@ -0,0 +1,284 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func TestRewritePayloadURL(t *testing.T) {
+	rewrite := func(rawURL string) (string, bool) {
+		if rawURL == "http://localhost:8080/uploads/boards/abc/photo.png" {
+			return "assets/photo.png", true
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name     string
+		payload  string
+		expected string
+	}{
+		{
+			name:     "Local upload is rewritten",
+			payload:  `{"url":"http://localhost:8080/uploads/boards/abc/photo.png","description":"trip"}`,
+			expected: "assets/photo.png",
+		},
+		{
+			name:     "Remote sticker is kept",
+			payload:  `{"url":"https://cdn.example.com/sticker.png","category":"emoji"}`,
+			expected: "https://cdn.example.com/sticker.png",
+		},
+		{
+			name:     "Payload without url is untouched",
+			payload:  `{"content":"hello"}`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rewritePayloadURL(datatypes.JSON(tt.payload), rewrite)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var fields map[string]interface{}
+			if err := json.Unmarshal(result, &fields); err != nil {
+				t.Fatalf("Expected valid JSON, got %v", err)
+			}
+
+			url, _ := fields["url"].(string)
+			if url != tt.expected {
+				t.Errorf("Expected url %q, got %q", tt.expected, url)
+			}
+		})
+	}
+}
+
+func TestReadArchiveManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		valid    bool
+	}{
+		{
+			name:     "Valid manifest",
+			manifest: `{"version":1,"board":{"title":"Trip","skin":"cork","pages":[{"title":"Day 1","elements":[{"kind":"text"}]}]}}`,
+			valid:    true,
+		},
+		{
+			name:     "Unsupported version",
+			manifest: `{"version":99,"board":{"title":"Trip"}}`,
+			valid:    false,
+		},
+		{
+			name:     "Missing title",
+			manifest: `{"version":1,"board":{"title":" "}}`,
+			valid:    false,
+		},
+		{
+			name:     "Unknown element kind",
+			manifest: `{"version":1,"board":{"title":"Trip","pages":[{"elements":[{"kind":"video"}]}]}}`,
+			valid:    false,
+		},
+		{
+			name:     "Malformed JSON",
+			manifest: `{"version":`,
+			valid:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			w, _ := zw.Create(ArchiveManifestName)
+			w.Write([]byte(tt.manifest))
+			zw.Close()
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("Failed to read test archive: %v", err)
+			}
+
+			remaining := int64(MaxArchiveTotalSize)
+			manifest, err := readArchiveManifest(zr.File[0], &remaining)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("Expected valid manifest, got %v", err)
+				}
+				if manifest.Board.Skin != "cork" {
+					t.Errorf("Expected skin to be preserved, got %q", manifest.Board.Skin)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidArchive) {
+				t.Errorf("Expected ErrInvalidArchive, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReadArchiveManifestMissing(t *testing.T) {
+	remaining := int64(MaxArchiveTotalSize)
+	if _, err := readArchiveManifest(nil, &remaining); !errors.Is(err, ErrInvalidArchive) {
+		t.Errorf("Expected ErrInvalidArchive, got %v", err)
+	}
+}
+
+func TestEntryReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		contents  []string
+		limit     int64
+		total     int64
+		expected  error // error of the last entry
+		remaining int64
+	}{
+		{name: "Smaller than the limits", contents: []string{"abc"}, limit: 5, total: 10, remaining: 7},
+		{name: "Exactly the entry limit", contents: []string{"abcde"}, limit: 5, total: 10, remaining: 5},
+		{name: "Larger than the entry limit", contents: []string{"abcdef"}, limit: 5, total: 10, expected: errEntryTooLarge},
+		{name: "Entries within the total", contents: []string{"abcd", "efgh"}, limit: 5, total: 8, remaining: 0},
+		{name: "Entries over the total", contents: []string{"abcd", "efgh", "i"}, limit: 5, total: 8, expected: errArchiveTooLarge},
+		{name: "Entry over the remaining total", contents: []string{"abcd", "efgh"}, limit: 5, total: 6, expected: errArchiveTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining := tt.total
+			var err error
+			for _, content := range tt.contents {
+				var data []byte
+				data, err = io.ReadAll(newEntryReader(strings.NewReader(content), tt.limit, &remaining))
+				if err != nil {
+					break
+				}
+				if string(data) != content {
+					t.Errorf("Expected %q, got %q", content, string(data))
+				}
+			}
+
+			if tt.expected != nil {
+				if !errors.Is(err, tt.expected) {
+					t.Errorf("Expected %v, got %v", tt.expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if remaining != tt.remaining {
+				t.Errorf("Expected %d bytes left, got %d", tt.remaining, remaining)
+			}
+		})
+	}
+}
+
+func TestImportBoardRejectsTooManyEntries(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i <= MaxArchiveEntries; i++ {
+		zw.Create(fmt.Sprintf("assets/%d.png", i))
+	}
+	zw.Close()
+
+	service := &ArchiveService{}
+	if _, err := service.ImportBoard(bytes.NewReader(buf.Bytes()), int64(buf.Len())); !errors.Is(err, ErrInvalidArchive) {
+		t.Errorf("Expected ErrInvalidArchive, got %v", err)
+	}
+}
+
+func TestImportBoardRejectsOversizedArchive(t *testing.T) {
+	// Uploads live relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change working directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	// Each asset is within the per-file limit, together they are over the archive total
+	count := MaxArchiveTotalSize/MaxUploadFileSize + 1
+	manifest := dto.ArchiveManifest{Version: ArchiveFormatVersion, Board: dto.ArchiveBoard{Title: "Trip"}}
+	for i := 0; i < count; i++ {
+		manifest.Assets = append(manifest.Assets, dto.ArchiveAsset{Path: fmt.Sprintf("%s/%d.png", ArchiveAssetsDir, i)})
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create(ArchiveManifestName)
+	json.NewEncoder(w).Encode(manifest)
+	for _, asset := range manifest.Assets {
+		// Only the headers are written; the sizes they declare are checked before anything is read
+		zw.CreateRaw(&zip.FileHeader{Name: asset.Path, Method: zip.Store, UncompressedSize64: MaxUploadFileSize})
+	}
+	zw.Close()
+
+	service := &ArchiveService{uploadService: NewUploadService()}
+	_, err = service.ImportBoard(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if !errors.Is(err, ErrInvalidArchive) || !strings.Contains(err.Error(), "archive too large") {
+		t.Fatalf("Expected the archive to be rejected as too large, got %v", err)
+	}
+
+	if entries, err := os.ReadDir(filepath.Join("uploads", "boards")); err == nil && len(entries) > 0 {
+		t.Errorf("Expected no uploads to be written, got %d board directories", len(entries))
+	}
+}
+
+func TestNewArchiveBoard(t *testing.T) {
+	pinnedID := uuid.New()
+	board := &models.Board{
+		ID:               uuid.New(),
+		Title:            "Trip",
+		Skin:             "cork",
+		EditTokenHash:    "hash",
+		PublicToken:      uuid.New(),
+		PinnedSnapshotID: &pinnedID,
+		Pages: []models.Page{
+			{ID: uuid.New(), Title: "Day 1", OrderIdx: 0, Elements: []models.Element{
+				{ID: uuid.New(), Kind: "text", Z: 1, Visible: true, Payload: datatypes.JSON(`{"content":"hello"}`)},
+			}},
+		},
+	}
+
+	archiveBoard := newArchiveBoard(board)
+	manifest, err := json.Marshal(dto.ArchiveManifest{Version: ArchiveFormatVersion, Board: archiveBoard})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, secret := range []string{board.PublicToken.String(), pinnedID.String(), board.ID.String(), "public_token", "edit_token"} {
+		if bytes.Contains(manifest, []byte(secret)) {
+			t.Errorf("Expected manifest not to contain %q", secret)
+		}
+	}
+
+	restored := archiveBoardModel(&archiveBoard)
+	if restored.Title != "Trip" || restored.Skin != "cork" || restored.PublicToken != uuid.Nil {
+		t.Errorf("Expected only board content to be restored, got %+v", restored)
+	}
+	if len(restored.Pages) != 1 || len(restored.Pages[0].Elements) != 1 {
+		t.Fatalf("Expected 1 page with 1 element, got %+v", restored.Pages)
+	}
+	element := restored.Pages[0].Elements[0]
+	if element.ID != uuid.Nil || element.Kind != "text" || element.Z != 1 || !element.Visible {
+		t.Errorf("Expected element content without its ID, got %+v", element)
+	}
+}
@@ -2,12 +2,16 @@ package services
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	"junk-journal-board/internal/models"
 	"junk-journal-board/internal/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BoardService struct {
@@ -33,12 +37,14 @@ func (s *BoardService) CreateBoard(title, description, skin string) (*models.Boa
 	return board, nil
 }
 
-// GetBoardByEditToken retrieves a board by its edit token with pages
+// GetBoardByEditToken retrieves a board by its edit token with pages. A retired edit token
+// inside its grace period still opens the board but is returned in place of the new one.
 func (s *BoardService) GetBoardByEditToken(editToken uuid.UUID) (*models.Board, error) {
+	editTokenHash := utils.HashToken(editToken)
 	var board models.Board
 	err := s.db.Preload("Pages", func(db *gorm.DB) *gorm.DB {
 		return db.Order("order_idx ASC")
-	}).Where("edit_token = ?", editToken).First(&board).Error
+	}).Where(editTokenCondition, editTokenHash, editTokenHash, time.Now()).First(&board).Error
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -47,15 +53,17 @@ func (s *BoardService) GetBoardByEditToken(editToken uuid.UUID) (*models.Board,
 		return nil, fmt.Errorf("failed to get board by edit token: %w", err)
 	}
 
+	board.EditToken = editToken
 	return &board, nil
 }
 
-// GetBoardByPublicToken retrieves a board by its public token with pages (read-only)
+// GetBoardByPublicToken retrieves a board by its public token with pages (read-only). A retired
+// public token inside its grace period still opens the board but is returned in place of the new one.
 func (s *BoardService) GetBoardByPublicToken(publicToken uuid.UUID) (*models.Board, error) {
 	var board models.Board
 	err := s.db.Preload("Pages", func(db *gorm.DB) *gorm.DB {
 		return db.Order("order_idx ASC")
-	}).Where("public_token = ?", publicToken).First(&board).Error
+	}).Where(publicTokenCondition, publicToken, utils.HashToken(publicToken), time.Now()).First(&board).Error
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -64,6 +72,12 @@ func (s *BoardService) GetBoardByPublicToken(publicToken uuid.UUID) (*models.Boa
 		return nil, fmt.Errorf("failed to get board by public token: %w", err)
 	}
 
+	// Views are counted by the caller, once the reader may actually see the board
+	if !publicLinkOpen(&board, time.Now()) {
+		return nil, utils.ErrLinkExpired
+	}
+
+	board.PublicToken = publicToken
 	return &board, nil
 }
 
@@ -121,54 +135,183 @@ func (s *BoardService) UpdateBoard(boardID uuid.UUID, title, description, skin *
 	return &board, nil
 }
 
-// ValidateBoardEditAccess validates that the edit token is valid for the board
-func (s *BoardService) ValidateBoardEditAccess(boardID, editToken uuid.UUID) error {
+// ValidateBoardEditToken validates that the token is the board's own edit token, or its
+// retired edit token inside the grace period. Unlike ValidateBoardEditAccess it does not accept share links.
+func (s *BoardService) ValidateBoardEditToken(boardID, editToken uuid.UUID) error {
+	editTokenHash := utils.HashToken(editToken)
 	var count int64
 	err := s.db.Model(&models.Board{}).
-		Where("id = ? AND edit_token = ?", boardID, editToken).
+		Where("id = ? AND "+editTokenCondition, boardID, editTokenHash, editTokenHash, time.Now()).
 		Count(&count).Error
 	if err != nil {
 		return fmt.Errorf("failed to validate board edit access: %w", err)
 	}
 
 	if count == 0 {
-		// Check if board exists to differentiate between not found and unauthorized
-		var boardCount int64
-		if err := s.db.Model(&models.Board{}).Where("id = ?", boardID).Count(&boardCount).Error; err != nil {
-			return fmt.Errorf("failed to check board existence: %w", err)
-		}
-		if boardCount == 0 {
-			return utils.ErrNotFound
-		}
-		return utils.ErrUnauthorized
+		return s.unauthorizedOrNotFound(boardID)
 	}
 
 	return nil
 }
 
-// ValidateBoardAccess validates that the token (edit or public) is valid for the board
+// IsEditToken reports whether the token is the edit token of a board, or a retired one inside
+// its grace period
+func (s *BoardService) IsEditToken(token uuid.UUID) (bool, error) {
+	tokenHash := utils.HashToken(token)
+	var count int64
+	err := s.db.Model(&models.Board{}).
+		Where(editTokenCondition, tokenHash, tokenHash, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check edit token: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ValidateBoardEditAccess validates that the token may edit the whole board: the board's
+// edit token or an unexpired editor share link without a page scope
+func (s *BoardService) ValidateBoardEditAccess(boardID, editToken uuid.UUID) error {
+	return s.validateEditAccess(boardID, nil, editToken)
+}
+
+// ValidatePageEditAccess validates that the token may edit a page of the board. On top of
+// ValidateBoardEditAccess it accepts editor share links scoped to that page.
+func (s *BoardService) ValidatePageEditAccess(boardID, pageID, editToken uuid.UUID) error {
+	return s.validateEditAccess(boardID, &pageID, editToken)
+}
+
+// validateEditAccess validates that the token may edit the whole board, when pageID is nil,
+// or the given page. The token is looked up as a share link at most once.
+func (s *BoardService) validateEditAccess(boardID uuid.UUID, pageID *uuid.UUID, editToken uuid.UUID) error {
+	err := s.ValidateBoardEditToken(boardID, editToken)
+	if err != utils.ErrUnauthorized {
+		return err
+	}
+
+	link, err := s.activeShareLink(boardID, editToken)
+	if err != nil {
+		return err
+	}
+	if shareLinkAllows(link, pageID, true) {
+		return nil
+	}
+
+	return utils.ErrUnauthorized
+}
+
+// ValidateBoardAccess validates that the token (edit, public or an unexpired share link
+// without a page scope) is valid for the board. A public token whose link has expired gives
+// utils.ErrLinkExpired; view limits are checked per reader against their view grant.
 func (s *BoardService) ValidateBoardAccess(boardID, token uuid.UUID) error {
+	return s.validateReadAccess(boardID, nil, token)
+}
+
+// ValidatePageAccess validates that the token may read a page of the board. On top of
+// ValidateBoardAccess it accepts share links scoped to that page.
+func (s *BoardService) ValidatePageAccess(boardID, pageID, token uuid.UUID) error {
+	return s.validateReadAccess(boardID, &pageID, token)
+}
+
+// validateReadAccess validates that the token may read the whole board, when pageID is nil,
+// or the given page. The token is looked up as a share link at most once.
+func (s *BoardService) validateReadAccess(boardID uuid.UUID, pageID *uuid.UUID, token uuid.UUID) error {
+	now := time.Now()
+	tokenHash := utils.HashToken(token)
 	var count int64
 	err := s.db.Model(&models.Board{}).
-		Where("id = ? AND (edit_token = ? OR public_token = ?)", boardID, token, token).
+		Where("id = ? AND ("+editTokenCondition+" OR ("+publicTokenCondition+" AND "+publicLinkActiveCondition+"))",
+			boardID, tokenHash, tokenHash, now, token, tokenHash, now, now).
 		Count(&count).Error
 	if err != nil {
 		return fmt.Errorf("failed to validate board access: %w", err)
 	}
 
-	if count == 0 {
-		// Check if board exists to differentiate between not found and unauthorized
-		var boardCount int64
-		if err := s.db.Model(&models.Board{}).Where("id = ?", boardID).Count(&boardCount).Error; err != nil {
-			return fmt.Errorf("failed to check board existence: %w", err)
+	if count > 0 {
+		return nil
+	}
+
+	link, err := s.activeShareLink(boardID, token)
+	if err != nil {
+		return err
+	}
+	if shareLinkAllows(link, pageID, false) {
+		return nil
+	}
+
+	// Tell a public link past its limits apart from a wrong token
+	err = s.db.Model(&models.Board{}).
+		Where("id = ? AND "+publicTokenCondition, boardID, token, tokenHash, now).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to validate board access: %w", err)
+	}
+	if count > 0 {
+		return utils.ErrLinkExpired
+	}
+
+	return s.unauthorizedOrNotFound(boardID)
+}
+
+// HasActiveShareLink reports whether the token is an unexpired share link of the board
+func (s *BoardService) HasActiveShareLink(boardID, token uuid.UUID) (bool, error) {
+	link, err := s.activeShareLink(boardID, token)
+	return link != nil, err
+}
+
+// GetPublicAccess returns a board with the passphrase and limits of its public access
+func (s *BoardService) GetPublicAccess(boardID uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := s.db.Select("id", "public_passphrase_hash", "public_expires_at", "public_max_views", "public_view_count").
+		First(&board, "id = ?", boardID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
 		}
-		if boardCount == 0 {
-			return utils.ErrNotFound
+		return nil, fmt.Errorf("failed to get public access: %w", err)
+	}
+
+	return &board, nil
+}
+
+// shareLinkAllows reports whether a share link grants access to the whole board, when pageID is
+// nil, or to the given page. Page-scoped links never reach the rest of the board, and editing
+// additionally requires the editor role.
+func shareLinkAllows(link *models.ShareLink, pageID *uuid.UUID, edit bool) bool {
+	if link == nil || (edit && link.Role != models.ShareRoleEditor) {
+		return false
+	}
+	if link.PageID == nil {
+		return true
+	}
+	return pageID != nil && *link.PageID == *pageID
+}
+
+// activeShareLink returns the unexpired share link of a board with the given token, or nil
+func (s *BoardService) activeShareLink(boardID, token uuid.UUID) (*models.ShareLink, error) {
+	var link models.ShareLink
+	err := s.db.Where("board_id = ? AND token_hash = ? AND (expires_at IS NULL OR expires_at > ?)", boardID, utils.HashToken(token), time.Now()).
+		First(&link).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
 		}
-		return utils.ErrUnauthorized
+		return nil, fmt.Errorf("failed to get share link: %w", err)
 	}
 
-	return nil
+	return &link, nil
+}
+
+// unauthorizedOrNotFound differentiates between a missing board and a rejected token
+func (s *BoardService) unauthorizedOrNotFound(boardID uuid.UUID) error {
+	var boardCount int64
+	if err := s.db.Model(&models.Board{}).Where("id = ?", boardID).Count(&boardCount).Error; err != nil {
+		return fmt.Errorf("failed to check board existence: %w", err)
+	}
+	if boardCount == 0 {
+		return utils.ErrNotFound
+	}
+	return utils.ErrUnauthorized
 }
 
 // ValidateBoardExists checks if a board exists (for public access without token)
@@ -188,49 +331,391 @@ func (s *BoardService) ValidateBoardExists(boardID uuid.UUID) error {
 	return nil
 }
 
-// GetAllBoards retrieves all boards with page count
-func (s *BoardService) GetAllBoards() ([]models.Board, error) {
+// boardSortKeys are the columns boards can be listed by
+var boardSortKeys = map[string]sortKey{
+	"created_at": {column: "created_at", kind: sortTime},
+	"updated_at": {column: "updated_at", kind: sortTime},
+	"title":      {column: "title", kind: sortString},
+}
+
+// BoardSummary is a board in a listing together with its number of pages
+type BoardSummary struct {
+	models.Board
+	PageCount int
+}
+
+// ListBoards returns a page of boards with their page counts and the cursor of the next page.
+// A non-nil boardIDs restricts the listing to those boards.
+func (s *BoardService) ListBoards(boardIDs []uuid.UUID, filter ListFilter, params ListParams) ([]BoardSummary, string, error) {
+	key := resolveSortKey(boardSortKeys, params.Sort, "created_at")
+
+	query := s.db.Model(&models.Board{})
+	if boardIDs != nil {
+		query = query.Where("boards.id IN ?", boardIDs)
+	}
+	query = filter.apply(query, "boards.title", "boards.created_at")
+	query, err := applyKeyset(query, "boards", key, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var boards []models.Board
+	if err := query.Find(&boards).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to list boards: %w", err)
+	}
+
+	boards, next := trimPage(boards, params, func(board models.Board) string {
+		return encodeCursor(boardSortValue(&board, key.column), board.ID)
+	})
+
+	counts, err := s.countPages(boards)
+	if err != nil {
+		return nil, "", err
+	}
+
+	summaries := make([]BoardSummary, len(boards))
+	for i, board := range boards {
+		summaries[i] = BoardSummary{Board: board, PageCount: counts[board.ID]}
+	}
+
+	return summaries, next, nil
+}
+
+// boardRoleRanks orders member roles by privilege
+var boardRoleRanks = map[string]int{
+	models.BoardRoleViewer: 1,
+	models.BoardRoleEditor: 2,
+	models.BoardRoleOwner:  3,
+}
+
+// ValidateMemberAccess validates that a user holds at least minRole on a board
+func (s *BoardService) ValidateMemberAccess(boardID, userID uuid.UUID, minRole string) error {
+	var member models.BoardMember
+	err := s.db.Joins("JOIN boards ON boards.id = board_members.board_id AND boards.deleted_at IS NULL").
+		Where("board_members.board_id = ? AND board_members.user_id = ?", boardID, userID).
+		First(&member).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrUnauthorized
+		}
+		return fmt.Errorf("failed to validate board membership: %w", err)
+	}
+
+	return checkMemberRole(member.Role, minRole)
+}
+
+// checkMemberRole gives utils.ErrForbidden unless role is at least minRole; unknown roles hold
+// no privileges
+func checkMemberRole(role, minRole string) error {
+	if boardRoleRanks[role] == 0 || boardRoleRanks[role] < boardRoleRanks[minRole] {
+		return utils.ErrForbidden
+	}
+	return nil
+}
+
+// BoardIDsByEditTokens maps the IDs of the boards the given edit tokens belong to onto those
+// tokens. Unknown tokens are ignored.
+func (s *BoardService) BoardIDsByEditTokens(editTokens []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	tokens := make(map[uuid.UUID]uuid.UUID, len(editTokens))
+	if len(editTokens) == 0 {
+		return tokens, nil
+	}
+
+	tokensByHash := make(map[string]uuid.UUID, len(editTokens))
+	for _, editToken := range editTokens {
+		tokensByHash[utils.HashToken(editToken)] = editToken
+	}
+	hashes := make([]string, 0, len(tokensByHash))
+	for hash := range tokensByHash {
+		hashes = append(hashes, hash)
+	}
+
 	var boards []models.Board
-	err := s.db.Preload("Pages").Find(&boards).Error
+	err := s.db.Select("id", "edit_token_hash").
+		Where("edit_token_hash IN ?", hashes).
+		Find(&boards).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve edit tokens: %w", err)
+	}
+
+	for _, board := range boards {
+		tokens[board.ID] = tokensByHash[board.EditTokenHash]
+	}
+
+	return tokens, nil
+}
+
+// countPages counts the pages of each board in one query
+func (s *BoardService) countPages(boards []models.Board) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(boards))
+	if len(boards) == 0 {
+		return counts, nil
+	}
+
+	boardIDs := make([]uuid.UUID, len(boards))
+	for i, board := range boards {
+		boardIDs[i] = board.ID
+	}
+
+	var rows []struct {
+		BoardID uuid.UUID
+		Count   int
+	}
+	err := s.db.Model(&models.Page{}).
+		Select("board_id, COUNT(*) AS count").
+		Where("board_id IN ?", boardIDs).
+		Group("board_id").
+		Scan(&rows).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all boards: %w", err)
+		return nil, fmt.Errorf("failed to count pages: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.BoardID] = row.Count
 	}
 
-	return boards, nil
+	return counts, nil
+}
+
+// boardSortValue returns the value of a board's sort column
+func boardSortValue(board *models.Board, column string) interface{} {
+	switch column {
+	case "updated_at":
+		return board.UpdatedAt
+	case "title":
+		return board.Title
+	default:
+		return board.CreatedAt
+	}
 }
 
-// DeleteBoard deletes a board and all its associated data
+// DeleteBoard moves a board to the trash. Its pages and elements stay untouched and
+// come back with it when the board is restored; the purge job removes them for good.
 func (s *BoardService) DeleteBoard(boardID uuid.UUID) error {
-	// Start a transaction to ensure all related data is deleted
-	tx := s.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	result := s.db.Delete(&models.Board{}, "id = ?", boardID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete board: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// CloneBoard deep-copies a board with its pages and elements under fresh IDs and tokens.
+// With copyFiles the uploaded images are duplicated into the clone's own upload directory;
+// otherwise the clone keeps pointing at the original board's files.
+func (s *BoardService) CloneBoard(boardID uuid.UUID, title *string, copyFiles bool) (*models.Board, error) {
+	source, err := loadBoardContent(s.db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if title != nil {
+		source.Title = *title
+	} else {
+		source.Title = fmt.Sprintf("%s (copy)", source.Title)
+	}
+
+	return createBoardCopy(s.db, source, uuid.New(), copyFiles)
+}
+
+// createBoardCopy creates a board with the given ID from a source board, as copyBoardContent
+// does, and returns it with its pages. With copyFiles the uploads in the source board's own
+// directory are duplicated into the new board's directory; otherwise the copy shares them.
+func createBoardCopy(db *gorm.DB, source *models.Board, boardID uuid.UUID, copyFiles bool) (*models.Board, error) {
+	uploadService := NewUploadService()
+
+	var rewriteURL func(string) (string, bool)
+	if copyFiles {
+		fileURLs, err := copyBoardUploads(uploadService, source, boardID)
+		if err != nil {
+			os.RemoveAll(uploadService.GetBoardUploadDir(boardID))
+			return nil, err
+		}
+		rewriteURL = func(rawURL string) (string, bool) {
+			newURL, ok := fileURLs[rawURL]
+			return newURL, ok
 		}
-	}()
+	}
 
-	// First, delete all elements in all pages of this board
-	if err := tx.Exec(`
-		DELETE FROM elements 
-		WHERE page_id IN (
-			SELECT id FROM pages WHERE board_id = ?
-		)
-	`, boardID).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to delete board elements: %w", err)
+	var board *models.Board
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		board, err = copyBoardContent(tx, source, boardID, rewriteURL)
+		return err
+	})
+	if err != nil {
+		os.RemoveAll(uploadService.GetBoardUploadDir(boardID))
+		return nil, err
 	}
 
-	// Then delete all pages of this board
-	if err := tx.Where("board_id = ?", boardID).Delete(&models.Page{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to delete board pages: %w", err)
+	// Reload the board to get pages for the response
+	if err := db.Preload("Pages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_idx ASC")
+	}).Where("id = ?", boardID).First(board).Error; err != nil {
+		return nil, fmt.Errorf("failed to reload board: %w", err)
+	}
+
+	return board, nil
+}
+
+// copyBoardUploads duplicates the files a board's elements reference from its own upload
+// directory into another board's directory and maps old URLs to new ones
+func copyBoardUploads(uploadService *UploadService, source *models.Board, targetBoardID uuid.UUID) (map[string]string, error) {
+	fileURLs := make(map[string]string)
+
+	for rawURL, localPath := range boardUploadURLs(uploadService, source) {
+		src, err := os.Open(localPath)
+		if err != nil {
+			// Keep the original URL when the file is gone
+			continue
+		}
+
+		newURL, err := uploadService.SaveReader(src, localPath, targetBoardID)
+		src.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy upload %s: %w", localPath, err)
+		}
+		fileURLs[rawURL] = newURL
+	}
+
+	return fileURLs, nil
+}
+
+// boardUploadURLs collects the payload URLs of a board's elements that point into the board's
+// own upload directory, mapped to their local paths
+func boardUploadURLs(uploadService *UploadService, board *models.Board) map[string]string {
+	boardDir := filepath.Clean(uploadService.GetBoardUploadDir(board.ID))
+	urls := make(map[string]string)
+
+	for _, page := range board.Pages {
+		for _, element := range page.Elements {
+			rawURL, ok := payloadURL(element.Payload)
+			if !ok {
+				continue
+			}
+			localPath, ok := uploadService.ResolveLocalPath(rawURL)
+			if !ok || filepath.Dir(localPath) != boardDir {
+				continue
+			}
+			urls[rawURL] = localPath
+		}
+	}
+
+	return urls
+}
+
+// recordUploadReferences records the upload directories of other boards that new elements of a
+// board point at, so purging those boards keeps the files
+func recordUploadReferences(tx *gorm.DB, boardID uuid.UUID, elements []models.Element) error {
+	uploadService := NewUploadService()
+	seen := make(map[uuid.UUID]bool)
+	var references []models.BoardUploadReference
+
+	for _, element := range elements {
+		rawURL, ok := payloadURL(element.Payload)
+		if !ok {
+			continue
+		}
+		uploadBoardID, ok := uploadService.ResolveBoardID(rawURL)
+		if !ok || uploadBoardID == boardID || seen[uploadBoardID] {
+			continue
+		}
+		seen[uploadBoardID] = true
+		references = append(references, models.BoardUploadReference{BoardID: boardID, UploadBoardID: uploadBoardID})
 	}
 
-	// Finally delete the board itself
-	if err := tx.Where("id = ?", boardID).Delete(&models.Board{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to delete board: %w", err)
+	if len(references) == 0 {
+		return nil
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&references).Error; err != nil {
+		return fmt.Errorf("failed to record upload references: %w", err)
 	}
 
-	return tx.Commit().Error
+	return nil
+}
+
+// copyBoardContent creates a board with the given ID from a source board, including its pages
+// and elements, as built by newBoardCopy. Uploads the copy shares with other boards are recorded.
+func copyBoardContent(tx *gorm.DB, source *models.Board, boardID uuid.UUID, rewriteURL func(string) (string, bool)) (*models.Board, error) {
+	board, err := newBoardCopy(source, boardID, rewriteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Omit("Pages").Create(board).Error; err != nil {
+		return nil, fmt.Errorf("failed to create board: %w", err)
+	}
+	for i := range board.Pages {
+		page := &board.Pages[i]
+		if err := tx.Omit("Elements").Create(page).Error; err != nil {
+			return nil, fmt.Errorf("failed to create page: %w", err)
+		}
+		for j := range page.Elements {
+			// Select all columns so false/zero values are not replaced by column defaults
+			if err := tx.Select("*").Create(&page.Elements[j]).Error; err != nil {
+				return nil, fmt.Errorf("failed to create element: %w", err)
+			}
+		}
+		if err := recordUploadReferences(tx, board.ID, page.Elements); err != nil {
+			return nil, err
+		}
+	}
+
+	return board, nil
+}
+
+// newBoardCopy builds an unsaved copy of a source board with its pages and elements.
+// Everything gets fresh IDs, and tokens are generated on create; page order, z-index,
+// visibility and lock state are kept. When rewriteURL is set it is applied to element payload URLs.
+func newBoardCopy(source *models.Board, boardID uuid.UUID, rewriteURL func(string) (string, bool)) (*models.Board, error) {
+	board := &models.Board{
+		ID:          boardID,
+		Title:       source.Title,
+		Description: source.Description,
+		Skin:        source.Skin,
+		Pages:       make([]models.Page, 0, len(source.Pages)),
+	}
+
+	for _, sourcePage := range source.Pages {
+		page := models.Page{
+			ID:       uuid.New(),
+			BoardID:  board.ID,
+			Title:    sourcePage.Title,
+			Date:     sourcePage.Date,
+			OrderIdx: sourcePage.OrderIdx,
+			Elements: make([]models.Element, 0, len(sourcePage.Elements)),
+		}
+
+		for _, sourceElement := range sourcePage.Elements {
+			payload := sourceElement.Payload
+			if rewriteURL != nil {
+				var err error
+				if payload, err = rewritePayloadURL(payload, rewriteURL); err != nil {
+					return nil, fmt.Errorf("failed to rewrite payload for element %s: %w", sourceElement.ID, err)
+				}
+			}
+
+			page.Elements = append(page.Elements, models.Element{
+				ID:       uuid.New(),
+				PageID:   page.ID,
+				Kind:     sourceElement.Kind,
+				X:        sourceElement.X,
+				Y:        sourceElement.Y,
+				W:        sourceElement.W,
+				H:        sourceElement.H,
+				Rotation: sourceElement.Rotation,
+				Z:        sourceElement.Z,
+				Visible:  sourceElement.Visible,
+				Locked:   sourceElement.Locked,
+				Payload:  payload,
+			})
+		}
+		board.Pages = append(board.Pages, page)
+	}
+
+	return board, nil
 }
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func TestBoardUploadURLs(t *testing.T) {
+	uploadService := NewUploadService()
+	boardID := uuid.New()
+	ownURL := uploadService.GetPublicURL(boardID, "photo.png")
+	otherURL := uploadService.GetPublicURL(uuid.New(), "photo.png")
+
+	board := &models.Board{
+		ID: boardID,
+		Pages: []models.Page{
+			{Elements: []models.Element{
+				{Kind: "image", Payload: datatypes.JSON(`{"url":"` + ownURL + `"}`)},
+				{Kind: "image", Payload: datatypes.JSON(`{"url":"` + otherURL + `"}`)},
+			}},
+			{Elements: []models.Element{
+				{Kind: "sticker", Payload: datatypes.JSON(`{"url":"https://cdn.example.com/sticker.png"}`)},
+				{Kind: "text", Payload: datatypes.JSON(`{"content":"hello"}`)},
+				{Kind: "image", Payload: datatypes.JSON(`{"url":"` + ownURL + `"}`)},
+			}},
+		},
+	}
+
+	urls := boardUploadURLs(uploadService, board)
+
+	if len(urls) != 1 {
+		t.Fatalf("Expected 1 upload of the board, got %v", urls)
+	}
+	expectedPath := filepath.Join("uploads", "boards", boardID.String(), "photo.png")
+	if urls[ownURL] != expectedPath {
+		t.Errorf("Expected %s, got %s", expectedPath, urls[ownURL])
+	}
+}
+
+func TestCopyBoardUploads(t *testing.T) {
+	// Uploads live relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change working directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	uploadService := NewUploadService()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+
+	sourceDir := uploadService.GetBoardUploadDir(sourceID)
+	if err := os.MkdirAll(sourceDir, 0755); err != nil {
+		t.Fatalf("Failed to create upload dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sourceDir, "photo.png"), []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write upload: %v", err)
+	}
+
+	presentURL := uploadService.GetPublicURL(sourceID, "photo.png")
+	missingURL := uploadService.GetPublicURL(sourceID, "gone.png")
+	source := &models.Board{
+		ID: sourceID,
+		Pages: []models.Page{{Elements: []models.Element{
+			{Kind: "image", Payload: datatypes.JSON(`{"url":"` + presentURL + `"}`)},
+			{Kind: "image", Payload: datatypes.JSON(`{"url":"` + missingURL + `"}`)},
+		}}},
+	}
+
+	fileURLs, err := copyBoardUploads(uploadService, source, targetID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, ok := fileURLs[missingURL]; ok {
+		t.Error("Expected missing files to keep their original URL")
+	}
+	newURL, ok := fileURLs[presentURL]
+	if !ok {
+		t.Fatalf("Expected the present file to be copied, got %v", fileURLs)
+	}
+
+	localPath, ok := uploadService.ResolveLocalPath(newURL)
+	if !ok || filepath.Dir(localPath) != uploadService.GetBoardUploadDir(targetID) {
+		t.Fatalf("Expected the copy in the target board's directory, got %s", newURL)
+	}
+	data, err := os.ReadFile(localPath)
+	if err != nil || string(data) != "png" {
+		t.Errorf("Expected the copied file contents, got %q (%v)", data, err)
+	}
+}
+
+func TestNewBoardCopy(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	oldURL := "http://localhost:8080/uploads/boards/abc/photo.png"
+	newURL := "http://localhost:8080/uploads/boards/def/copy.png"
+
+	source := &models.Board{
+		ID:    uuid.New(),
+		Title: "Trip",
+		Skin:  "cork",
+		Pages: []models.Page{
+			{ID: uuid.New(), Title: "Day 1", Date: day, OrderIdx: 0, Elements: []models.Element{
+				{ID: uuid.New(), Kind: "text", Z: 1, Visible: true, Payload: datatypes.JSON(`{"content":"first"}`)},
+				{ID: uuid.New(), Kind: "image", Z: 2, Visible: false, Locked: true, Payload: datatypes.JSON(`{"url":"` + oldURL + `"}`)},
+			}},
+			{ID: uuid.New(), Title: "Day 2", Date: day.AddDate(0, 0, 1), OrderIdx: 1, Elements: []models.Element{
+				{ID: uuid.New(), Kind: "sticker", Z: 5, Visible: true, Payload: datatypes.JSON(`{"url":"https://cdn.example.com/sticker.png"}`)},
+			}},
+		},
+	}
+
+	boardID := uuid.New()
+	board, err := newBoardCopy(source, boardID, func(rawURL string) (string, bool) {
+		if rawURL == oldURL {
+			return newURL, true
+		}
+		return "", false
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if board.ID != boardID || board.Title != "Trip" || board.Skin != "cork" {
+		t.Errorf("Expected the board content under the new ID, got %+v", board)
+	}
+	if len(board.Pages) != len(source.Pages) {
+		t.Fatalf("Expected %d pages, got %d", len(source.Pages), len(board.Pages))
+	}
+
+	for i, page := range board.Pages {
+		sourcePage := source.Pages[i]
+		if page.ID == sourcePage.ID || page.BoardID != boardID {
+			t.Errorf("Expected page %d to get a fresh ID on the new board", i)
+		}
+		if page.Title != sourcePage.Title || page.OrderIdx != sourcePage.OrderIdx || !page.Date.Equal(sourcePage.Date) {
+			t.Errorf("Expected page %d to keep its title, date and order, got %+v", i, page)
+		}
+		if len(page.Elements) != len(sourcePage.Elements) {
+			t.Fatalf("Expected %d elements on page %d, got %d", len(sourcePage.Elements), i, len(page.Elements))
+		}
+
+		for j, element := range page.Elements {
+			sourceElement := sourcePage.Elements[j]
+			if element.ID == sourceElement.ID || element.PageID != page.ID {
+				t.Errorf("Expected element %d of page %d to get a fresh ID on the new page", j, i)
+			}
+			if element.Kind != sourceElement.Kind || element.Z != sourceElement.Z ||
+				element.Visible != sourceElement.Visible || element.Locked != sourceElement.Locked {
+				t.Errorf("Expected element %d of page %d to keep its kind, z-index and state, got %+v", j, i, element)
+			}
+		}
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(board.Pages[0].Elements[1].Payload, &fields); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if fields["url"] != newURL {
+		t.Errorf("Expected the upload URL to be rewritten to %s, got %v", newURL, fields["url"])
+	}
+	if err := json.Unmarshal(board.Pages[1].Elements[0].Payload, &fields); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if fields["url"] != "https://cdn.example.com/sticker.png" {
+		t.Errorf("Expected remote URLs to be kept, got %v", fields["url"])
+	}
+}
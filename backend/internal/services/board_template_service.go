@@ -0,0 +1,233 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxBoardTemplatePages caps how many pages a board template may generate
+const MaxBoardTemplatePages = 400
+
+var (
+	// ErrBoardTemplateNotFound is returned when a board is created from an unknown template
+	ErrBoardTemplateNotFound = errors.New("board template not found")
+	// ErrInvalidTemplateDates is returned when the requested dates do not fit the template
+	ErrInvalidTemplateDates = errors.New("invalid template dates")
+)
+
+// plannedPage is a page a board template will create
+type plannedPage struct {
+	Title      string
+	Date       time.Time
+	TemplateID *uuid.UUID
+}
+
+type BoardTemplateService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewBoardTemplateService(db *gorm.DB) *BoardTemplateService {
+	return &BoardTemplateService{db: db, now: time.Now}
+}
+
+// ListTemplates returns all board templates ordered by name
+func (s *BoardTemplateService) ListTemplates() ([]models.BoardTemplate, error) {
+	var templates []models.BoardTemplate
+	if err := s.db.Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get board templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetTemplate retrieves a board template by ID
+func (s *BoardTemplateService) GetTemplate(templateID uuid.UUID) (*models.BoardTemplate, error) {
+	var template models.BoardTemplate
+	if err := s.db.First(&template, "id = ?", templateID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get board template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// CreateBoardFromTemplate creates a board and generates its pages from a board template in one
+// transaction. The page dates come from month, or from start and days, depending on the template's
+// date pattern; an empty skin falls back to the template's skin.
+func (s *BoardTemplateService) CreateBoardFromTemplate(templateID uuid.UUID, title, description, skin, month string, start *time.Time, days *int) (*models.Board, error) {
+	template, err := s.GetTemplate(templateID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return nil, ErrBoardTemplateNotFound
+		}
+		return nil, err
+	}
+
+	templatePages, err := DecodeBoardTemplatePages(template)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := templateDateRange(template, month, start, days, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := planTemplatePages(templatePages, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if skin == "" {
+		skin = template.Skin
+	}
+
+	var board *models.Board
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		board, err = NewBoardService(tx).CreateBoard(title, description, skin)
+		if err != nil {
+			return err
+		}
+
+		pageService := NewPageService(tx)
+		for _, page := range pages {
+			created, err := pageService.CreatePage(board.ID, page.Title, page.Date, page.TemplateID)
+			if err != nil {
+				return err
+			}
+			board.Pages = append(board.Pages, *created)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+// DecodeBoardTemplatePages decodes the page structure stored in a board template
+func DecodeBoardTemplatePages(template *models.BoardTemplate) ([]dto.BoardTemplatePage, error) {
+	var pages []dto.BoardTemplatePage
+	if err := json.Unmarshal(template.Pages, &pages); err != nil {
+		return nil, fmt.Errorf("failed to decode board template %s: %w", template.ID, err)
+	}
+
+	return pages, nil
+}
+
+// templateDateRange resolves the first and last day a new board spans.
+// Missing inputs default to the current month, or to today and the template's default length.
+func templateDateRange(template *models.BoardTemplate, month string, start *time.Time, days *int, now time.Time) (time.Time, time.Time, error) {
+	today := truncateToDay(now)
+
+	switch template.DatePattern {
+	case models.BoardTemplateDateMonth:
+		first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if month != "" {
+			parsed, err := time.Parse("2006-01", month)
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("%w: month must look like 2006-01", ErrInvalidTemplateDates)
+			}
+			first = parsed
+		} else if start != nil {
+			day := truncateToDay(*start)
+			first = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		}
+		return first, first.AddDate(0, 1, -1), nil
+
+	case models.BoardTemplateDateRange:
+		from := today
+		if start != nil {
+			from = truncateToDay(*start)
+		}
+		length := template.DefaultDays
+		if days != nil {
+			length = *days
+		}
+		if length < 1 {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: days must be at least 1", ErrInvalidTemplateDates)
+		}
+		return from, from.AddDate(0, 0, length-1), nil
+
+	default:
+		from := today
+		if start != nil {
+			from = truncateToDay(*start)
+		}
+		return from, from, nil
+	}
+}
+
+// planTemplatePages expands a board template's page structure over a date range
+func planTemplatePages(templatePages []dto.BoardTemplatePage, from, to time.Time) ([]plannedPage, error) {
+	var pages []plannedPage
+
+	for _, templatePage := range templatePages {
+		step := 0
+		switch templatePage.Repeat {
+		case dto.BoardTemplateRepeatDaily:
+			step = 1
+		case dto.BoardTemplateRepeatWeekly:
+			step = 7
+		}
+
+		if step == 0 {
+			date := from.AddDate(0, 0, templatePage.DayOffset)
+			pages = append(pages, plannedPage{
+				Title:      formatTemplateTitle(templatePage.Title, date, 1),
+				Date:       date,
+				TemplateID: templatePage.TemplateID,
+			})
+			continue
+		}
+
+		for i, date := 0, from.AddDate(0, 0, templatePage.DayOffset); !date.After(to); i, date = i+1, date.AddDate(0, 0, step) {
+			pages = append(pages, plannedPage{
+				Title:      formatTemplateTitle(templatePage.Title, date, i+1),
+				Date:       date,
+				TemplateID: templatePage.TemplateID,
+			})
+			if len(pages) > MaxBoardTemplatePages {
+				break
+			}
+		}
+	}
+
+	if len(pages) > MaxBoardTemplatePages {
+		return nil, fmt.Errorf("%w: a board template may create at most %d pages", ErrInvalidTemplateDates, MaxBoardTemplatePages)
+	}
+
+	return pages, nil
+}
+
+// formatTemplateTitle fills in the placeholders of a board template page title
+func formatTemplateTitle(title string, date time.Time, day int) string {
+	return strings.NewReplacer(
+		"{date}", date.Format("January 2"),
+		"{weekday}", date.Format("Monday"),
+		"{month}", date.Format("January 2006"),
+		"{day}", strconv.Itoa(day),
+	).Replace(title)
+}
+
+// truncateToDay returns midnight UTC of the calendar day of t
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
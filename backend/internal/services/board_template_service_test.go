@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+)
+
+func TestTemplateDateRange(t *testing.T) {
+	now := time.Date(2024, 2, 10, 15, 30, 0, 0, time.UTC)
+	start := time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)
+	days := 3
+
+	tests := []struct {
+		name     string
+		template models.BoardTemplate
+		month    string
+		start    *time.Time
+		days     *int
+		from     string
+		to       string
+	}{
+		{"month defaults to current", models.BoardTemplate{DatePattern: models.BoardTemplateDateMonth}, "", nil, nil, "2024-02-01", "2024-02-29"},
+		{"explicit month", models.BoardTemplate{DatePattern: models.BoardTemplateDateMonth}, "2024-04", nil, nil, "2024-04-01", "2024-04-30"},
+		{"month from start date", models.BoardTemplate{DatePattern: models.BoardTemplateDateMonth}, "", &start, nil, "2024-03-01", "2024-03-31"},
+		{"range uses default length", models.BoardTemplate{DatePattern: models.BoardTemplateDateRange, DefaultDays: 7}, "", &start, nil, "2024-03-05", "2024-03-11"},
+		{"range with days", models.BoardTemplate{DatePattern: models.BoardTemplateDateRange, DefaultDays: 7}, "", nil, &days, "2024-02-10", "2024-02-12"},
+		{"single day", models.BoardTemplate{DatePattern: models.BoardTemplateDateNone}, "", nil, nil, "2024-02-10", "2024-02-10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := templateDateRange(&tt.template, tt.month, tt.start, tt.days, now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := from.Format("2006-01-02"); got != tt.from {
+				t.Errorf("Expected range to start %s, got %s", tt.from, got)
+			}
+			if got := to.Format("2006-01-02"); got != tt.to {
+				t.Errorf("Expected range to end %s, got %s", tt.to, got)
+			}
+		})
+	}
+
+	if _, _, err := templateDateRange(&models.BoardTemplate{DatePattern: models.BoardTemplateDateMonth}, "April", nil, nil, now); !errors.Is(err, ErrInvalidTemplateDates) {
+		t.Errorf("Expected ErrInvalidTemplateDates for a malformed month, got %v", err)
+	}
+}
+
+func TestPlanTemplatePages(t *testing.T) {
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	pages, err := planTemplatePages([]dto.BoardTemplatePage{
+		{Title: "Goals for {month}"},
+		{Title: "Week {day} of {date}", Repeat: dto.BoardTemplateRepeatWeekly},
+		{Title: "{weekday}, {date}", Repeat: dto.BoardTemplateRepeatDaily},
+	}, from, to)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(pages) != 1+5+30 {
+		t.Fatalf("Expected 36 pages, got %d", len(pages))
+	}
+	if pages[0].Title != "Goals for April 2024" || !pages[0].Date.Equal(from) {
+		t.Errorf("Unexpected first page %q on %s", pages[0].Title, pages[0].Date)
+	}
+	if pages[5].Title != "Week 5 of April 29" {
+		t.Errorf("Expected last weekly page to be %q, got %q", "Week 5 of April 29", pages[5].Title)
+	}
+	if last := pages[len(pages)-1]; last.Title != "Tuesday, April 30" || !last.Date.Equal(to) {
+		t.Errorf("Unexpected last page %q on %s", last.Title, last.Date)
+	}
+
+	_, err = planTemplatePages([]dto.BoardTemplatePage{
+		{Title: "{date}", Repeat: dto.BoardTemplateRepeatDaily},
+	}, from, from.AddDate(2, 0, 0))
+	if !errors.Is(err, ErrInvalidTemplateDates) {
+		t.Errorf("Expected ErrInvalidTemplateDates for too many pages, got %v", err)
+	}
+}
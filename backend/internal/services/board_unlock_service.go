@@ -0,0 +1,207 @@
+package services
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// defaultUnlockTTL is how long an unlock token for a passphrase-protected board stays valid
+const defaultUnlockTTL = 30 * time.Minute
+
+// Board unlock errors
+var (
+	ErrInvalidPassphrase  = errors.New("invalid passphrase")
+	ErrBoardNotProtected  = errors.New("board is not protected by a passphrase")
+	ErrTooManyUnlockTries = errors.New("too many unlock attempts")
+)
+
+// BoardUnlockService manages passphrases on public board access and the unlock tokens
+// readers get by submitting them. Failed attempts are counted in the shared rate limit store.
+type BoardUnlockService struct {
+	db          *gorm.DB
+	ttl         time.Duration
+	now         func() time.Time
+	rateLimiter *RateLimiter
+}
+
+// NewBoardUnlockService creates the service. Unlock attempts stay throttled in process memory
+// when API rate limiting is turned off and rateLimiter is nil.
+func NewBoardUnlockService(db *gorm.DB, rateLimiter *RateLimiter) *BoardUnlockService {
+	ttl := defaultUnlockTTL
+	if value := os.Getenv("PUBLIC_UNLOCK_TTL_MINUTES"); value != "" {
+		if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+			ttl = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	if rateLimiter == nil {
+		rateLimiter = NewRateLimiter(NewMemoryRateLimitStore(), defaultRateLimits)
+	}
+
+	return &BoardUnlockService{
+		db:          db,
+		ttl:         ttl,
+		now:         time.Now,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// SetPassphrase protects public access to a board with a passphrase, replacing any previous one.
+// Unlock tokens issued for the old passphrase stop working.
+func (s *BoardUnlockService) SetPassphrase(boardID uuid.UUID, passphrase string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(passphrase), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash passphrase: %w", err)
+	}
+
+	return s.updatePassphraseHash(boardID, string(hash))
+}
+
+// RemovePassphrase makes public access to a board open again
+func (s *BoardUnlockService) RemovePassphrase(boardID uuid.UUID) error {
+	return s.updatePassphraseHash(boardID, "")
+}
+
+// updatePassphraseHash stores a board's passphrase hash
+func (s *BoardUnlockService) updatePassphraseHash(boardID uuid.UUID, hash string) error {
+	result := s.db.Model(&models.Board{}).Where("id = ?", boardID).Update("public_passphrase_hash", hash)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update board passphrase: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// Unlock checks a passphrase for a board and returns an unlock token with its expiry. clientKey
+// identifies the caller for throttling; when it has failed too often ErrTooManyUnlockTries is
+// returned together with how long to wait.
+func (s *BoardUnlockService) Unlock(boardID uuid.UUID, passphrase, clientKey string) (string, time.Time, time.Duration, error) {
+	clientKey = boardID.String() + "|" + clientKey
+	wait, err := s.unlockRetryAfter(boardID, clientKey)
+	if err != nil {
+		return "", time.Time{}, 0, err
+	}
+	if wait > 0 {
+		return "", time.Time{}, wait, ErrTooManyUnlockTries
+	}
+
+	var board models.Board
+	if err := s.db.Select("id", "public_passphrase_hash").First(&board, "id = ?", boardID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", time.Time{}, 0, utils.ErrNotFound
+		}
+		return "", time.Time{}, 0, fmt.Errorf("failed to get board: %w", err)
+	}
+	if board.PublicPassphraseHash == "" {
+		return "", time.Time{}, 0, ErrBoardNotProtected
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(board.PublicPassphraseHash), []byte(passphrase)); err != nil {
+		if err := s.recordUnlockFailure(boardID, clientKey); err != nil {
+			return "", time.Time{}, 0, err
+		}
+		return "", time.Time{}, 0, ErrInvalidPassphrase
+	}
+
+	if err := s.rateLimiter.Reset(RateLimitUnlock, clientKey); err != nil {
+		return "", time.Time{}, 0, fmt.Errorf("failed to reset unlock attempts: %w", err)
+	}
+	expiresAt := s.now().Add(s.ttl)
+	return IssueUnlockToken(boardID, board.PublicPassphraseHash, expiresAt), expiresAt, 0, nil
+}
+
+// unlockRetryAfter returns how long a client has to wait before trying to unlock a board again,
+// or zero. A client is refused once it has failed too often itself. While the board as a whole
+// sees too many failures, every attempt on it is slowed down to one per Window/Limit of the board
+// budget, whichever client makes it, so rotating client keys does not buy extra guesses while
+// other readers can still get through.
+func (s *BoardUnlockService) unlockRetryAfter(boardID uuid.UUID, clientKey string) (time.Duration, error) {
+	client, err := s.rateLimiter.Check(RateLimitUnlock, clientKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check unlock attempts: %w", err)
+	}
+	if !client.Allowed {
+		return client.Reset, nil
+	}
+
+	board, err := s.rateLimiter.Check(RateLimitUnlockBoard, boardID.String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to check unlock attempts: %w", err)
+	}
+	if board.Allowed {
+		return 0, nil
+	}
+
+	wait, err := s.rateLimiter.Pace(RateLimitUnlockBoard, boardID.String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to check unlock attempts: %w", err)
+	}
+	return wait, nil
+}
+
+// recordUnlockFailure counts a failed unlock attempt of a client and of the board
+func (s *BoardUnlockService) recordUnlockFailure(boardID uuid.UUID, clientKey string) error {
+	if _, err := s.rateLimiter.Allow(RateLimitUnlock, clientKey); err != nil {
+		return fmt.Errorf("failed to count unlock attempt: %w", err)
+	}
+	if _, err := s.rateLimiter.Allow(RateLimitUnlockBoard, boardID.String()); err != nil {
+		return fmt.Errorf("failed to count unlock attempt: %w", err)
+	}
+	return nil
+}
+
+// IssueUnlockToken signs an unlock token for a board that is valid until expiresAt. It is bound
+// to the passphrase hash, so changing or removing the passphrase revokes it.
+func IssueUnlockToken(boardID uuid.UUID, passphraseHash string, expiresAt time.Time) string {
+	return issueBoardGrant("board-unlock", boardID, passphraseHash, expiresAt)
+}
+
+// VerifyUnlockToken reports whether an unlock token was issued for the board's current
+// passphrase and has not expired
+func VerifyUnlockToken(boardID uuid.UUID, passphraseHash, token string, now time.Time) bool {
+	return verifyBoardGrant("board-unlock", boardID, passphraseHash, token, now)
+}
+
+// issueBoardGrant signs a short-lived grant of a board, written as "<expiry>.<signature>".
+// The purpose and binding are part of the signature but not of the grant.
+func issueBoardGrant(purpose string, boardID uuid.UUID, binding string, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expires + "." + boardGrantSignature(purpose, boardID, binding, expires)
+}
+
+// verifyBoardGrant reports whether a grant was issued for the purpose, board and binding and
+// has not expired
+func verifyBoardGrant(purpose string, boardID uuid.UUID, binding, grant string, now time.Time) bool {
+	expires, signature, found := strings.Cut(grant, ".")
+	if !found {
+		return false
+	}
+
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !now.Before(time.Unix(expiresUnix, 0)) {
+		return false
+	}
+
+	expected := boardGrantSignature(purpose, boardID, binding, expires)
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+// boardGrantSignature signs the parts of a board grant
+func boardGrantSignature(purpose string, boardID uuid.UUID, binding, expires string) string {
+	return utils.SignValue(purpose + "|" + boardID.String() + "|" + expires + "|" + binding)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyUnlockToken(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	boardID := uuid.New()
+	passphraseHash := "$2a$10$current"
+	token := IssueUnlockToken(boardID, passphraseHash, now.Add(30*time.Minute))
+
+	tests := []struct {
+		name           string
+		boardID        uuid.UUID
+		passphraseHash string
+		token          string
+		now            time.Time
+		expected       bool
+	}{
+		{
+			name:           "Valid token",
+			boardID:        boardID,
+			passphraseHash: passphraseHash,
+			token:          token,
+			now:            now,
+			expected:       true,
+		},
+		{
+			name:           "Expired token",
+			boardID:        boardID,
+			passphraseHash: passphraseHash,
+			token:          token,
+			now:            now.Add(30 * time.Minute),
+			expected:       false,
+		},
+		{
+			name:           "Token of another board",
+			boardID:        uuid.New(),
+			passphraseHash: passphraseHash,
+			token:          token,
+			now:            now,
+			expected:       false,
+		},
+		{
+			name:           "Passphrase changed since unlocking",
+			boardID:        boardID,
+			passphraseHash: "$2a$10$changed",
+			token:          token,
+			now:            now,
+			expected:       false,
+		},
+		{
+			name:           "Extended expiry",
+			boardID:        boardID,
+			passphraseHash: passphraseHash,
+			token:          "9999999999" + token[len("1705314600"):],
+			now:            now,
+			expected:       false,
+		},
+		{
+			name:           "Malformed token",
+			boardID:        boardID,
+			passphraseHash: passphraseHash,
+			token:          "not-a-token",
+			now:            now,
+			expected:       false,
+		},
+		{
+			name:           "Missing token",
+			boardID:        boardID,
+			passphraseHash: passphraseHash,
+			token:          "",
+			now:            now,
+			expected:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := VerifyUnlockToken(tt.boardID, tt.passphraseHash, tt.token, tt.now)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnlockRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(NewMemoryRateLimitStore(), map[string]RateLimit{
+		RateLimitUnlock:      {Limit: 3, Window: 15 * time.Minute},
+		RateLimitUnlockBoard: {Limit: 5, Window: 15 * time.Minute},
+	})
+	limiter.now = func() time.Time { return now }
+	service := &BoardUnlockService{rateLimiter: limiter, now: limiter.now}
+	boardID := uuid.New()
+
+	retryAfter := func(clientKey string) time.Duration {
+		t.Helper()
+		wait, err := service.unlockRetryAfter(boardID, clientKey)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return wait
+	}
+	fail := func(clientKey string, times int) {
+		t.Helper()
+		for i := 0; i < times; i++ {
+			if err := service.recordUnlockFailure(boardID, clientKey); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	}
+
+	fail("client", 2)
+	if wait := retryAfter("client"); wait != 0 {
+		t.Fatalf("Expected no wait below the client limit, got %v", wait)
+	}
+
+	fail("client", 1)
+	if wait := retryAfter("client"); wait != 15*time.Minute {
+		t.Fatalf("Expected the client to wait for its window to end, got %v", wait)
+	}
+
+	// The board is now over its limit: every attempt is paced to one per 3 minutes, whatever the client key
+	now = now.Add(5 * time.Minute)
+	fail("other", 2)
+	if wait := retryAfter("fresh"); wait != 0 {
+		t.Fatalf("Expected a paced attempt to go ahead, got %v", wait)
+	}
+	if wait := retryAfter("rotated"); wait != 3*time.Minute {
+		t.Fatalf("Expected a client without failures to be paced too, got %v", wait)
+	}
+	if wait := retryAfter("other"); wait != 3*time.Minute {
+		t.Fatalf("Expected a failing client to be paced, got %v", wait)
+	}
+
+	now = now.Add(3 * time.Minute)
+	if wait := retryAfter("another"); wait != 0 {
+		t.Fatalf("Expected the next paced attempt to go ahead, got %v", wait)
+	}
+
+	if err := limiter.Reset(RateLimitUnlock, "client"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if wait := retryAfter("client"); wait != 3*time.Minute {
+		t.Fatalf("Expected a reset client to still be paced by the board, got %v", wait)
+	}
+
+	// Once the board's window ends attempts are no longer paced
+	now = now.Add(7 * time.Minute)
+	if wait := retryAfter("client"); wait != 0 {
+		t.Fatalf("Expected the windows to have ended, got %v", wait)
+	}
+	if wait := retryAfter("rotated"); wait != 0 {
+		t.Fatalf("Expected attempts not to be paced after the board's window, got %v", wait)
+	}
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"junk-journal-board/internal/utils"
+)
+
+// checkVersion gives utils.ErrConflict when an expected version is set and the current
+// version differs
+func checkVersion(current int, expected *int) error {
+	if expected != nil && current != *expected {
+		return utils.ErrConflict
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"junk-journal-board/internal/utils"
+)
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  int
+		expected *int
+		err      error
+	}{
+		{name: "No expected version", current: 4, expected: nil, err: nil},
+		{name: "Matching version", current: 4, expected: intPtr(4), err: nil},
+		{name: "Stale version", current: 4, expected: intPtr(3), err: utils.ErrConflict},
+		{name: "Version from the future", current: 4, expected: intPtr(5), err: utils.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkVersion(tt.current, tt.expected); err != tt.err {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens an in-memory database with tables for the given models
+func newTestDB(t *testing.T, tables ...interface{}) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(tables...); err != nil {
+		t.Fatalf("Failed to create tables: %v", err)
+	}
+
+	return db
+}
+
+// useTempWorkDir runs the rest of a test in an empty working directory, where uploads are written
+func useTempWorkDir(t *testing.T) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change working directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// newBoardWithUpload creates a board with one page holding an image element whose file is
+// stored in the board's upload directory
+func newBoardWithUpload(t *testing.T, db *gorm.DB) *models.Board {
+	t.Helper()
+	uploadService := NewUploadService()
+
+	board := &models.Board{Title: "Trip"}
+	if err := db.Create(board).Error; err != nil {
+		t.Fatalf("Failed to create board: %v", err)
+	}
+	page := models.Page{BoardID: board.ID, Title: "Day 1", Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)}
+	if err := db.Create(&page).Error; err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+	payload := fmt.Sprintf(`{"url":%q}`, uploadService.GetPublicURL(board.ID, "photo.png"))
+	if err := db.Create(&models.Element{PageID: page.ID, Kind: "image", Visible: true, Payload: datatypes.JSON(payload)}).Error; err != nil {
+		t.Fatalf("Failed to create element: %v", err)
+	}
+
+	boardDir := uploadService.GetBoardUploadDir(board.ID)
+	if err := os.MkdirAll(boardDir, 0755); err != nil {
+		t.Fatalf("Failed to create upload directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(boardDir, "photo.png"), []byte("png"), 0644); err != nil {
+		t.Fatalf("Failed to write upload: %v", err)
+	}
+
+	return board
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ElementService struct {
@@ -63,8 +64,14 @@ func (s *ElementService) CreateElement(pageID uuid.UUID, kind string, x, y, w, h
 		Payload:  datatypes.JSON(payloadJSON),
 	}
 
-	if err := s.db.Create(element).Error; err != nil {
-		return nil, fmt.Errorf("failed to create element: %w", err)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(element).Error; err != nil {
+			return fmt.Errorf("failed to create element: %w", err)
+		}
+		return recordElementRevision(tx, models.ElementActionCreate, nil, element)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return element, nil
@@ -83,6 +90,70 @@ func (s *ElementService) GetElementsByPage(pageID uuid.UUID) ([]models.Element,
 	return elements, nil
 }
 
+// elementSortKeys are the columns elements can be listed by
+var elementSortKeys = map[string]sortKey{
+	"z":          {column: "z", kind: sortInt},
+	"created_at": {column: "created_at", kind: sortTime},
+	"updated_at": {column: "updated_at", kind: sortTime},
+}
+
+// ListElements returns a page of a page's elements, optionally of one kind, and the cursor of the next page
+func (s *ElementService) ListElements(pageID uuid.UUID, kind string, filter ListFilter, params ListParams) ([]models.Element, string, error) {
+	key := resolveSortKey(elementSortKeys, params.Sort, "z")
+
+	query := s.db.Where("elements.page_id = ?", pageID)
+	if kind != "" {
+		query = query.Where("elements.kind = ?", kind)
+	}
+	query = filter.apply(query, "", "elements.created_at")
+
+	query, err := applyKeyset(query, "elements", key, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var elements []models.Element
+	if err := query.Find(&elements).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to get elements: %w", err)
+	}
+
+	elements, next := trimPage(elements, params, func(element models.Element) string {
+		return encodeCursor(elementSortValue(&element, key.column), element.ID)
+	})
+
+	return elements, next, nil
+}
+
+// ListPinnedElements applies the filters and pagination of ListElements to the elements of a pinned page
+func ListPinnedElements(elements []models.Element, kind string, filter ListFilter, params ListParams) ([]models.Element, string, error) {
+	filtered := make([]models.Element, 0, len(elements))
+	for _, element := range elements {
+		if kind != "" && element.Kind != kind {
+			continue
+		}
+		if filter.matches("", element.CreatedAt) {
+			filtered = append(filtered, element)
+		}
+	}
+
+	key := resolveSortKey(elementSortKeys, params.Sort, "z")
+	return paginateSlice(filtered, key, params,
+		func(element models.Element) interface{} { return elementSortValue(&element, key.column) },
+		func(element models.Element) uuid.UUID { return element.ID })
+}
+
+// elementSortValue returns the value of an element's sort column
+func elementSortValue(element *models.Element, column string) interface{} {
+	switch column {
+	case "created_at":
+		return element.CreatedAt
+	case "updated_at":
+		return element.UpdatedAt
+	default:
+		return int64(element.Z)
+	}
+}
+
 // GetElementByID retrieves a single element by ID
 func (s *ElementService) GetElementByID(elementID uuid.UUID) (*models.Element, error) {
 	var element models.Element
@@ -97,16 +168,10 @@ func (s *ElementService) GetElementByID(elementID uuid.UUID) (*models.Element, e
 	return &element, nil
 }
 
-// UpdateElement updates element properties
-func (s *ElementService) UpdateElement(elementID uuid.UUID, updates map[string]interface{}) (*models.Element, error) {
-	var element models.Element
-	if err := s.db.First(&element, "id = ?", elementID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, utils.ErrNotFound
-		}
-		return nil, fmt.Errorf("failed to find element: %w", err)
-	}
-
+// UpdateElement updates element properties and bumps the element version.
+// When expectedVersion is set the update only applies to that version; on a mismatch
+// the current server copy is returned together with utils.ErrConflict.
+func (s *ElementService) UpdateElement(elementID uuid.UUID, updates map[string]interface{}, expectedVersion *int) (*models.Element, error) {
 	// Handle payload separately if it exists
 	if payload, exists := updates["payload"]; exists {
 		payloadJSON, err := json.Marshal(payload)
@@ -115,15 +180,41 @@ func (s *ElementService) UpdateElement(elementID uuid.UUID, updates map[string]i
 		}
 		updates["payload"] = datatypes.JSON(payloadJSON)
 	}
+	updates["version"] = gorm.Expr("version + 1")
 
-	// Update the element
-	if err := s.db.Model(&element).Updates(updates).Error; err != nil {
-		return nil, fmt.Errorf("failed to update element: %w", err)
-	}
+	var element models.Element
+	var current *models.Element
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the row so the version check and the history entry see a consistent state
+		var before models.Element
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, "id = ?", elementID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			return fmt.Errorf("failed to get element: %w", err)
+		}
 
-	// Reload the element to get updated values
-	if err := s.db.First(&element, "id = ?", elementID).Error; err != nil {
-		return nil, fmt.Errorf("failed to reload element: %w", err)
+		if err := checkVersion(before.Version, expectedVersion); err != nil {
+			current = &before
+			return err
+		}
+
+		if err := tx.Model(&models.Element{}).Where("id = ?", elementID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update element: %w", err)
+		}
+
+		// Reload the element to get updated values
+		if err := tx.First(&element, "id = ?", elementID).Error; err != nil {
+			return fmt.Errorf("failed to reload element: %w", err)
+		}
+
+		return recordElementRevision(tx, models.ElementActionUpdate, &before, &element)
+	})
+	if err == utils.ErrConflict {
+		return current, err
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return &element, nil
@@ -131,16 +222,21 @@ func (s *ElementService) UpdateElement(elementID uuid.UUID, updates map[string]i
 
 // DeleteElement deletes an element
 func (s *ElementService) DeleteElement(elementID uuid.UUID) error {
-	result := s.db.Delete(&models.Element{}, "id = ?", elementID)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete element: %w", result.Error)
-	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var before models.Element
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&before, "id = ?", elementID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			return fmt.Errorf("failed to get element: %w", err)
+		}
 
-	if result.RowsAffected == 0 {
-		return utils.ErrNotFound
-	}
+		if err := tx.Delete(&models.Element{}, "id = ?", elementID).Error; err != nil {
+			return fmt.Errorf("failed to delete element: %w", err)
+		}
 
-	return nil
+		return recordElementRevision(tx, models.ElementActionDelete, &before, nil)
+	})
 }
 
 // BatchUpdateZIndex updates z-index for multiple elements in a single transaction
@@ -151,24 +247,35 @@ func (s *ElementService) BatchUpdateZIndex(pageID uuid.UUID, updates []struct {
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		for _, update := range updates {
 			// Verify element belongs to the page
-			var count int64
-			err := tx.Model(&models.Element{}).
+			var before models.Element
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 				Where("id = ? AND page_id = ?", update.ID, pageID).
-				Count(&count).Error
+				First(&before).Error
 			if err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return fmt.Errorf("element %s not found on page %s", update.ID, pageID)
+				}
 				return fmt.Errorf("failed to verify element ownership: %w", err)
 			}
-			if count == 0 {
-				return fmt.Errorf("element %s not found on page %s", update.ID, pageID)
-			}
 
 			// Update z-index
 			err = tx.Model(&models.Element{}).
 				Where("id = ?", update.ID).
-				Update("z", update.Z).Error
+				Updates(map[string]interface{}{
+					"z":       update.Z,
+					"version": gorm.Expr("version + 1"),
+				}).Error
 			if err != nil {
 				return fmt.Errorf("failed to update z-index for element %s: %w", update.ID, err)
 			}
+
+			var after models.Element
+			if err := tx.First(&after, "id = ?", update.ID).Error; err != nil {
+				return fmt.Errorf("failed to reload element %s: %w", update.ID, err)
+			}
+			if err := recordElementRevision(tx, models.ElementActionReorder, &before, &after); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// History listing limits
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 200
+)
+
+type HistoryService struct {
+	db *gorm.DB
+}
+
+func NewHistoryService(db *gorm.DB) *HistoryService {
+	return &HistoryService{db: db}
+}
+
+// ListPageHistory returns a page's element revisions newest first, optionally for a single
+// element and only those recorded before the given time
+func (s *HistoryService) ListPageHistory(pageID uuid.UUID, elementID *uuid.UUID, before *time.Time, limit int) ([]models.ElementRevision, error) {
+	if limit <= 0 || limit > MaxHistoryLimit {
+		limit = DefaultHistoryLimit
+	}
+
+	query := s.db.Where("page_id = ?", pageID)
+	if elementID != nil {
+		query = query.Where("element_id = ?", *elementID)
+	}
+	if before != nil {
+		query = query.Where("created_at < ?", *before)
+	}
+
+	var revisions []models.ElementRevision
+	err := query.Order("created_at DESC").Limit(limit).Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page history: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetPageAsOf reconstructs the elements of a page as they were at the given time
+func (s *HistoryService) GetPageAsOf(pageID uuid.UUID, at time.Time) ([]models.Element, error) {
+	return s.pageStateAsOf(s.db, pageID, at)
+}
+
+// pageStateAsOf replays a page's revisions up to the given time
+func (s *HistoryService) pageStateAsOf(db *gorm.DB, pageID uuid.UUID, at time.Time) ([]models.Element, error) {
+	var revisions []models.ElementRevision
+	err := db.Where("page_id = ? AND created_at <= ?", pageID, at).
+		Order("created_at ASC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page history: %w", err)
+	}
+
+	// Elements created before history was recorded have no revisions; they are taken as they are now
+	var untracked []models.Element
+	err = db.Where("page_id = ? AND created_at <= ?", pageID, at).
+		Where("id NOT IN (?)", db.Model(&models.ElementRevision{}).Select("element_id").Where("page_id = ?", pageID)).
+		Find(&untracked).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get untracked elements: %w", err)
+	}
+
+	// Such elements first revised after that time are taken as their first revision found them
+	var firstRevisions []models.ElementRevision
+	err = db.Raw(`SELECT DISTINCT ON (element_id) * FROM element_revisions
+		WHERE page_id = ? AND created_at > ?
+		AND element_id NOT IN (SELECT element_id FROM element_revisions WHERE page_id = ? AND created_at <= ?)
+		ORDER BY element_id, created_at ASC`, pageID, at, pageID, at).
+		Scan(&firstRevisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get first revisions: %w", err)
+	}
+
+	start, err := elementsBeforeRevisions(untracked, firstRevisions, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return replayElementRevisions(start, revisions)
+}
+
+// RestorePage brings a page's elements back to how they were at the given time.
+// Every element that changes is recorded as a restore revision, which is returned.
+func (s *HistoryService) RestorePage(pageID uuid.UUID, at time.Time) ([]models.ElementRevision, error) {
+	changes := make([]models.ElementRevision, 0)
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Trashed elements are included so they can be brought back
+		var current []models.Element
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("page_id = ?", pageID).
+			Find(&current).Error
+		if err != nil {
+			return fmt.Errorf("failed to get elements: %w", err)
+		}
+
+		target, err := s.pageStateAsOf(tx, pageID, at)
+		if err != nil {
+			return err
+		}
+
+		currentByID := make(map[uuid.UUID]*models.Element, len(current))
+		for i := range current {
+			currentByID[current[i].ID] = &current[i]
+		}
+
+		for i := range target {
+			revision, err := applyElementState(tx, currentByID[target[i].ID], &target[i])
+			if err != nil {
+				return err
+			}
+			if revision != nil {
+				changes = append(changes, *revision)
+			}
+			delete(currentByID, target[i].ID)
+		}
+
+		// Anything left did not exist at that time
+		for _, element := range currentByID {
+			if element.DeletedAt.Valid {
+				continue
+			}
+			if err := tx.Delete(&models.Element{}, "id = ?", element.ID).Error; err != nil {
+				return fmt.Errorf("failed to delete element: %w", err)
+			}
+			revision, err := appendElementRevision(tx, models.ElementActionRestore, element, nil)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, *revision)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// RestoreElement brings an element back to the state recorded by a revision. Restoring a
+// deletion revision brings back the element as it was just before it was deleted.
+// The returned revision is nil when the element already matches that state.
+func (s *HistoryService) RestoreElement(pageID, revisionID uuid.UUID) (*models.Element, *models.ElementRevision, error) {
+	var source models.ElementRevision
+	if err := s.db.First(&source, "id = ? AND page_id = ?", revisionID, pageID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, utils.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	snapshot := source.After
+	if isEmptySnapshot(snapshot) {
+		snapshot = source.Before
+	}
+
+	var target models.Element
+	if err := json.Unmarshal(snapshot, &target); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode revision %s: %w", revisionID, err)
+	}
+
+	var element models.Element
+	var revision *models.ElementRevision
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var current *models.Element
+		var existing models.Element
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, "id = ?", target.ID).Error
+		if err == nil {
+			current = &existing
+		} else if err != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to get element: %w", err)
+		}
+
+		revision, err = applyElementState(tx, current, &target)
+		if err != nil {
+			return err
+		}
+
+		return tx.First(&element, "id = ?", target.ID).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &element, revision, nil
+}
+
+// applyElementState writes a past element state over the current one, bringing the element
+// back from the trash or recreating it if needed. It returns nil when nothing had to change.
+func applyElementState(tx *gorm.DB, current, target *models.Element) (*models.ElementRevision, error) {
+	trashed := current != nil && current.DeletedAt.Valid
+	if current != nil && !trashed && sameElementState(current, target) {
+		return nil, nil
+	}
+
+	restored := *target
+	restored.DeletedAt = gorm.DeletedAt{}
+	restored.UpdatedAt = time.Now()
+
+	if current == nil {
+		restored.Version = target.Version + 1
+		// Select all columns so false/zero values are not replaced by column defaults
+		if err := tx.Select("*").Create(&restored).Error; err != nil {
+			return nil, fmt.Errorf("failed to recreate element %s: %w", restored.ID, err)
+		}
+	} else {
+		restored.Version = current.Version + 1
+		restored.CreatedAt = current.CreatedAt
+		err := tx.Unscoped().Model(&models.Element{}).Where("id = ?", restored.ID).Updates(map[string]interface{}{
+			"kind":       restored.Kind,
+			"x":          restored.X,
+			"y":          restored.Y,
+			"w":          restored.W,
+			"h":          restored.H,
+			"rotation":   restored.Rotation,
+			"z":          restored.Z,
+			"visible":    restored.Visible,
+			"locked":     restored.Locked,
+			"payload":    restored.Payload,
+			"version":    restored.Version,
+			"updated_at": restored.UpdatedAt,
+			"deleted_at": nil,
+		}).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore element %s: %w", restored.ID, err)
+		}
+	}
+
+	// A trashed element counts as absent, so the restore reads as a re-creation
+	before := current
+	if trashed {
+		before = nil
+	}
+
+	return appendElementRevision(tx, models.ElementActionRestore, before, &restored)
+}
+
+// recordElementRevision appends an element mutation to the history table
+func recordElementRevision(tx *gorm.DB, action string, before, after *models.Element) error {
+	_, err := appendElementRevision(tx, action, before, after)
+	return err
+}
+
+// appendElementRevision appends an element mutation to the history table and returns it
+func appendElementRevision(tx *gorm.DB, action string, before, after *models.Element) (*models.ElementRevision, error) {
+	revision := &models.ElementRevision{Action: action}
+	if before != nil {
+		revision.ElementID = before.ID
+		revision.PageID = before.PageID
+	} else if after != nil {
+		revision.ElementID = after.ID
+		revision.PageID = after.PageID
+	}
+
+	var err error
+	if revision.Before, err = elementSnapshot(before); err != nil {
+		return nil, err
+	}
+	if revision.After, err = elementSnapshot(after); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(revision).Error; err != nil {
+		return nil, fmt.Errorf("failed to record element revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// elementSnapshot serializes an element for the history table; nil elements have no snapshot
+func elementSnapshot(element *models.Element) (datatypes.JSON, error) {
+	if element == nil {
+		return nil, nil
+	}
+
+	snapshot, err := json.Marshal(element)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot element %s: %w", element.ID, err)
+	}
+
+	return datatypes.JSON(snapshot), nil
+}
+
+// isEmptySnapshot reports whether a revision side holds no element
+func isEmptySnapshot(snapshot datatypes.JSON) bool {
+	return len(snapshot) == 0 || string(snapshot) == "null"
+}
+
+// elementsBeforeRevisions adds to the untracked elements those that already existed before
+// their first revision, recorded after the given time, as that revision found them
+func elementsBeforeRevisions(untracked []models.Element, firstRevisions []models.ElementRevision, at time.Time) ([]models.Element, error) {
+	elements := append([]models.Element(nil), untracked...)
+	for _, revision := range firstRevisions {
+		if isEmptySnapshot(revision.Before) {
+			// Created after that time
+			continue
+		}
+
+		var element models.Element
+		if err := json.Unmarshal(revision.Before, &element); err != nil {
+			return nil, fmt.Errorf("failed to decode revision %s: %w", revision.ID, err)
+		}
+		if element.CreatedAt.After(at) {
+			continue
+		}
+		elements = append(elements, element)
+	}
+
+	return elements, nil
+}
+
+// replayElementRevisions applies revisions in order on top of a starting set of elements
+// and returns the resulting elements ordered by z-index
+func replayElementRevisions(start []models.Element, revisions []models.ElementRevision) ([]models.Element, error) {
+	state := make(map[uuid.UUID]models.Element, len(start))
+	for _, element := range start {
+		state[element.ID] = element
+	}
+
+	for _, revision := range revisions {
+		if isEmptySnapshot(revision.After) {
+			delete(state, revision.ElementID)
+			continue
+		}
+
+		var element models.Element
+		if err := json.Unmarshal(revision.After, &element); err != nil {
+			return nil, fmt.Errorf("failed to decode revision %s: %w", revision.ID, err)
+		}
+		state[revision.ElementID] = element
+	}
+
+	elements := make([]models.Element, 0, len(state))
+	for _, element := range state {
+		elements = append(elements, element)
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		if elements[i].Z != elements[j].Z {
+			return elements[i].Z < elements[j].Z
+		}
+		return elements[i].CreatedAt.Before(elements[j].CreatedAt)
+	})
+
+	return elements, nil
+}
+
+// sameElementState reports whether two elements look the same, ignoring bookkeeping fields
+func sameElementState(a, b *models.Element) bool {
+	return len(elementChanges(a, b)) == 0
+}
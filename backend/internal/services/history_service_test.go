@@ -0,0 +1,135 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func snapshotOf(t *testing.T, element models.Element) datatypes.JSON {
+	t.Helper()
+	snapshot, err := elementSnapshot(&element)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return snapshot
+}
+
+func TestReplayElementRevisions(t *testing.T) {
+	pageID := uuid.New()
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	untracked := models.Element{ID: uuid.New(), PageID: pageID, Kind: "text", Z: 0, CreatedAt: base}
+	created := models.Element{ID: uuid.New(), PageID: pageID, Kind: "shape", X: 10, Z: 1, CreatedAt: base}
+	moved := created
+	moved.X = 50
+	deleted := models.Element{ID: uuid.New(), PageID: pageID, Kind: "sticker", Z: 2, CreatedAt: base}
+
+	revisions := []models.ElementRevision{
+		{ElementID: created.ID, Action: models.ElementActionCreate, After: snapshotOf(t, created)},
+		{ElementID: deleted.ID, Action: models.ElementActionCreate, After: snapshotOf(t, deleted)},
+		{ElementID: created.ID, Action: models.ElementActionUpdate, Before: snapshotOf(t, created), After: snapshotOf(t, moved)},
+		{ElementID: deleted.ID, Action: models.ElementActionDelete, Before: snapshotOf(t, deleted)},
+	}
+
+	elements, err := replayElementRevisions([]models.Element{untracked}, revisions)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(elements) != 2 {
+		t.Fatalf("Expected 2 elements, got %d", len(elements))
+	}
+	if elements[0].ID != untracked.ID {
+		t.Errorf("Expected untracked element first, got %s", elements[0].ID)
+	}
+	if elements[1].ID != created.ID || elements[1].X != 50 {
+		t.Errorf("Expected moved element with x=50, got %s with x=%v", elements[1].ID, elements[1].X)
+	}
+}
+
+func TestSameElementState(t *testing.T) {
+	a := &models.Element{Kind: "text", X: 1, Y: 2, Payload: datatypes.JSON(`{"content":"hi","fontSize":16}`)}
+
+	tests := []struct {
+		name     string
+		modify   func(e *models.Element)
+		expected bool
+	}{
+		{
+			name: "Payload key order and bookkeeping fields are ignored",
+			modify: func(e *models.Element) {
+				e.Payload = datatypes.JSON(`{"fontSize": 16, "content": "hi"}`)
+				e.Version = 7
+			},
+			expected: true,
+		},
+		{
+			name:     "Position change is detected",
+			modify:   func(e *models.Element) { e.X = 3 },
+			expected: false,
+		},
+		{
+			name:     "Payload change is detected",
+			modify:   func(e *models.Element) { e.Payload = datatypes.JSON(`{"content":"bye","fontSize":16}`) },
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := *a
+			tt.modify(&b)
+			if result := sameElementState(a, &b); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestElementsBeforeRevisions(t *testing.T) {
+	pageID := uuid.New()
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	at := base.Add(time.Hour)
+
+	untracked := models.Element{ID: uuid.New(), PageID: pageID, Kind: "text", CreatedAt: base}
+	// Existed before history was recorded, first moved after the requested time
+	legacy := models.Element{ID: uuid.New(), PageID: pageID, Kind: "shape", X: 10, Z: 1, CreatedAt: base}
+	legacyMoved := legacy
+	legacyMoved.X = 90
+	// Existed before history was recorded, but was created after the requested time
+	newer := models.Element{ID: uuid.New(), PageID: pageID, Kind: "shape", Z: 2, CreatedAt: at.Add(time.Minute)}
+	// Created with history after the requested time
+	created := models.Element{ID: uuid.New(), PageID: pageID, Kind: "sticker", Z: 3, CreatedAt: at.Add(time.Minute)}
+
+	firstRevisions := []models.ElementRevision{
+		{ElementID: legacy.ID, Action: models.ElementActionUpdate, Before: snapshotOf(t, legacy), After: snapshotOf(t, legacyMoved)},
+		{ElementID: newer.ID, Action: models.ElementActionDelete, Before: snapshotOf(t, newer)},
+		{ElementID: created.ID, Action: models.ElementActionCreate, After: snapshotOf(t, created)},
+	}
+
+	start, err := elementsBeforeRevisions([]models.Element{untracked}, firstRevisions, at)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Replaying no revisions up to the requested time keeps the element where it was
+	elements, err := replayElementRevisions(start, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(elements) != 2 {
+		t.Fatalf("Expected 2 elements, got %d", len(elements))
+	}
+	if elements[0].ID != untracked.ID {
+		t.Errorf("Expected untracked element first, got %s", elements[0].ID)
+	}
+	if elements[1].ID != legacy.ID || elements[1].X != 10 {
+		t.Errorf("Expected the element as its first revision found it with x=10, got %s with x=%v", elements[1].ID, elements[1].X)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"fmt"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// KeyringService manages keyrings, which let a client list the boards it owns with one token
+type KeyringService struct {
+	db           *gorm.DB
+	boardService *BoardService
+}
+
+func NewKeyringService(db *gorm.DB) *KeyringService {
+	return &KeyringService{
+		db:           db,
+		boardService: NewBoardService(db),
+	}
+}
+
+// CreateKeyring creates a keyring holding the boards of the given edit tokens and returns its board count
+func (s *KeyringService) CreateKeyring(editTokens []uuid.UUID) (*models.Keyring, int, error) {
+	keyring := &models.Keyring{}
+	var count int
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(keyring).Error; err != nil {
+			return fmt.Errorf("failed to create keyring: %w", err)
+		}
+
+		var err error
+		count, err = NewKeyringService(tx).addBoards(keyring, editTokens)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return keyring, count, nil
+}
+
+// GetKeyring retrieves a keyring by its token together with its board count
+func (s *KeyringService) GetKeyring(token uuid.UUID) (*models.Keyring, int, error) {
+	keyring, err := s.findKeyring(token)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := s.countBoards(keyring)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return keyring, count, nil
+}
+
+// AddBoards adds the boards of the given edit tokens to a keyring and returns its board count.
+// Unknown edit tokens are ignored so a keyring never reveals which tokens exist.
+func (s *KeyringService) AddBoards(token uuid.UUID, editTokens []uuid.UUID) (*models.Keyring, int, error) {
+	keyring, err := s.findKeyring(token)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := s.addBoards(keyring, editTokens)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return keyring, count, nil
+}
+
+// addBoards links boards to a keyring, skipping ones it already holds
+func (s *KeyringService) addBoards(keyring *models.Keyring, editTokens []uuid.UUID) (int, error) {
+	boardTokens, err := s.boardService.BoardIDsByEditTokens(editTokens)
+	if err != nil {
+		return 0, err
+	}
+
+	if links := keyringBoardLinks(keyring.ID, boardTokens); len(links) > 0 {
+		if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+			return 0, fmt.Errorf("failed to add boards to keyring: %w", err)
+		}
+	}
+
+	return s.countBoards(keyring)
+}
+
+// keyringBoardLinks links a keyring to the boards whose edit tokens were resolved, so only
+// boards the client holds an edit token for are ever added
+func keyringBoardLinks(keyringID uuid.UUID, boardTokens map[uuid.UUID]uuid.UUID) []models.KeyringBoard {
+	links := make([]models.KeyringBoard, 0, len(boardTokens))
+	for boardID := range boardTokens {
+		links = append(links, models.KeyringBoard{KeyringID: keyringID, BoardID: boardID})
+	}
+	return links
+}
+
+// DirectoryBoardIDs combines the boards of presented edit tokens with the boards of a keyring,
+// without duplicates
+func DirectoryBoardIDs(editTokenBoards map[uuid.UUID]uuid.UUID, keyringBoardIDs []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(editTokenBoards)+len(keyringBoardIDs))
+	boardIDs := make([]uuid.UUID, 0, len(editTokenBoards)+len(keyringBoardIDs))
+	for boardID := range editTokenBoards {
+		seen[boardID] = true
+		boardIDs = append(boardIDs, boardID)
+	}
+	for _, boardID := range keyringBoardIDs {
+		if !seen[boardID] {
+			seen[boardID] = true
+			boardIDs = append(boardIDs, boardID)
+		}
+	}
+	return boardIDs
+}
+
+// RemoveBoard takes a board off a keyring
+func (s *KeyringService) RemoveBoard(token, boardID uuid.UUID) error {
+	keyring, err := s.findKeyring(token)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.Where("keyring_id = ? AND board_id = ?", keyring.ID, boardID).Delete(&models.KeyringBoard{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove board from keyring: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteKeyring deletes a keyring; its boards are left untouched
+func (s *KeyringService) DeleteKeyring(token uuid.UUID) error {
+	result := s.db.Where("token_hash = ?", utils.HashToken(token)).Delete(&models.Keyring{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete keyring: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// BoardIDs returns the IDs of the boards held by a keyring
+func (s *KeyringService) BoardIDs(token uuid.UUID) ([]uuid.UUID, error) {
+	keyring, err := s.findKeyring(token)
+	if err != nil {
+		return nil, err
+	}
+
+	boardIDs := make([]uuid.UUID, 0)
+	err = s.db.Model(&models.KeyringBoard{}).
+		Where("keyring_id = ?", keyring.ID).
+		Pluck("board_id", &boardIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keyring boards: %w", err)
+	}
+
+	return boardIDs, nil
+}
+
+// findKeyring looks a keyring up by the hash of its token
+func (s *KeyringService) findKeyring(token uuid.UUID) (*models.Keyring, error) {
+	var keyring models.Keyring
+	if err := s.db.Where("token_hash = ?", utils.HashToken(token)).First(&keyring).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get keyring: %w", err)
+	}
+
+	// Only the hash is stored; hand back the token the client presented
+	keyring.Token = token
+	return &keyring, nil
+}
+
+// countBoards counts the boards of a keyring that have not been deleted
+func (s *KeyringService) countBoards(keyring *models.Keyring) (int, error) {
+	var count int64
+	err := s.db.Model(&models.KeyringBoard{}).
+		Joins("JOIN boards ON boards.id = keyring_boards.board_id AND boards.deleted_at IS NULL").
+		Where("keyring_boards.keyring_id = ?", keyring.ID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count keyring boards: %w", err)
+	}
+
+	return int(count), nil
+}
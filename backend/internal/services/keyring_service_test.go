@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestKeyringBoardLinks(t *testing.T) {
+	keyringID := uuid.New()
+	boardTokens := map[uuid.UUID]uuid.UUID{
+		uuid.New(): uuid.New(),
+		uuid.New(): uuid.New(),
+	}
+
+	links := keyringBoardLinks(keyringID, boardTokens)
+
+	if len(links) != len(boardTokens) {
+		t.Fatalf("Expected %d links, got %d", len(boardTokens), len(links))
+	}
+	for _, link := range links {
+		if link.KeyringID != keyringID {
+			t.Errorf("Expected keyring %s, got %s", keyringID, link.KeyringID)
+		}
+		if _, ok := boardTokens[link.BoardID]; !ok {
+			t.Errorf("Expected only boards with a resolved edit token, got %s", link.BoardID)
+		}
+	}
+
+	if links := keyringBoardLinks(keyringID, nil); len(links) != 0 {
+		t.Errorf("Expected no links without resolved edit tokens, got %d", len(links))
+	}
+}
+
+func TestDirectoryBoardIDs(t *testing.T) {
+	shared := uuid.New()
+	tokenOnly := uuid.New()
+	keyringOnly := uuid.New()
+
+	editTokenBoards := map[uuid.UUID]uuid.UUID{
+		shared:    uuid.New(),
+		tokenOnly: uuid.New(),
+	}
+	boardIDs := DirectoryBoardIDs(editTokenBoards, []uuid.UUID{shared, keyringOnly, keyringOnly})
+
+	if len(boardIDs) != 3 {
+		t.Fatalf("Expected 3 boards, got %v", boardIDs)
+	}
+	seen := make(map[uuid.UUID]bool)
+	for _, boardID := range boardIDs {
+		seen[boardID] = true
+	}
+	for _, boardID := range []uuid.UUID{shared, tokenOnly, keyringOnly} {
+		if !seen[boardID] {
+			t.Errorf("Expected board %s in the directory", boardID)
+		}
+	}
+
+	if boardIDs := DirectoryBoardIDs(nil, nil); boardIDs == nil || len(boardIDs) != 0 {
+		t.Errorf("Expected an empty, non-nil list, got %v", boardIDs)
+	}
+}
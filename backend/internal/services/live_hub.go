@@ -0,0 +1,181 @@
+package services
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"junk-journal-board/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// liveClientBuffer is how many pending messages a client may queue before it is dropped
+const liveClientBuffer = 64
+
+// LiveClient is a single connection subscribed to a board's live channel
+type LiveClient struct {
+	ID       uuid.UUID
+	BoardID  uuid.UUID
+	ReadOnly bool
+	Pinned   bool // sees the board's pinned snapshot, so changes to the live board are not sent
+	send     chan []byte
+}
+
+// Messages returns the channel of encoded events queued for the client
+func (c *LiveClient) Messages() <-chan []byte {
+	return c.send
+}
+
+// LiveHub fans out board events to connected WebSocket clients
+type LiveHub struct {
+	mu     sync.RWMutex
+	boards map[uuid.UUID]map[uuid.UUID]*LiveClient
+}
+
+func NewLiveHub() *LiveHub {
+	return &LiveHub{
+		boards: make(map[uuid.UUID]map[uuid.UUID]*LiveClient),
+	}
+}
+
+// Register subscribes a new client to a board
+func (h *LiveHub) Register(boardID uuid.UUID, readOnly, pinned bool) *LiveClient {
+	client := &LiveClient{
+		ID:       uuid.New(),
+		BoardID:  boardID,
+		ReadOnly: readOnly,
+		Pinned:   pinned,
+		send:     make(chan []byte, liveClientBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.boards[boardID] == nil {
+		h.boards[boardID] = make(map[uuid.UUID]*LiveClient)
+	}
+	h.boards[boardID][client.ID] = client
+
+	return client
+}
+
+// Unregister removes a client and closes its message channel
+func (h *LiveHub) Unregister(client *LiveClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(client)
+}
+
+// removeLocked removes a client while the write lock is held
+func (h *LiveHub) removeLocked(client *LiveClient) {
+	clients, ok := h.boards[client.BoardID]
+	if !ok {
+		return
+	}
+	if _, ok := clients[client.ID]; !ok {
+		return
+	}
+
+	delete(clients, client.ID)
+	close(client.send)
+	if len(clients) == 0 {
+		delete(h.boards, client.BoardID)
+	}
+}
+
+// DisconnectReaders closes the read-only clients of a board, e.g. when the snapshot they see
+// changes, so they reconnect to what they may see now
+func (h *LiveHub) DisconnectReaders(boardID uuid.UUID) {
+	h.disconnect(boardID, func(client *LiveClient) bool { return client.ReadOnly })
+}
+
+// DisconnectAll closes every client of a board, e.g. when tokens they may have connected with
+// are revoked. Clients still allowed on the board reconnect with their current credentials.
+func (h *LiveHub) DisconnectAll(boardID uuid.UUID) {
+	h.disconnect(boardID, func(client *LiveClient) bool { return true })
+}
+
+// disconnect closes the clients of a board that match
+func (h *LiveHub) disconnect(boardID uuid.UUID, match func(client *LiveClient) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, client := range h.boards[boardID] {
+		if match(client) {
+			h.removeLocked(client)
+		}
+	}
+}
+
+// Broadcast sends an event to every client of the event's board except the excluded one.
+// Clients seeing a pinned snapshot only get presence events.
+func (h *LiveHub) Broadcast(event dto.LiveEvent, excludeClientID uuid.UUID) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	message, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, client := range h.boards[event.BoardID] {
+		if id == excludeClientID || (client.Pinned && !isPresenceEvent(event.Type)) {
+			continue
+		}
+
+		select {
+		case client.send <- message:
+		default:
+			// Drop clients that cannot keep up rather than blocking the request
+			h.removeLocked(client)
+		}
+	}
+}
+
+// Send queues an event for a single client
+func (h *LiveHub) Send(client *LiveClient, event dto.LiveEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	message, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.boards[client.BoardID][client.ID]; !ok {
+		return
+	}
+
+	select {
+	case client.send <- message:
+	default:
+		h.removeLocked(client)
+	}
+}
+
+// isPresenceEvent reports whether an event is about live sessions rather than board content
+func isPresenceEvent(eventType string) bool {
+	switch eventType {
+	case dto.LiveEventPresenceJoined, dto.LiveEventPresenceUpdated, dto.LiveEventPresenceLeft:
+		return true
+	}
+	return false
+}
+
+// ClientCount returns the number of clients connected to a board
+func (h *LiveHub) ClientCount(boardID uuid.UUID) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.boards[boardID])
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"testing"
+
+	"junk-journal-board/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+func TestLiveHubBroadcast(t *testing.T) {
+	hub := NewLiveHub()
+	boardID := uuid.New()
+	author := hub.Register(boardID, false, false)
+	editor := hub.Register(boardID, false, false)
+	reader := hub.Register(boardID, true, false)
+	otherBoard := hub.Register(uuid.New(), false, false)
+
+	hub.Broadcast(dto.LiveEvent{Type: dto.LiveEventElementUpdated, BoardID: boardID}, author.ID)
+
+	if receivedEvent(author, dto.LiveEventElementUpdated) {
+		t.Error("Expected the originating client not to get its own change echoed back")
+	}
+	if !receivedEvent(editor, dto.LiveEventElementUpdated) {
+		t.Error("Expected other editors to receive the change")
+	}
+	if !receivedEvent(reader, dto.LiveEventElementUpdated) {
+		t.Error("Expected read-only clients of the live board to receive the change")
+	}
+	if receivedEvent(otherBoard, dto.LiveEventElementUpdated) {
+		t.Error("Expected clients of other boards not to receive the change")
+	}
+}
+
+func TestLiveHubDropsSlowClients(t *testing.T) {
+	hub := NewLiveHub()
+	boardID := uuid.New()
+	slow := hub.Register(boardID, true, false)
+
+	for i := 0; i <= liveClientBuffer; i++ {
+		hub.Broadcast(dto.LiveEvent{Type: dto.LiveEventElementUpdated, BoardID: boardID}, uuid.Nil)
+	}
+
+	if hub.ClientCount(boardID) != 0 {
+		t.Fatalf("Expected a client with a full buffer to be dropped, got %d clients", hub.ClientCount(boardID))
+	}
+	received := 0
+	for range slow.Messages() {
+		received++
+	}
+	if received != liveClientBuffer {
+		t.Errorf("Expected the %d queued events before the channel closed, got %d", liveClientBuffer, received)
+	}
+}
+
+func TestLiveHubPinnedClients(t *testing.T) {
+	hub := NewLiveHub()
+	boardID := uuid.New()
+	editor := hub.Register(boardID, false, false)
+	reader := hub.Register(boardID, true, true)
+
+	hub.Broadcast(dto.LiveEvent{Type: dto.LiveEventElementUpdated, BoardID: boardID}, uuid.Nil)
+	hub.Broadcast(dto.LiveEvent{Type: dto.LiveEventPresenceJoined, BoardID: boardID}, uuid.Nil)
+
+	if !receivedEvent(editor, dto.LiveEventElementUpdated) {
+		t.Errorf("Expected the editor to receive %s", dto.LiveEventElementUpdated)
+	}
+	if !receivedEvent(reader, dto.LiveEventPresenceJoined) {
+		t.Errorf("Expected the pinned reader to receive %s", dto.LiveEventPresenceJoined)
+	}
+
+	hub.Broadcast(dto.LiveEvent{Type: dto.LiveEventPageCreated, BoardID: boardID}, uuid.Nil)
+	if receivedEvent(reader, dto.LiveEventPageCreated) {
+		t.Errorf("Expected the pinned reader not to receive changes to the live board")
+	}
+}
+
+func TestLiveHubDisconnectReaders(t *testing.T) {
+	hub := NewLiveHub()
+	boardID := uuid.New()
+	editor := hub.Register(boardID, false, false)
+	reader := hub.Register(boardID, true, true)
+	otherBoard := hub.Register(uuid.New(), true, false)
+
+	hub.DisconnectReaders(boardID)
+
+	if _, open := <-reader.Messages(); open {
+		t.Error("Expected the reader's channel to be closed")
+	}
+	if hub.ClientCount(boardID) != 1 {
+		t.Errorf("Expected only the editor to stay connected, got %d clients", hub.ClientCount(boardID))
+	}
+
+	hub.Broadcast(dto.LiveEvent{Type: dto.LiveEventPageCreated, BoardID: boardID}, uuid.Nil)
+	if !receivedEvent(editor, dto.LiveEventPageCreated) {
+		t.Errorf("Expected the editor to keep receiving events")
+	}
+	if hub.ClientCount(otherBoard.BoardID) != 1 {
+		t.Error("Expected readers of other boards to stay connected")
+	}
+}
+
+func TestLiveHubDisconnectAll(t *testing.T) {
+	hub := NewLiveHub()
+	boardID := uuid.New()
+	editor := hub.Register(boardID, false, false)
+	reader := hub.Register(boardID, true, false)
+	otherBoard := hub.Register(uuid.New(), false, false)
+
+	hub.DisconnectAll(boardID)
+
+	for _, client := range []*LiveClient{editor, reader} {
+		if _, open := <-client.Messages(); open {
+			t.Errorf("Expected client %s to be disconnected", client.ID)
+		}
+	}
+	if hub.ClientCount(boardID) != 0 {
+		t.Errorf("Expected no clients left on the board, got %d", hub.ClientCount(boardID))
+	}
+	if hub.ClientCount(otherBoard.BoardID) != 1 {
+		t.Error("Expected clients of other boards to stay connected")
+	}
+}
@@ -19,45 +19,124 @@ func NewPageService(db *gorm.DB) *PageService {
 	return &PageService{db: db}
 }
 
-// CreatePage creates a new page with proper ordering
-func (s *PageService) CreatePage(boardID uuid.UUID, title string, date time.Time) (*models.Page, error) {
-	// Get the next order index for this board
-	var maxOrder int
-	err := s.db.Model(&models.Page{}).
-		Where("board_id = ?", boardID).
-		Select("COALESCE(MAX(order_idx), -1) + 1").
-		Scan(&maxOrder).Error
-	if err != nil {
-		return nil, fmt.Errorf("failed to get next order index: %w", err)
-	}
+// CreatePage creates a new page with proper ordering, optionally laid out from a page template
+func (s *PageService) CreatePage(boardID uuid.UUID, title string, date time.Time, templateID *uuid.UUID) (*models.Page, error) {
+	var page *models.Page
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Get the next order index for this board
+		var maxOrder int
+		err := tx.Model(&models.Page{}).
+			Where("board_id = ?", boardID).
+			Select("COALESCE(MAX(order_idx), -1) + 1").
+			Scan(&maxOrder).Error
+		if err != nil {
+			return fmt.Errorf("failed to get next order index: %w", err)
+		}
 
-	page := &models.Page{
-		BoardID:  boardID,
-		Title:    title,
-		Date:     date,
-		OrderIdx: maxOrder,
-	}
+		page = &models.Page{
+			BoardID:  boardID,
+			Title:    title,
+			Date:     date,
+			OrderIdx: maxOrder,
+		}
+
+		if err := tx.Create(page).Error; err != nil {
+			return fmt.Errorf("failed to create page: %w", err)
+		}
+
+		if templateID != nil {
+			elements, err := instantiateTemplate(tx, boardID, *templateID, page.ID)
+			if err != nil {
+				return err
+			}
+			page.Elements = elements
+		}
 
-	if err := s.db.Create(page).Error; err != nil {
-		return nil, fmt.Errorf("failed to create page: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return page, nil
 }
 
-// GetPagesByBoard retrieves all pages for a board ordered by order_idx
-func (s *PageService) GetPagesByBoard(boardID uuid.UUID) ([]models.Page, error) {
-	var pages []models.Page
-	err := s.db.Preload("Elements", func(db *gorm.DB) *gorm.DB {
+// pageSortKeys are the columns pages can be listed by
+var pageSortKeys = map[string]sortKey{
+	"order_idx":  {column: "order_idx", kind: sortInt},
+	"date":       {column: "date", kind: sortTime},
+	"title":      {column: "title", kind: sortString},
+	"created_at": {column: "created_at", kind: sortTime},
+}
+
+// ListPages returns a page of a board's pages with their elements and the cursor of the next page.
+// A non-nil pageIDs restricts the listing to those pages.
+func (s *PageService) ListPages(boardID uuid.UUID, filter ListFilter, pageIDs []uuid.UUID, params ListParams) ([]models.Page, string, error) {
+	key := resolveSortKey(pageSortKeys, params.Sort, "order_idx")
+
+	query := s.db.Preload("Elements", func(db *gorm.DB) *gorm.DB {
 		return db.Order("z ASC")
-	}).Where("board_id = ?", boardID).
-		Order("order_idx ASC").
-		Find(&pages).Error
+	}).Where("pages.board_id = ?", boardID)
+	if pageIDs != nil {
+		query = query.Where("pages.id IN ?", pageIDs)
+	}
+	query = filter.apply(query, "pages.title", "pages.date")
+
+	query, err := applyKeyset(query, "pages", key, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pages: %w", err)
+		return nil, "", err
+	}
+
+	var pages []models.Page
+	if err := query.Find(&pages).Error; err != nil {
+		return nil, "", fmt.Errorf("failed to get pages: %w", err)
+	}
+
+	pages, next := trimPage(pages, params, func(page models.Page) string {
+		return encodeCursor(pageSortValue(&page, key.column), page.ID)
+	})
+
+	return pages, next, nil
+}
+
+// ListPinnedPages applies the filters and pagination of ListPages to the pages of a pinned snapshot
+func ListPinnedPages(pages []models.Page, filter ListFilter, pageIDs []uuid.UUID, params ListParams) ([]models.Page, string, error) {
+	var allowed map[uuid.UUID]bool
+	if pageIDs != nil {
+		allowed = make(map[uuid.UUID]bool, len(pageIDs))
+		for _, id := range pageIDs {
+			allowed[id] = true
+		}
+	}
+
+	filtered := make([]models.Page, 0, len(pages))
+	for _, page := range pages {
+		if allowed != nil && !allowed[page.ID] {
+			continue
+		}
+		if filter.matches(page.Title, page.Date) {
+			filtered = append(filtered, page)
+		}
 	}
 
-	return pages, nil
+	key := resolveSortKey(pageSortKeys, params.Sort, "order_idx")
+	return paginateSlice(filtered, key, params,
+		func(page models.Page) interface{} { return pageSortValue(&page, key.column) },
+		func(page models.Page) uuid.UUID { return page.ID })
+}
+
+// pageSortValue returns the value of a page's sort column
+func pageSortValue(page *models.Page, column string) interface{} {
+	switch column {
+	case "date":
+		return page.Date
+	case "title":
+		return page.Title
+	case "created_at":
+		return page.CreatedAt
+	default:
+		return int64(page.OrderIdx)
+	}
 }
 
 // GetPageByID retrieves a single page with its elements
@@ -76,64 +155,97 @@ func (s *PageService) GetPageByID(pageID uuid.UUID) (*models.Page, error) {
 	return &page, nil
 }
 
-// UpdatePage updates page metadata
-func (s *PageService) UpdatePage(pageID uuid.UUID, title string, date time.Time, orderIdx *int) (*models.Page, error) {
+// UpdatePage updates page metadata and bumps the page version.
+// When expectedVersion is set the update only applies to that version; on a mismatch
+// the current server copy is returned together with utils.ErrConflict.
+func (s *PageService) UpdatePage(pageID uuid.UUID, title string, date time.Time, orderIdx *int, expectedVersion *int) (*models.Page, error) {
 	var page models.Page
-	if err := s.db.First(&page, "id = ?", pageID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, utils.ErrNotFound
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&page, "id = ?", pageID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			return fmt.Errorf("failed to find page: %w", err)
+		}
+
+		if err := checkVersion(page.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		// Handle order index update if provided
+		if orderIdx != nil {
+			if err := s.updatePageOrder(tx, &page, *orderIdx); err != nil {
+				return fmt.Errorf("failed to update page order: %w", err)
+			}
 		}
-		return nil, fmt.Errorf("failed to find page: %w", err)
-	}
 
-	// Update fields
-	page.Title = title
-	page.Date = date
+		// Guard against a concurrent update between the read and the write
+		result := tx.Model(&models.Page{}).
+			Where("id = ? AND version = ?", page.ID, page.Version).
+			Updates(map[string]interface{}{
+				"title":     title,
+				"date":      date,
+				"order_idx": page.OrderIdx,
+				"version":   gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update page: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return utils.ErrConflict
+		}
+
+		return nil
+	})
 
-	// Handle order index update if provided
-	if orderIdx != nil {
-		if err := s.updatePageOrder(&page, *orderIdx); err != nil {
-			return nil, fmt.Errorf("failed to update page order: %w", err)
+	if err == utils.ErrConflict {
+		current, getErr := s.getPage(pageID)
+		if getErr != nil {
+			return nil, getErr
 		}
+		return current, utils.ErrConflict
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.db.Save(&page).Error; err != nil {
-		return nil, fmt.Errorf("failed to update page: %w", err)
+	return s.getPage(pageID)
+}
+
+// getPage retrieves a page without its elements
+func (s *PageService) getPage(pageID uuid.UUID) (*models.Page, error) {
+	var page models.Page
+	if err := s.db.First(&page, "id = ?", pageID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get page: %w", err)
 	}
 
 	return &page, nil
 }
 
-// DeletePage deletes a page and all its elements (cascade delete)
+// DeletePage moves a page to the trash; its elements are hidden along with it
 func (s *PageService) DeletePage(pageID uuid.UUID) error {
-	// Start a transaction to ensure atomicity
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		// First, delete all elements associated with this page
-		if err := tx.Where("page_id = ?", pageID).Delete(&models.Element{}).Error; err != nil {
-			return fmt.Errorf("failed to delete page elements: %w", err)
-		}
-
-		// Then delete the page itself
-		result := tx.Delete(&models.Page{}, "id = ?", pageID)
-		if result.Error != nil {
-			return fmt.Errorf("failed to delete page: %w", result.Error)
-		}
+	result := s.db.Delete(&models.Page{}, "id = ?", pageID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete page: %w", result.Error)
+	}
 
-		if result.RowsAffected == 0 {
-			return utils.ErrNotFound
-		}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // updatePageOrder handles reordering pages when order_idx changes
-func (s *PageService) updatePageOrder(page *models.Page, newOrderIdx int) error {
+func (s *PageService) updatePageOrder(db *gorm.DB, page *models.Page, newOrderIdx int) error {
 	if page.OrderIdx == newOrderIdx {
 		return nil // No change needed
 	}
 
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
 		oldOrderIdx := page.OrderIdx
 
 		if newOrderIdx > oldOrderIdx {
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Page sizes of paginated list endpoints
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded for the requested sort
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ListParams controls cursor pagination and ordering of a list. A zero Limit returns every item,
+// which is only meant for internal callers; list endpoints default to DefaultListLimit.
+type ListParams struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Desc   bool
+}
+
+// ListFilter narrows a list by title prefix and by a date range. From is inclusive and To exclusive.
+type ListFilter struct {
+	TitlePrefix string
+	From        *time.Time
+	To          *time.Time
+}
+
+// apply adds the filter conditions for the given title and date columns to a query
+func (f ListFilter) apply(query *gorm.DB, titleColumn, dateColumn string) *gorm.DB {
+	if f.TitlePrefix != "" && titleColumn != "" {
+		query = query.Where(titleColumn+" ILIKE ?", likePrefix(f.TitlePrefix))
+	}
+	if f.From != nil {
+		query = query.Where(dateColumn+" >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where(dateColumn+" < ?", *f.To)
+	}
+	return query
+}
+
+// matches reports whether an in-memory item with the given title and date passes the filter
+func (f ListFilter) matches(title string, date time.Time) bool {
+	if f.TitlePrefix != "" && !strings.HasPrefix(strings.ToLower(title), strings.ToLower(f.TitlePrefix)) {
+		return false
+	}
+	if f.From != nil && date.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !date.Before(*f.To) {
+		return false
+	}
+	return true
+}
+
+// sortKind decides how the values of a sort key are compared and stored in cursors
+type sortKind int
+
+const (
+	sortInt sortKind = iota
+	sortTime
+	sortString
+)
+
+// sortKey is a column a list can be ordered by; ties are broken by ID
+type sortKey struct {
+	column string
+	kind   sortKind
+}
+
+// listCursor is the position of the last item of a page of results
+type listCursor struct {
+	Value json.RawMessage `json:"v"`
+	ID    uuid.UUID       `json:"id"`
+}
+
+// resolveSortKey returns the sort key for a name, falling back to the default
+func resolveSortKey(keys map[string]sortKey, name, defaultName string) sortKey {
+	if key, ok := keys[name]; ok {
+		return key
+	}
+	return keys[defaultName]
+}
+
+// encodeCursor builds an opaque cursor pointing just after an item
+func encodeCursor(value interface{}, id uuid.UUID) string {
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC().Format(time.RFC3339Nano)
+	}
+	rawValue, _ := json.Marshal(value)
+	data, _ := json.Marshal(listCursor{Value: rawValue, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes a cursor into a typed sort value and ID
+func decodeCursor(cursor string, kind sortKind) (interface{}, uuid.UUID, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, uuid.Nil, ErrInvalidCursor
+	}
+
+	var decoded listCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, uuid.Nil, ErrInvalidCursor
+	}
+
+	switch kind {
+	case sortInt:
+		var value int64
+		if err := json.Unmarshal(decoded.Value, &value); err != nil {
+			return nil, uuid.Nil, ErrInvalidCursor
+		}
+		return value, decoded.ID, nil
+	case sortTime:
+		var raw string
+		if err := json.Unmarshal(decoded.Value, &raw); err != nil {
+			return nil, uuid.Nil, ErrInvalidCursor
+		}
+		value, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return nil, uuid.Nil, ErrInvalidCursor
+		}
+		return value, decoded.ID, nil
+	default:
+		var value string
+		if err := json.Unmarshal(decoded.Value, &value); err != nil {
+			return nil, uuid.Nil, ErrInvalidCursor
+		}
+		return value, decoded.ID, nil
+	}
+}
+
+// applyKeyset orders a query by a sort key and ID and continues after the cursor.
+// It asks for one extra row so trimPage can tell whether another page follows.
+func applyKeyset(query *gorm.DB, table string, key sortKey, params ListParams) (*gorm.DB, error) {
+	direction, operator := "ASC", ">"
+	if params.Desc {
+		direction, operator = "DESC", "<"
+	}
+	column := table + "." + key.column
+
+	if params.Cursor != "" {
+		value, id, err := decodeCursor(params.Cursor, key.kind)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s.id) %s (?, ?)", column, table, operator), value, id)
+	}
+
+	query = query.Order(fmt.Sprintf("%s %s, %s.id %s", column, direction, table, direction))
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit + 1)
+	}
+
+	return query, nil
+}
+
+// trimPage cuts a result fetched with applyKeyset down to the requested size and returns the
+// cursor of the next page, or "" on the last page
+func trimPage[T any](items []T, params ListParams, cursorOf func(T) string) ([]T, string) {
+	if params.Limit <= 0 || len(items) <= params.Limit {
+		return items, ""
+	}
+
+	items = items[:params.Limit]
+	return items, cursorOf(items[len(items)-1])
+}
+
+// paginateSlice applies the ordering and cursor of applyKeyset to items held in memory,
+// such as the pages of a pinned snapshot
+func paginateSlice[T any](items []T, key sortKey, params ListParams, valueOf func(T) interface{}, idOf func(T) uuid.UUID) ([]T, string, error) {
+	sorted := make([]T, len(items))
+	copy(sorted, items)
+
+	compare := func(a, b T) int {
+		if c := compareSortValues(valueOf(a), valueOf(b)); c != 0 {
+			return c
+		}
+		idA, idB := idOf(a), idOf(b)
+		return bytes.Compare(idA[:], idB[:])
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if params.Desc {
+			return compare(sorted[i], sorted[j]) > 0
+		}
+		return compare(sorted[i], sorted[j]) < 0
+	})
+
+	if params.Cursor != "" {
+		value, id, err := decodeCursor(params.Cursor, key.kind)
+		if err != nil {
+			return nil, "", err
+		}
+
+		start := len(sorted)
+		for i, item := range sorted {
+			c := compareSortValues(valueOf(item), value)
+			if c == 0 {
+				itemID := idOf(item)
+				c = bytes.Compare(itemID[:], id[:])
+			}
+			if (!params.Desc && c > 0) || (params.Desc && c < 0) {
+				start = i
+				break
+			}
+		}
+		sorted = sorted[start:]
+	}
+
+	if params.Limit > 0 && len(sorted) > params.Limit {
+		sorted = sorted[:params.Limit+1]
+	}
+	page, next := trimPage(sorted, params, func(item T) string {
+		return encodeCursor(valueOf(item), idOf(item))
+	})
+
+	return page, next, nil
+}
+
+// compareSortValues compares two sort values of the same kind
+func compareSortValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case string:
+		return strings.Compare(a, b.(string))
+	}
+	return 0
+}
+
+// likePrefix escapes a string for use as a LIKE prefix pattern
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
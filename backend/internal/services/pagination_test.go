@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.New()
+	when := time.Date(2024, 3, 5, 10, 30, 0, 123456000, time.UTC)
+
+	value, gotID, err := decodeCursor(encodeCursor(when, id), sortTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotID != id || !value.(time.Time).Equal(when) {
+		t.Errorf("expected %v/%v, got %v/%v", when, id, value, gotID)
+	}
+
+	if _, _, err := decodeCursor(encodeCursor("title", id), sortInt); err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor for a mismatched sort, got %v", err)
+	}
+	if _, _, err := decodeCursor("not a cursor!", sortString); err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor for garbage, got %v", err)
+	}
+}
+
+func TestListPinnedPagesWalksAllPages(t *testing.T) {
+	pages := make([]models.Page, 5)
+	for i := range pages {
+		pages[i] = models.Page{ID: uuid.New(), Title: "Day", OrderIdx: 4 - i}
+	}
+
+	for _, desc := range []bool{false, true} {
+		params := ListParams{Limit: 2, Desc: desc}
+		var seen []int
+		for {
+			page, next, err := ListPinnedPages(pages, ListFilter{}, nil, params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, p := range page {
+				seen = append(seen, p.OrderIdx)
+			}
+			if next == "" {
+				break
+			}
+			params.Cursor = next
+		}
+
+		expected := []int{0, 1, 2, 3, 4}
+		if desc {
+			expected = []int{4, 3, 2, 1, 0}
+		}
+		if len(seen) != len(expected) {
+			t.Fatalf("desc=%v: expected %v, got %v", desc, expected, seen)
+		}
+		for i := range expected {
+			if seen[i] != expected[i] {
+				t.Errorf("desc=%v: expected %v, got %v", desc, expected, seen)
+				break
+			}
+		}
+	}
+}
+
+func TestListFilterMatches(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	filter := ListFilter{TitlePrefix: "tr", From: &from, To: &to}
+
+	tests := []struct {
+		title    string
+		date     time.Time
+		expected bool
+	}{
+		{"Travel", from, true},
+		{"Travel", to.Add(-time.Second), true},
+		{"Travel", to, false},
+		{"Travel", from.Add(-time.Second), false},
+		{"Gratitude", from, false},
+	}
+
+	for _, tt := range tests {
+		if got := filter.matches(tt.title, tt.date); got != tt.expected {
+			t.Errorf("matches(%q, %v): expected %v, got %v", tt.title, tt.date, tt.expected, got)
+		}
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	if got := likePrefix(`50%_off\`); got != `50\%\_off\\%` {
+		t.Errorf("unexpected pattern %q", got)
+	}
+}
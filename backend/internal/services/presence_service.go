@@ -0,0 +1,281 @@
+package services
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"junk-journal-board/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// defaultPresenceTTL is how long a session may stay silent before it is dropped from the roster
+const defaultPresenceTTL = 60 * time.Second
+
+// presenceColors is the palette assigned to sessions that have not picked a color
+var presenceColors = []string{
+	"#2563EB", "#DC2626", "#16A34A", "#D97706",
+	"#9333EA", "#DB2777", "#0891B2", "#65A30D",
+}
+
+// PresenceSession tracks what a single live client is doing on a board
+type PresenceSession struct {
+	ClientID           uuid.UUID
+	BoardID            uuid.UUID
+	PageID             *uuid.UUID
+	DisplayName        string
+	Color              string
+	SelectedElementIDs []uuid.UUID
+	Cursor             *dto.PresenceCursor
+	ReadOnly           bool
+	JoinedAt           time.Time
+	LastSeen           time.Time
+}
+
+// PresenceService keeps an in-memory roster of live sessions per board
+type PresenceService struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]map[uuid.UUID]*PresenceSession
+	liveHub  *LiveHub
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+func NewPresenceService(liveHub *LiveHub) *PresenceService {
+	ttl := defaultPresenceTTL
+	if value := os.Getenv("PRESENCE_TTL_SECONDS"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			ttl = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return &PresenceService{
+		sessions: make(map[uuid.UUID]map[uuid.UUID]*PresenceSession),
+		liveHub:  liveHub,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Join adds a live client to its board's roster
+func (s *PresenceService) Join(client *LiveClient) *PresenceSession {
+	s.mu.Lock()
+	session := s.joinLocked(client.BoardID, client.ID, client.ReadOnly)
+	snapshot := *session
+	s.mu.Unlock()
+
+	s.broadcast(dto.LiveEventPresenceJoined, &snapshot, client.ID)
+	return &snapshot
+}
+
+// joinLocked creates a session while the lock is held
+func (s *PresenceService) joinLocked(boardID, clientID uuid.UUID, readOnly bool) *PresenceSession {
+	now := s.now()
+	session := &PresenceSession{
+		ClientID:           clientID,
+		BoardID:            boardID,
+		DisplayName:        "Guest",
+		Color:              presenceColors[int(clientID[0])%len(presenceColors)],
+		SelectedElementIDs: []uuid.UUID{},
+		ReadOnly:           readOnly,
+		JoinedAt:           now,
+		LastSeen:           now,
+	}
+
+	if s.sessions[boardID] == nil {
+		s.sessions[boardID] = make(map[uuid.UUID]*PresenceSession)
+	}
+	s.sessions[boardID][clientID] = session
+
+	return session
+}
+
+// Update applies a presence update, re-joining the client if its session had expired
+func (s *PresenceService) Update(client *LiveClient, update dto.PresenceUpdateRequest) *PresenceSession {
+	s.mu.Lock()
+	session, ok := s.sessions[client.BoardID][client.ID]
+	joined := !ok
+	if !ok {
+		session = s.joinLocked(client.BoardID, client.ID, client.ReadOnly)
+	}
+
+	if update.PageID != nil {
+		pageID := *update.PageID
+		if session.PageID == nil || *session.PageID != pageID {
+			// Selections and cursors do not carry over between pages
+			session.SelectedElementIDs = []uuid.UUID{}
+			session.Cursor = nil
+		}
+		session.PageID = &pageID
+	}
+	if update.DisplayName != nil {
+		session.DisplayName = *update.DisplayName
+	}
+	if update.Color != nil {
+		session.Color = *update.Color
+	}
+	if update.SelectedElementIDs != nil {
+		session.SelectedElementIDs = append([]uuid.UUID{}, update.SelectedElementIDs...)
+	}
+	if update.Cursor != nil {
+		cursor := *update.Cursor
+		session.Cursor = &cursor
+	}
+	session.LastSeen = s.now()
+
+	snapshot := *session
+	s.mu.Unlock()
+
+	eventType := dto.LiveEventPresenceUpdated
+	if joined {
+		eventType = dto.LiveEventPresenceJoined
+	}
+	s.broadcast(eventType, &snapshot, client.ID)
+
+	return &snapshot
+}
+
+// Touch marks a session as active without changing its state
+func (s *PresenceService) Touch(client *LiveClient) {
+	s.mu.Lock()
+	session, ok := s.sessions[client.BoardID][client.ID]
+	if ok {
+		session.LastSeen = s.now()
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		// The session expired while the connection stayed open
+		s.Join(client)
+	}
+}
+
+// Leave removes a client from its board's roster
+func (s *PresenceService) Leave(client *LiveClient) {
+	s.mu.Lock()
+	session, ok := s.removeLocked(client.BoardID, client.ID)
+	s.mu.Unlock()
+
+	if ok {
+		s.broadcast(dto.LiveEventPresenceLeft, session, client.ID)
+	}
+}
+
+// removeLocked deletes a session while the lock is held
+func (s *PresenceService) removeLocked(boardID, clientID uuid.UUID) (*PresenceSession, bool) {
+	sessions, ok := s.sessions[boardID]
+	if !ok {
+		return nil, false
+	}
+	session, ok := sessions[clientID]
+	if !ok {
+		return nil, false
+	}
+
+	delete(sessions, clientID)
+	if len(sessions) == 0 {
+		delete(s.sessions, boardID)
+	}
+
+	return session, true
+}
+
+// Snapshot returns the sessions on a board, optionally limited to one page, oldest first
+func (s *PresenceService) Snapshot(boardID uuid.UUID, pageID *uuid.UUID) []PresenceSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	roster := make([]PresenceSession, 0, len(s.sessions[boardID]))
+	for _, session := range s.sessions[boardID] {
+		if pageID != nil && (session.PageID == nil || *session.PageID != *pageID) {
+			continue
+		}
+		roster = append(roster, *session)
+	}
+
+	sort.Slice(roster, func(i, j int) bool {
+		return roster[i].JoinedAt.Before(roster[j].JoinedAt)
+	})
+
+	return roster
+}
+
+// ExpireIdle drops sessions that have been silent longer than the TTL and returns how many were removed
+func (s *PresenceService) ExpireIdle() int {
+	cutoff := s.now().Add(-s.ttl)
+
+	s.mu.Lock()
+	var expired []*PresenceSession
+	for boardID, sessions := range s.sessions {
+		for clientID, session := range sessions {
+			if session.LastSeen.Before(cutoff) {
+				if removed, ok := s.removeLocked(boardID, clientID); ok {
+					expired = append(expired, removed)
+				}
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, session := range expired {
+		s.broadcast(dto.LiveEventPresenceLeft, session, uuid.Nil)
+	}
+
+	return len(expired)
+}
+
+// StartJanitor periodically expires idle sessions until the returned stop function is called
+func (s *PresenceService) StartJanitor() func() {
+	ticker := time.NewTicker(s.ttl / 2)
+	stop := make(chan struct{})
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.ExpireIdle()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
+
+// broadcast pushes a presence change to the other clients of the board
+func (s *PresenceService) broadcast(eventType string, session *PresenceSession, excludeClientID uuid.UUID) {
+	if s.liveHub == nil {
+		return
+	}
+
+	response := ConvertPresenceSession(*session)
+	s.liveHub.Broadcast(dto.LiveEvent{
+		Type:    eventType,
+		BoardID: session.BoardID,
+		PageID:  session.PageID,
+		Data:    response,
+	}, excludeClientID)
+}
+
+// ConvertPresenceSession converts a session into its API representation
+func ConvertPresenceSession(session PresenceSession) dto.PresenceSessionResponse {
+	return dto.PresenceSessionResponse{
+		ClientID:           session.ClientID,
+		PageID:             session.PageID,
+		DisplayName:        session.DisplayName,
+		Color:              session.Color,
+		SelectedElementIDs: session.SelectedElementIDs,
+		Cursor:             session.Cursor,
+		ReadOnly:           session.ReadOnly,
+		JoinedAt:           session.JoinedAt,
+		LastSeen:           session.LastSeen,
+	}
+}
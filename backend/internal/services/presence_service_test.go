@@ -0,0 +1,107 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+func newTestPresenceService(hub *LiveHub, now *time.Time) *PresenceService {
+	return &PresenceService{
+		sessions: make(map[uuid.UUID]map[uuid.UUID]*PresenceSession),
+		liveHub:  hub,
+		ttl:      time.Minute,
+		now:      func() time.Time { return *now },
+	}
+}
+
+func TestPresenceUpdateAndSnapshot(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	hub := NewLiveHub()
+	service := newTestPresenceService(hub, &now)
+
+	boardID := uuid.New()
+	pageID := uuid.New()
+	alice := hub.Register(boardID, false, false)
+	bob := hub.Register(boardID, true, false)
+
+	service.Join(alice)
+	service.Join(bob)
+
+	name := "Alice"
+	service.Update(alice, dto.PresenceUpdateRequest{
+		PageID:      &pageID,
+		DisplayName: &name,
+		Cursor:      &dto.PresenceCursor{X: 10, Y: 20},
+	})
+
+	roster := service.Snapshot(boardID, nil)
+	if len(roster) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(roster))
+	}
+
+	onPage := service.Snapshot(boardID, &pageID)
+	if len(onPage) != 1 || onPage[0].DisplayName != "Alice" {
+		t.Fatalf("Expected only Alice on the page, got %+v", onPage)
+	}
+	if onPage[0].Cursor == nil || onPage[0].Cursor.X != 10 {
+		t.Errorf("Expected cursor to be recorded, got %+v", onPage[0].Cursor)
+	}
+
+	// Bob should have been told about Alice's update, Alice should not see her own
+	if !receivedEvent(bob, dto.LiveEventPresenceUpdated) {
+		t.Errorf("Expected Bob to receive %s", dto.LiveEventPresenceUpdated)
+	}
+	if receivedEvent(alice, dto.LiveEventPresenceUpdated) {
+		t.Errorf("Expected Alice not to receive her own update")
+	}
+}
+
+func TestPresenceExpireIdle(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	hub := NewLiveHub()
+	service := newTestPresenceService(hub, &now)
+
+	boardID := uuid.New()
+	idle := hub.Register(boardID, false, false)
+	active := hub.Register(boardID, false, false)
+	service.Join(idle)
+	service.Join(active)
+
+	now = now.Add(45 * time.Second)
+	service.Touch(active)
+
+	now = now.Add(30 * time.Second)
+	if expired := service.ExpireIdle(); expired != 1 {
+		t.Fatalf("Expected 1 expired session, got %d", expired)
+	}
+
+	roster := service.Snapshot(boardID, nil)
+	if len(roster) != 1 || roster[0].ClientID != active.ID {
+		t.Fatalf("Expected only the active session to remain, got %+v", roster)
+	}
+
+	if !receivedEvent(active, dto.LiveEventPresenceLeft) {
+		t.Errorf("Expected %s to be broadcast", dto.LiveEventPresenceLeft)
+	}
+}
+
+// receivedEvent drains a client's queue and reports whether an event of the given type was sent
+func receivedEvent(client *LiveClient, eventType string) bool {
+	found := false
+	for {
+		select {
+		case message := <-client.Messages():
+			var event dto.LiveEvent
+			if err := json.Unmarshal(message, &event); err == nil && event.Type == eventType {
+				found = true
+			}
+		default:
+			return found
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PublicViewWindow is how long the view grant of a view-limited public link lets its reader
+// keep loading the board's pages after opening it
+const PublicViewWindow = time.Hour
+
+// ErrInvalidPublicLink is returned when the limits of a public link are not acceptable
+var ErrInvalidPublicLink = errors.New("invalid public link")
+
+// Conditions on the limits of a board's public link, both followed by the current time. The
+// active condition holds until the link expires, the open condition while another view may be
+// counted. Reads after the view was counted are checked against the reader's view grant.
+const (
+	publicLinkActiveCondition = "(boards.public_expires_at IS NULL OR boards.public_expires_at > ?)"
+	publicLinkOpenCondition   = publicLinkActiveCondition + " AND (boards.public_max_views IS NULL OR boards.public_view_count < boards.public_max_views)"
+)
+
+// PublicLinkService manages the expiry and view limit of boards' public links
+type PublicLinkService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewPublicLinkService(db *gorm.DB) *PublicLinkService {
+	return &PublicLinkService{db: db, now: time.Now}
+}
+
+// GetPublicLink returns a board with the limits and view count of its public link
+func (s *PublicLinkService) GetPublicLink(boardID uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := s.db.Select("id", "public_token", "public_expires_at", "public_max_views", "public_view_count", "public_last_viewed_at").
+		First(&board, "id = ?", boardID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get public link: %w", err)
+	}
+
+	return &board, nil
+}
+
+// UpdatePublicLink replaces the limits of a board's public link; nil limits are removed.
+// resetViews starts the view count over, which also reopens a used up link.
+func (s *PublicLinkService) UpdatePublicLink(boardID uuid.UUID, expiresAt *time.Time, maxViews *int, resetViews bool) (*models.Board, error) {
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidPublicLink)
+	}
+
+	updates := map[string]interface{}{
+		"public_expires_at": expiresAt,
+		"public_max_views":  maxViews,
+		"updated_at":        s.now(),
+	}
+	if resetViews {
+		updates["public_view_count"] = 0
+		updates["public_last_viewed_at"] = nil
+	}
+
+	result := s.db.Model(&models.Board{}).Where("id = ?", boardID).Updates(updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update public link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, utils.ErrNotFound
+	}
+
+	return s.GetPublicLink(boardID)
+}
+
+// RecordView counts a view of a board's public link and returns the view grant that lets the
+// reader load the rest of the board, with its expiry. The count is checked and incremented in a
+// single statement, so concurrent readers cannot go past the limit. Returns utils.ErrLinkExpired
+// once the link has expired or used up its views.
+func (s *PublicLinkService) RecordView(board *models.Board) (string, time.Time, error) {
+	now := s.now()
+	result := s.db.Model(&models.Board{}).
+		Where("id = ? AND "+publicLinkOpenCondition, board.ID, now).
+		UpdateColumns(map[string]interface{}{
+			"public_view_count":     gorm.Expr("public_view_count + 1"),
+			"public_last_viewed_at": now,
+		})
+	if result.Error != nil {
+		return "", time.Time{}, fmt.Errorf("failed to record public view: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return "", time.Time{}, utils.ErrLinkExpired
+	}
+
+	expiresAt := now.Add(PublicViewWindow)
+	if board.PublicExpiresAt != nil && board.PublicExpiresAt.Before(expiresAt) {
+		expiresAt = *board.PublicExpiresAt
+	}
+
+	return IssueViewGrant(board.ID, expiresAt), expiresAt, nil
+}
+
+// IssueViewGrant signs the grant of a counted view of a board's public link, valid until expiresAt
+func IssueViewGrant(boardID uuid.UUID, expiresAt time.Time) string {
+	return issueBoardGrant("board-view", boardID, "", expiresAt)
+}
+
+// VerifyViewGrant reports whether a view grant was issued for the board and has not expired
+func VerifyViewGrant(boardID uuid.UUID, grant string, now time.Time) bool {
+	return verifyBoardGrant("board-view", boardID, "", grant, now)
+}
+
+// IsOpen reports whether the public link of a board may still be viewed
+func (s *PublicLinkService) IsOpen(board *models.Board) bool {
+	return publicLinkOpen(board, s.now())
+}
+
+// publicLinkOpen reports whether a board's public link has neither expired nor used up its views
+func publicLinkOpen(board *models.Board, now time.Time) bool {
+	if board.PublicExpiresAt != nil && !now.Before(*board.PublicExpiresAt) {
+		return false
+	}
+	return board.PublicMaxViews == nil || board.PublicViewCount < *board.PublicMaxViews
+}
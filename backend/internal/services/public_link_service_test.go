@@ -0,0 +1,109 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestPublicLinkIsOpen(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+	tenViews := 10
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		maxViews  *int
+		viewCount int
+		expected  bool
+	}{
+		{
+			name:      "Link without limits",
+			viewCount: 500,
+			expected:  true,
+		},
+		{
+			name:      "Link before its expiry",
+			expiresAt: &later,
+			expected:  true,
+		},
+		{
+			name:      "Link past its expiry",
+			expiresAt: &earlier,
+			expected:  false,
+		},
+		{
+			name:      "Link expiring now",
+			expiresAt: &now,
+			expected:  false,
+		},
+		{
+			name:      "Views left",
+			maxViews:  &tenViews,
+			viewCount: 9,
+			expected:  true,
+		},
+		{
+			name:      "Views used up",
+			maxViews:  &tenViews,
+			viewCount: 10,
+			expected:  false,
+		},
+		{
+			name:      "Views left but expired",
+			expiresAt: &earlier,
+			maxViews:  &tenViews,
+			viewCount: 1,
+			expected:  false,
+		},
+	}
+
+	service := NewPublicLinkService(nil)
+	service.now = func() time.Time { return now }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := &models.Board{
+				PublicExpiresAt: tt.expiresAt,
+				PublicMaxViews:  tt.maxViews,
+				PublicViewCount: tt.viewCount,
+			}
+			if result := service.IsOpen(board); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestVerifyViewGrant(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	boardID := uuid.New()
+	grant := IssueViewGrant(boardID, now.Add(PublicViewWindow))
+
+	tests := []struct {
+		name     string
+		boardID  uuid.UUID
+		grant    string
+		now      time.Time
+		expected bool
+	}{
+		{name: "Valid grant", boardID: boardID, grant: grant, now: now, expected: true},
+		{name: "Expired grant", boardID: boardID, grant: grant, now: now.Add(PublicViewWindow), expected: false},
+		{name: "Grant of another board", boardID: uuid.New(), grant: grant, now: now, expected: false},
+		{name: "Unlock token is not a view grant", boardID: boardID, grant: IssueUnlockToken(boardID, "", now.Add(time.Hour)), now: now, expected: false},
+		{name: "Missing grant", boardID: boardID, grant: "", now: now, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := VerifyViewGrant(tt.boardID, tt.grant, tt.now); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"junk-journal-board/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// Rate limit budgets, each with its own limit per client and per token. The lookup budget
+// counts failed lookups of boards and keyrings by a token in the URL, to slow down guessing.
+// The unlock budgets count failed passphrase attempts per client of a board and per board.
+const (
+	RateLimitCreate      = "create"
+	RateLimitMutate      = "mutate"
+	RateLimitUpload      = "upload"
+	RateLimitRead        = "read"
+	RateLimitLookup      = "lookup"
+	RateLimitUnlock      = "unlock"
+	RateLimitUnlockBoard = "unlock_board"
+)
+
+// Rate limit backends selected with RATE_LIMIT_BACKEND
+const (
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+	RateLimitBackendOff      = "off"
+)
+
+// defaultRateLimits are used for budgets without a RATE_LIMIT_<BUDGET> setting
+var defaultRateLimits = map[string]RateLimit{
+	RateLimitCreate:      {Limit: 20, Window: time.Hour},
+	RateLimitMutate:      {Limit: 300, Window: time.Minute},
+	RateLimitUpload:      {Limit: 30, Window: time.Minute},
+	RateLimitRead:        {Limit: 600, Window: time.Minute},
+	RateLimitLookup:      {Limit: 20, Window: 15 * time.Minute},
+	RateLimitUnlock:      {Limit: 5, Window: 15 * time.Minute},
+	RateLimitUnlockBoard: {Limit: 50, Window: 15 * time.Minute},
+}
+
+// rateLimitPruneInterval is how often ended windows are removed from the store
+const rateLimitPruneInterval = time.Minute
+
+// RateLimit allows Limit requests per Window
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRateLimit parses a rate limit written as "<requests>/<window>", e.g. "20/1h"
+func ParseRateLimit(value string) (RateLimit, error) {
+	count, window, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("rate limit %q must look like 20/1h", value)
+	}
+
+	limit, err := strconv.Atoi(count)
+	if err != nil || limit < 1 {
+		return RateLimit{}, fmt.Errorf("rate limit %q must allow at least one request", value)
+	}
+
+	duration, err := time.ParseDuration(window)
+	if err != nil || duration < time.Second {
+		return RateLimit{}, fmt.Errorf("rate limit %q must have a window of at least 1s", value)
+	}
+
+	return RateLimit{Limit: limit, Window: duration}, nil
+}
+
+// RateLimitResult is the outcome of counting a request against a budget
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // until the window of the most restrictive key ends
+}
+
+// RateLimitStore counts requests per key in fixed windows
+type RateLimitStore interface {
+	// Hit counts a request for the key and returns the count in the current window and when
+	// that window ends. A window of the given length starts when the previous one has ended.
+	Hit(key string, window time.Duration, now time.Time) (count int, resetAt time.Time, err error)
+	// Count returns the count of the key's current window without counting a request; the
+	// count is zero when there is no current window
+	Count(key string, now time.Time) (count int, resetAt time.Time, err error)
+	// Clear removes the window of a key
+	Clear(key string) error
+	// Prune removes windows that have ended
+	Prune(now time.Time) error
+}
+
+// RateLimiter counts requests against per-budget limits
+type RateLimiter struct {
+	store  RateLimitStore
+	limits map[string]RateLimit
+	now    func() time.Time
+}
+
+func NewRateLimiter(store RateLimitStore, limits map[string]RateLimit) *RateLimiter {
+	return &RateLimiter{
+		store:  store,
+		limits: limits,
+		now:    time.Now,
+	}
+}
+
+// NewRateLimiterFromEnv configures a rate limiter from RATE_LIMIT_BACKEND and the
+// RATE_LIMIT_<BUDGET> settings. It returns nil when rate limiting is turned off.
+func NewRateLimiterFromEnv(db *gorm.DB) (*RateLimiter, error) {
+	var store RateLimitStore
+	switch backend := os.Getenv("RATE_LIMIT_BACKEND"); backend {
+	case "", RateLimitBackendMemory:
+		store = NewMemoryRateLimitStore()
+	case RateLimitBackendPostgres:
+		store = NewPostgresRateLimitStore(db)
+	case RateLimitBackendOff:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_BACKEND %q", backend)
+	}
+
+	limits := make(map[string]RateLimit, len(defaultRateLimits))
+	for budget, limit := range defaultRateLimits {
+		limits[budget] = limit
+		if value := os.Getenv("RATE_LIMIT_" + strings.ToUpper(budget)); value != "" {
+			parsed, err := ParseRateLimit(value)
+			if err != nil {
+				return nil, err
+			}
+			limits[budget] = parsed
+		}
+	}
+
+	return NewRateLimiter(store, limits), nil
+}
+
+// Allow counts a request against a budget once for each key, e.g. the client IP and the board
+// token. The request is allowed while no key is over the limit; the result describes the key
+// closest to it.
+func (l *RateLimiter) Allow(budget string, keys ...string) (RateLimitResult, error) {
+	limit, ok := l.limits[budget]
+	if !ok {
+		return RateLimitResult{}, fmt.Errorf("unknown rate limit budget %q", budget)
+	}
+
+	now := l.now()
+	result := RateLimitResult{Allowed: true, Limit: limit.Limit, Remaining: limit.Limit}
+	for _, key := range keys {
+		count, resetAt, err := l.store.Hit(budget+":"+key, limit.Window, now)
+		if err != nil {
+			return RateLimitResult{}, err
+		}
+
+		reset := resetAt.Sub(now)
+		remaining := limit.Limit - count
+		if count > limit.Limit {
+			// Retry once every exceeded key has a fresh window
+			if result.Allowed || reset > result.Reset {
+				result.Reset = reset
+			}
+			result.Allowed = false
+			result.Remaining = 0
+			continue
+		}
+		if result.Allowed && (remaining < result.Remaining || result.Reset == 0) {
+			result.Remaining = remaining
+			result.Reset = reset
+		}
+	}
+
+	return result, nil
+}
+
+// Check reports whether a key may make another request against a budget without counting one,
+// for budgets that only count some requests, e.g. failed ones
+func (l *RateLimiter) Check(budget, key string) (RateLimitResult, error) {
+	limit, ok := l.limits[budget]
+	if !ok {
+		return RateLimitResult{}, fmt.Errorf("unknown rate limit budget %q", budget)
+	}
+
+	now := l.now()
+	count, resetAt, err := l.store.Count(budget+":"+key, now)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	result := RateLimitResult{Allowed: count < limit.Limit, Limit: limit.Limit, Remaining: limit.Limit - count}
+	if count > 0 {
+		result.Reset = resetAt.Sub(now)
+	}
+	if !result.Allowed {
+		result.Remaining = 0
+	}
+
+	return result, nil
+}
+
+// Pace spaces the requests of a key evenly over a budget's window, one every Window/Limit, and
+// returns how long to wait before the next one. A request that may go ahead is counted and gets zero.
+func (l *RateLimiter) Pace(budget, key string) (time.Duration, error) {
+	limit, ok := l.limits[budget]
+	if !ok {
+		return 0, fmt.Errorf("unknown rate limit budget %q", budget)
+	}
+
+	now := l.now()
+	count, resetAt, err := l.store.Hit(budget+":pace:"+key, limit.Window/time.Duration(limit.Limit), now)
+	if err != nil {
+		return 0, err
+	}
+	if count > 1 {
+		return resetAt.Sub(now), nil
+	}
+
+	return 0, nil
+}
+
+// Reset forgets the counted requests of a key against a budget, e.g. after a successful attempt
+func (l *RateLimiter) Reset(budget, key string) error {
+	return l.store.Clear(budget + ":" + key)
+}
+
+// StartJanitor periodically removes ended windows until the returned stop function is called
+func (l *RateLimiter) StartJanitor(logger *utils.Logger) func() {
+	ticker := time.NewTicker(rateLimitPruneInterval)
+	stop := make(chan struct{})
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := l.store.Prune(l.now()); err != nil {
+					logger.Errorw("Failed to prune rate limit windows", "error", err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
+
+// MemoryRateLimitStore keeps rate limit windows in process memory, for single-instance deployments
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	windows map[string]*rateLimitWindow
+}
+
+// rateLimitWindow holds the requests of one key until the window ends
+type rateLimitWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{windows: make(map[string]*rateLimitWindow)}
+}
+
+func (s *MemoryRateLimitStore) Hit(key string, window time.Duration, now time.Time) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.windows[key]
+	if !ok || !now.Before(entry.resetAt) {
+		entry = &rateLimitWindow{resetAt: now.Add(window)}
+		s.windows[key] = entry
+	}
+	entry.count++
+
+	return entry.count, entry.resetAt, nil
+}
+
+func (s *MemoryRateLimitStore) Count(key string, now time.Time) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.windows[key]
+	if !ok || !now.Before(entry.resetAt) {
+		return 0, time.Time{}, nil
+	}
+
+	return entry.count, entry.resetAt, nil
+}
+
+func (s *MemoryRateLimitStore) Clear(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.windows, key)
+	return nil
+}
+
+func (s *MemoryRateLimitStore) Prune(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, entry := range s.windows {
+		if !now.Before(entry.resetAt) {
+			delete(s.windows, key)
+		}
+	}
+	return nil
+}
+
+// PostgresRateLimitStore keeps rate limit windows in the database, so that all instances of a
+// multi-instance deployment share them
+type PostgresRateLimitStore struct {
+	db *gorm.DB
+}
+
+func NewPostgresRateLimitStore(db *gorm.DB) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{db: db}
+}
+
+// hitRateLimitSQL counts a request in a single statement, starting a new window when the
+// stored one has ended, so concurrent instances cannot lose counts
+const hitRateLimitSQL = `INSERT INTO rate_limit_windows (key, count, reset_at) VALUES (?, 1, ?)
+ON CONFLICT (key) DO UPDATE SET
+	count = CASE WHEN rate_limit_windows.reset_at <= ? THEN 1 ELSE rate_limit_windows.count + 1 END,
+	reset_at = CASE WHEN rate_limit_windows.reset_at <= ? THEN EXCLUDED.reset_at ELSE rate_limit_windows.reset_at END
+RETURNING count, reset_at`
+
+func (s *PostgresRateLimitStore) Hit(key string, window time.Duration, now time.Time) (int, time.Time, error) {
+	var row struct {
+		Count   int
+		ResetAt time.Time
+	}
+	if err := s.db.Raw(hitRateLimitSQL, key, now.Add(window), now, now).Scan(&row).Error; err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to count request: %w", err)
+	}
+
+	return row.Count, row.ResetAt, nil
+}
+
+func (s *PostgresRateLimitStore) Count(key string, now time.Time) (int, time.Time, error) {
+	var row struct {
+		Count   int
+		ResetAt time.Time
+	}
+	err := s.db.Raw("SELECT count, reset_at FROM rate_limit_windows WHERE key = ? AND reset_at > ?", key, now).
+		Scan(&row).Error
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to read request count: %w", err)
+	}
+
+	return row.Count, row.ResetAt, nil
+}
+
+func (s *PostgresRateLimitStore) Clear(key string) error {
+	if err := s.db.Exec("DELETE FROM rate_limit_windows WHERE key = ?", key).Error; err != nil {
+		return fmt.Errorf("failed to clear rate limit window: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresRateLimitStore) Prune(now time.Time) error {
+	if err := s.db.Exec("DELETE FROM rate_limit_windows WHERE reset_at <= ?", now).Error; err != nil {
+		return fmt.Errorf("failed to prune rate limit windows: %w", err)
+	}
+	return nil
+}
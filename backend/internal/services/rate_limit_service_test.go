@@ -0,0 +1,195 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected RateLimit
+		wantErr  bool
+	}{
+		{
+			name:     "Requests per hour",
+			value:    "20/1h",
+			expected: RateLimit{Limit: 20, Window: time.Hour},
+		},
+		{
+			name:     "Surrounding spaces",
+			value:    " 600/1m ",
+			expected: RateLimit{Limit: 600, Window: time.Minute},
+		},
+		{
+			name:    "Missing window",
+			value:   "20",
+			wantErr: true,
+		},
+		{
+			name:    "Zero requests",
+			value:   "0/1m",
+			wantErr: true,
+		},
+		{
+			name:    "Window below a second",
+			value:   "20/10ms",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid window",
+			value:   "20/hour",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseRateLimit(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(NewMemoryRateLimitStore(), map[string]RateLimit{
+		RateLimitCreate: {Limit: 2, Window: time.Minute},
+	})
+	limiter.now = func() time.Time { return now }
+
+	result, err := limiter.Allow(RateLimitCreate, "ip:1", "token:a")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Allowed || result.Limit != 2 || result.Remaining != 1 || result.Reset != time.Minute {
+		t.Fatalf("Unexpected first result: %+v", result)
+	}
+
+	// The token is used from another IP, so only the token is at its limit
+	now = now.Add(20 * time.Second)
+	if result, _ = limiter.Allow(RateLimitCreate, "ip:2", "token:a"); !result.Allowed || result.Remaining != 0 {
+		t.Fatalf("Expected the token's last request to be allowed, got %+v", result)
+	}
+
+	now = now.Add(10 * time.Second)
+	result, _ = limiter.Allow(RateLimitCreate, "ip:3", "token:a")
+	if result.Allowed || result.Remaining != 0 || result.Reset != 30*time.Second {
+		t.Fatalf("Expected the token to be throttled until its window ends, got %+v", result)
+	}
+
+	// Budgets are configured up front
+	if _, err := limiter.Allow(RateLimitRead, "ip:1"); err == nil {
+		t.Fatal("Expected an error for a budget without a limit")
+	}
+
+	// A new window starts once the previous one has ended
+	now = now.Add(30 * time.Second)
+	if result, _ = limiter.Allow(RateLimitCreate, "ip:4", "token:a"); !result.Allowed || result.Remaining != 1 {
+		t.Fatalf("Expected a fresh window, got %+v", result)
+	}
+}
+
+func TestMemoryRateLimitStorePrune(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryRateLimitStore()
+	store.Hit("short", time.Second, now)
+	store.Hit("long", time.Hour, now)
+
+	store.Prune(now.Add(time.Minute))
+
+	if _, ok := store.windows["short"]; ok {
+		t.Error("Expected the ended window to be pruned")
+	}
+	if _, ok := store.windows["long"]; !ok {
+		t.Error("Expected the current window to be kept")
+	}
+}
+
+func TestRateLimiterCheck(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(NewMemoryRateLimitStore(), map[string]RateLimit{
+		RateLimitLookup: {Limit: 2, Window: time.Minute},
+	})
+	limiter.now = func() time.Time { return now }
+
+	result, err := limiter.Check(RateLimitLookup, "ip:1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Allowed || result.Remaining != 2 || result.Reset != 0 {
+		t.Fatalf("Expected a key without failures to be allowed, got %+v", result)
+	}
+
+	// Checking does not count a request
+	if result, _ = limiter.Check(RateLimitLookup, "ip:1"); result.Remaining != 2 {
+		t.Fatalf("Expected checks not to count, got %+v", result)
+	}
+
+	limiter.Allow(RateLimitLookup, "ip:1")
+	limiter.Allow(RateLimitLookup, "ip:1")
+	now = now.Add(15 * time.Second)
+	result, _ = limiter.Check(RateLimitLookup, "ip:1")
+	if result.Allowed || result.Remaining != 0 || result.Reset != 45*time.Second {
+		t.Fatalf("Expected the key to be throttled until its window ends, got %+v", result)
+	}
+
+	// Other keys keep their own budget
+	if result, _ = limiter.Check(RateLimitLookup, "ip:2"); !result.Allowed {
+		t.Fatalf("Expected another key to be allowed, got %+v", result)
+	}
+
+	now = now.Add(45 * time.Second)
+	if result, _ = limiter.Check(RateLimitLookup, "ip:1"); !result.Allowed || result.Remaining != 2 {
+		t.Fatalf("Expected the key to be allowed once its window ended, got %+v", result)
+	}
+}
+
+func TestRateLimiterPace(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(NewMemoryRateLimitStore(), map[string]RateLimit{
+		RateLimitUnlockBoard: {Limit: 4, Window: time.Minute},
+	})
+	limiter.now = func() time.Time { return now }
+
+	pace := func(key string) time.Duration {
+		t.Helper()
+		wait, err := limiter.Pace(RateLimitUnlockBoard, key)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return wait
+	}
+
+	if wait := pace("board"); wait != 0 {
+		t.Fatalf("Expected the first request to go ahead, got %v", wait)
+	}
+	now = now.Add(5 * time.Second)
+	if wait := pace("board"); wait != 10*time.Second {
+		t.Fatalf("Expected to wait for the next slot, got %v", wait)
+	}
+	if wait := pace("other"); wait != 0 {
+		t.Fatalf("Expected other keys to be paced separately, got %v", wait)
+	}
+
+	now = now.Add(10 * time.Second)
+	if wait := pace("board"); wait != 0 {
+		t.Fatalf("Expected the next slot to go ahead, got %v", wait)
+	}
+
+	if _, err := limiter.Pace("unknown", "board"); err == nil {
+		t.Error("Expected an error for an unknown budget")
+	}
+}
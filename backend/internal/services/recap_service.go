@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"junk-journal-board/internal/dto"
@@ -12,62 +13,143 @@ import (
 )
 
 type RecapService struct {
-	db *gorm.DB
+	db         *gorm.DB
+	tagService *TagService
 }
 
 func NewRecapService(db *gorm.DB) *RecapService {
-	return &RecapService{db: db}
+	return &RecapService{
+		db:         db,
+		tagService: NewTagService(db),
+	}
 }
 
-// GetRecapData retrieves recap data for a board with date filtering
-func (s *RecapService) GetRecapData(boardID uuid.UUID, filter string, date *time.Time) (*dto.RecapResponse, error) {
+// GetRecapData retrieves recap data for a board with date filtering. When tag names are given
+// only pages carrying one of them, on the page or on one of its elements, are included. With a
+// pinned snapshot the recap covers the snapshot instead of the live board.
+func (s *RecapService) GetRecapData(boardID uuid.UUID, filter string, date *time.Time, tagNames []string, pinned *models.Board) (*dto.RecapResponse, error) {
 	// Calculate date range based on filter
 	startDate, endDate := s.calculateDateRange(filter, date)
 
+	var tagged map[uuid.UUID]bool
+	if len(tagNames) > 0 {
+		var err error
+		if tagged, err = s.tagService.PageIDsWithTags(boardID, tagNames, pinned); err != nil {
+			return nil, err
+		}
+	}
+
+	var pageMetadata []dto.RecapPageMetadata
+	if pinned != nil {
+		pageMetadata = pinnedRecapPages(pinned.Pages, startDate, endDate, tagged)
+	} else {
+		var err error
+		if pageMetadata, err = s.liveRecapPages(boardID, startDate, endDate, tagged); err != nil {
+			return nil, err
+		}
+	}
+
+	pageIDs := make([]uuid.UUID, len(pageMetadata))
+	totalElementCount := 0
+	for i, page := range pageMetadata {
+		pageIDs[i] = page.ID
+		totalElementCount += page.ElementCount
+	}
+
+	tagCounts, err := s.tagService.TagCounts(boardID, pageIDs, pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.RecapResponse{
+		Filter: filter,
+		DateRange: dto.RecapDateRange{
+			StartDate: startDate,
+			EndDate:   endDate,
+		},
+		PageCount:    len(pageMetadata),
+		ElementCount: totalElementCount,
+		Pages:        pageMetadata,
+		Tags:         tagCounts,
+	}
+
+	return response, nil
+}
+
+// liveRecapPages returns the live pages of a board within the date range, newest first, with
+// their element counts. A non-nil tagged set keeps only the pages in it.
+func (s *RecapService) liveRecapPages(boardID uuid.UUID, startDate, endDate time.Time, tagged map[uuid.UUID]bool) ([]dto.RecapPageMetadata, error) {
 	// Get pages within the date range with element counts
 	var pages []models.Page
 	query := s.db.Where("board_id = ? AND date >= ? AND date <= ?", boardID, startDate, endDate).
 		Order("date DESC, order_idx ASC")
 
+	if tagged != nil {
+		taggedIDs := make([]uuid.UUID, 0, len(tagged))
+		for pageID := range tagged {
+			taggedIDs = append(taggedIDs, pageID)
+		}
+		query = query.Where("id IN ?", taggedIDs)
+	}
+
 	if err := query.Find(&pages).Error; err != nil {
 		return nil, fmt.Errorf("failed to get pages: %w", err)
 	}
 
 	// Get element counts for each page
 	pageMetadata := make([]dto.RecapPageMetadata, len(pages))
-	totalElementCount := 0
-
 	for i, page := range pages {
 		var elementCount int64
 		if err := s.db.Model(&models.Element{}).Where("page_id = ?", page.ID).Count(&elementCount).Error; err != nil {
 			return nil, fmt.Errorf("failed to count elements for page %s: %w", page.ID, err)
 		}
 
-		pageMetadata[i] = dto.RecapPageMetadata{
-			ID:           page.ID,
-			Title:        page.Title,
-			Date:         page.Date,
-			OrderIdx:     page.OrderIdx,
-			ElementCount: int(elementCount),
-			CreatedAt:    page.CreatedAt,
-			UpdatedAt:    page.UpdatedAt,
-		}
+		pageMetadata[i] = recapPageMetadata(&page, int(elementCount))
+	}
 
-		totalElementCount += int(elementCount)
+	return pageMetadata, nil
+}
+
+// pinnedRecapPages returns the pages of a pinned snapshot within the date range in the order of
+// liveRecapPages, counting the elements stored with them
+func pinnedRecapPages(pages []models.Page, startDate, endDate time.Time, tagged map[uuid.UUID]bool) []dto.RecapPageMetadata {
+	var inRange []models.Page
+	for _, page := range pages {
+		if page.Date.Before(startDate) || page.Date.After(endDate) {
+			continue
+		}
+		if tagged != nil && !tagged[page.ID] {
+			continue
+		}
+		inRange = append(inRange, page)
 	}
 
-	response := &dto.RecapResponse{
-		Filter: filter,
-		DateRange: dto.RecapDateRange{
-			StartDate: startDate,
-			EndDate:   endDate,
-		},
-		PageCount:    len(pages),
-		ElementCount: totalElementCount,
-		Pages:        pageMetadata,
+	sort.SliceStable(inRange, func(i, j int) bool {
+		if !inRange[i].Date.Equal(inRange[j].Date) {
+			return inRange[i].Date.After(inRange[j].Date)
+		}
+		return inRange[i].OrderIdx < inRange[j].OrderIdx
+	})
+
+	pageMetadata := make([]dto.RecapPageMetadata, len(inRange))
+	for i := range inRange {
+		pageMetadata[i] = recapPageMetadata(&inRange[i], len(inRange[i].Elements))
 	}
 
-	return response, nil
+	return pageMetadata
+}
+
+// recapPageMetadata describes a page of a recap
+func recapPageMetadata(page *models.Page, elementCount int) dto.RecapPageMetadata {
+	return dto.RecapPageMetadata{
+		ID:           page.ID,
+		Title:        page.Title,
+		Date:         page.Date,
+		OrderIdx:     page.OrderIdx,
+		ElementCount: elementCount,
+		CreatedAt:    page.CreatedAt,
+		UpdatedAt:    page.UpdatedAt,
+	}
 }
 
 // calculateDateRange calculates the start and end dates based on filter and reference date
@@ -3,6 +3,10 @@ package services
 import (
 	"testing"
 	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
 )
 
 func TestCalculateDateRange(t *testing.T) {
@@ -83,3 +87,36 @@ func TestCalculateDateRangeWeekWithSunday(t *testing.T) {
 		t.Errorf("Expected end date %v, got %v", expectedEnd, end)
 	}
 }
+
+func TestPinnedRecapPages(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	first := models.Page{ID: uuid.New(), Title: "Morning", Date: day, Elements: []models.Element{{ID: uuid.New()}, {ID: uuid.New()}}}
+	second := models.Page{ID: uuid.New(), Title: "Evening", Date: day, OrderIdx: 1}
+	later := models.Page{ID: uuid.New(), Title: "Next day", Date: day.AddDate(0, 0, 1), Elements: []models.Element{{ID: uuid.New()}}}
+	outside := models.Page{ID: uuid.New(), Title: "Next month", Date: day.AddDate(0, 1, 0)}
+	pages := []models.Page{second, outside, first, later}
+
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 21, 23, 59, 59, 999999999, time.UTC)
+
+	recap := pinnedRecapPages(pages, start, end, nil)
+	if len(recap) != 3 {
+		t.Fatalf("Expected 3 pages in range, got %+v", recap)
+	}
+	// Newest date first, then by order index
+	if recap[0].ID != later.ID || recap[1].ID != first.ID || recap[2].ID != second.ID {
+		t.Errorf("Expected pages in recap order, got %+v", recap)
+	}
+	if recap[0].ElementCount != 1 || recap[1].ElementCount != 2 || recap[2].ElementCount != 0 {
+		t.Errorf("Expected element counts from the snapshot, got %+v", recap)
+	}
+
+	tagged := pinnedRecapPages(pages, start, end, map[uuid.UUID]bool{second.ID: true, outside.ID: true})
+	if len(tagged) != 1 || tagged[0].ID != second.ID {
+		t.Errorf("Expected only the tagged page in range, got %+v", tagged)
+	}
+
+	if none := pinnedRecapPages(pages, start, end, map[uuid.UUID]bool{}); len(none) != 0 {
+		t.Errorf("Expected no pages without tagged ones, got %+v", none)
+	}
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Recurrence scheduler settings
+const (
+	defaultRecurrenceInterval = 5 * time.Minute
+	// maxRecurrencePagesPerRun bounds how far a rule catches up in one run; the rest follows on the next run
+	maxRecurrencePagesPerRun = 366
+)
+
+// ErrInvalidRecurrence is returned when a recurrence rule cannot be saved
+var ErrInvalidRecurrence = errors.New("invalid recurrence")
+
+type RecurrenceService struct {
+	db       *gorm.DB
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewRecurrenceService(db *gorm.DB) *RecurrenceService {
+	interval := defaultRecurrenceInterval
+	if value := os.Getenv("RECURRENCE_INTERVAL_SECONDS"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			interval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return &RecurrenceService{
+		db:       db,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// GetRecurrence retrieves the recurring page rule of a board
+func (s *RecurrenceService) GetRecurrence(boardID uuid.UUID) (*models.PageRecurrence, error) {
+	var recurrence models.PageRecurrence
+	if err := s.db.First(&recurrence, "board_id = ?", boardID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get recurrence: %w", err)
+	}
+
+	return &recurrence, nil
+}
+
+// SetRecurrence creates or replaces the recurring page rule of a board and creates any pages
+// that are already due. Occurrences on or before the last page created under the previous
+// rule are not created again.
+func (s *RecurrenceService) SetRecurrence(boardID uuid.UUID, req dto.SetRecurrenceRequest) (*models.PageRecurrence, int, error) {
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: unknown timezone %q", ErrInvalidRecurrence, timezone)
+	}
+
+	var recurrence models.PageRecurrence
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&recurrence, "board_id = ?", boardID).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return fmt.Errorf("failed to get recurrence: %w", err)
+		}
+
+		if req.TemplateID != nil {
+			if _, err := findTemplate(tx, &boardID, *req.TemplateID); err != nil {
+				if err == utils.ErrNotFound {
+					return ErrTemplateNotFound
+				}
+				return err
+			}
+		}
+
+		recurrence.BoardID = boardID
+		recurrence.Frequency = req.Frequency
+		recurrence.Interval = 1
+		if req.Interval != nil {
+			recurrence.Interval = *req.Interval
+		}
+		recurrence.TitlePattern = req.TitlePattern
+		recurrence.Timezone = timezone
+		recurrence.StartDate = localToday(s.now(), location)
+		if req.StartDate != nil {
+			recurrence.StartDate = truncateToDay(*req.StartDate)
+		}
+		recurrence.PageTemplateID = req.TemplateID
+		recurrence.Enabled = true
+		if req.Enabled != nil {
+			recurrence.Enabled = *req.Enabled
+		}
+
+		recurrence.OccurrenceCount = 0
+		if recurrence.LastOccurrenceDate != nil {
+			recurrence.OccurrenceCount = recurrenceOccurrencesThrough(&recurrence, *recurrence.LastOccurrenceDate)
+		}
+		recurrence.NextDate = recurrenceOccurrence(&recurrence, recurrence.OccurrenceCount)
+
+		// Select all columns so a disabled rule is not replaced by the column default
+		if err := tx.Select("*").Save(&recurrence).Error; err != nil {
+			return fmt.Errorf("failed to save recurrence: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	created, err := s.materialize(recurrence.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	current, err := s.GetRecurrence(boardID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return current, created, nil
+}
+
+// DeleteRecurrence removes the recurring page rule of a board; pages it created are kept
+func (s *RecurrenceService) DeleteRecurrence(boardID uuid.UUID) error {
+	result := s.db.Delete(&models.PageRecurrence{}, "board_id = ?", boardID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete recurrence: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// MaterializeDue creates the missing pages of every enabled rule that is due and returns how many were created
+func (s *RecurrenceService) MaterializeDue() (int, error) {
+	// Rules are checked against the earliest "today" on the planet; each rule applies its own timezone
+	var ids []uuid.UUID
+	err := s.db.Model(&models.PageRecurrence{}).
+		Where("enabled AND next_date <= ?", truncateToDay(s.now()).AddDate(0, 0, 1)).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due recurrences: %w", err)
+	}
+
+	total := 0
+	for _, id := range ids {
+		created, err := s.materialize(id)
+		if err != nil {
+			return total, err
+		}
+		total += created
+	}
+
+	return total, nil
+}
+
+// materialize creates the pages a rule owes up to today in its timezone. The rule row is locked
+// with SKIP LOCKED, so when several servers run the scheduler only one of them handles a rule at a
+// time, and the counters are advanced in the same transaction as the pages they account for.
+func (s *RecurrenceService) materialize(recurrenceID uuid.UUID) (int, error) {
+	created := 0
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var recurrence models.PageRecurrence
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			First(&recurrence, "id = ? AND enabled", recurrenceID).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				// Disabled, deleted or being handled by another server
+				return nil
+			}
+			return fmt.Errorf("failed to lock recurrence: %w", err)
+		}
+
+		// Boards in the trash get no new pages
+		var boardCount int64
+		if err := tx.Model(&models.Board{}).Where("id = ?", recurrence.BoardID).Count(&boardCount).Error; err != nil {
+			return fmt.Errorf("failed to check board: %w", err)
+		}
+		if boardCount == 0 {
+			return nil
+		}
+
+		location, err := time.LoadLocation(recurrence.Timezone)
+		if err != nil {
+			location = time.UTC
+		}
+
+		pageService := NewPageService(tx)
+		dates := dueRecurrenceDates(&recurrence, localToday(s.now(), location), maxRecurrencePagesPerRun)
+		for _, date := range dates {
+			title := date.Format(recurrence.TitlePattern)
+			_, err := pageService.CreatePage(recurrence.BoardID, title, date, recurrence.PageTemplateID)
+			if err == ErrTemplateNotFound {
+				// The template was deleted; keep the schedule going with blank pages
+				recurrence.PageTemplateID = nil
+				_, err = pageService.CreatePage(recurrence.BoardID, title, date, nil)
+			}
+			if err != nil {
+				return err
+			}
+
+			last := date
+			recurrence.LastOccurrenceDate = &last
+			recurrence.OccurrenceCount++
+			created++
+		}
+		if created == 0 {
+			return nil
+		}
+
+		recurrence.NextDate = recurrenceOccurrence(&recurrence, recurrence.OccurrenceCount)
+		err = tx.Model(&recurrence).Updates(map[string]interface{}{
+			"occurrence_count":     recurrence.OccurrenceCount,
+			"next_date":            recurrence.NextDate,
+			"last_occurrence_date": recurrence.LastOccurrenceDate,
+			"page_template_id":     recurrence.PageTemplateID,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to advance recurrence: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return created, nil
+}
+
+// StartScheduler periodically creates due recurring pages until the returned stop function is called
+func (s *RecurrenceService) StartScheduler(logger *utils.Logger) func() {
+	ticker := time.NewTicker(s.interval)
+	stop := make(chan struct{})
+
+	run := func() {
+		created, err := s.MaterializeDue()
+		if err != nil {
+			logger.Errorw("Failed to create recurring pages", "error", err)
+		}
+		if created > 0 {
+			logger.Infow("Created recurring pages", "pages", created)
+		}
+	}
+
+	go func() {
+		defer ticker.Stop()
+		run()
+		for {
+			select {
+			case <-ticker.C:
+				run()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
+
+// dueRecurrenceDates returns the dates of the not yet materialized occurrences up to today, at most limit
+func dueRecurrenceDates(recurrence *models.PageRecurrence, today time.Time, limit int) []time.Time {
+	var dates []time.Time
+	for n := recurrence.OccurrenceCount; len(dates) < limit; n++ {
+		date := recurrenceOccurrence(recurrence, n)
+		if date.After(today) {
+			break
+		}
+		dates = append(dates, date)
+	}
+
+	return dates
+}
+
+// recurrenceOccurrence returns the date of the nth occurrence (from zero) of a rule.
+// Monthly occurrences on days a month lacks fall on its last day.
+func recurrenceOccurrence(recurrence *models.PageRecurrence, n int) time.Time {
+	start := truncateToDay(recurrence.StartDate)
+	interval := recurrence.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch recurrence.Frequency {
+	case models.RecurrenceWeekly:
+		return start.AddDate(0, 0, 7*interval*n)
+	case models.RecurrenceMonthly:
+		firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(interval*n), 1, 0, 0, 0, 0, time.UTC)
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+		day := start.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		return firstOfMonth.AddDate(0, 0, day-1)
+	default:
+		return start.AddDate(0, 0, interval*n)
+	}
+}
+
+// recurrenceOccurrencesThrough counts the occurrences of a rule on or before a date
+func recurrenceOccurrencesThrough(recurrence *models.PageRecurrence, date time.Time) int {
+	date = truncateToDay(date)
+	n := 0
+	for !recurrenceOccurrence(recurrence, n).After(date) {
+		n++
+	}
+
+	return n
+}
+
+// localToday returns the current calendar day in a timezone as midnight UTC, the form page dates are stored in
+func localToday(now time.Time, location *time.Location) time.Time {
+	return truncateToDay(now.In(location))
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+)
+
+func TestRecurrenceOccurrence(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency string
+		interval  int
+		n         int
+		expected  string
+	}{
+		{"daily", models.RecurrenceDaily, 1, 3, "2024-02-03"},
+		{"every other day", models.RecurrenceDaily, 2, 3, "2024-02-06"},
+		{"weekly", models.RecurrenceWeekly, 1, 2, "2024-02-14"},
+		{"monthly clamps to short months", models.RecurrenceMonthly, 1, 1, "2024-02-29"},
+		{"monthly returns to the start day", models.RecurrenceMonthly, 1, 2, "2024-03-31"},
+		{"quarterly", models.RecurrenceMonthly, 3, 1, "2024-04-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence := &models.PageRecurrence{Frequency: tt.frequency, Interval: tt.interval, StartDate: start}
+			if got := recurrenceOccurrence(recurrence, tt.n).Format("2006-01-02"); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDueRecurrenceDates(t *testing.T) {
+	recurrence := &models.PageRecurrence{
+		Frequency: models.RecurrenceDaily,
+		Interval:  1,
+		StartDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	today := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	dates := dueRecurrenceDates(recurrence, today, 100)
+	if len(dates) != 5 {
+		t.Fatalf("Expected 5 due dates, got %d", len(dates))
+	}
+
+	// Already materialized occurrences are not due again
+	recurrence.OccurrenceCount = 4
+	dates = dueRecurrenceDates(recurrence, today, 100)
+	if len(dates) != 1 || !dates[0].Equal(today) {
+		t.Fatalf("Expected only today to be due, got %v", dates)
+	}
+
+	recurrence.OccurrenceCount = 0
+	if dates := dueRecurrenceDates(recurrence, today, 2); len(dates) != 2 {
+		t.Errorf("Expected the limit to cap due dates at 2, got %d", len(dates))
+	}
+
+	// A rule changed after some pages were created resumes after the last one
+	weekly := &models.PageRecurrence{
+		Frequency: models.RecurrenceWeekly,
+		Interval:  1,
+		StartDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if count := recurrenceOccurrencesThrough(weekly, today); count != 1 {
+		t.Errorf("Expected 1 weekly occurrence through %s, got %d", today.Format("2006-01-02"), count)
+	}
+}
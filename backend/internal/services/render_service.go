@@ -0,0 +1,631 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/f64"
+	"golang.org/x/image/math/fixed"
+	"gorm.io/gorm"
+)
+
+// Canvas dimensions used by the editor (see CanvasEditor.vue)
+const (
+	RenderCanvasWidth  = 800
+	RenderCanvasHeight = 600
+)
+
+// Supported render output formats
+const (
+	RenderFormatPNG = "png"
+	RenderFormatPDF = "pdf"
+)
+
+// Render limits, so that a single request cannot exhaust the server's memory
+const (
+	MaxRenderScale       = 4
+	maxRenderTileSize    = 4096       // pixels per side of an element tile
+	maxRenderImagePixels = 25_000_000 // pixels of a decoded image
+)
+
+// skinBackgrounds maps board skins to their canvas background colors
+var skinBackgrounds = map[string]color.RGBA{
+	"default":  {0xFF, 0xFF, 0xFF, 0xFF},
+	"wood":     {0xDE, 0xB8, 0x87, 0xFF},
+	"notebook": {0xFD, 0xFB, 0xF3, 0xFF},
+	"cork":     {0xC8, 0xA2, 0x6B, 0xFF},
+}
+
+// textPayload mirrors the frontend TextPayload shape
+type textPayload struct {
+	Content   string  `json:"content"`
+	FontSize  float64 `json:"fontSize"`
+	Color     string  `json:"color"`
+	Bold      bool    `json:"bold"`
+	Italic    bool    `json:"italic"`
+	TextAlign string  `json:"textAlign"`
+}
+
+// imagePayload mirrors the URL part of the frontend ImagePayload and StickerPayload shapes
+type imagePayload struct {
+	URL string `json:"url"`
+}
+
+// shapePayload mirrors the frontend ShapePayload shape
+type shapePayload struct {
+	ShapeType   string  `json:"shapeType"`
+	Fill        string  `json:"fill"`
+	Stroke      string  `json:"stroke"`
+	StrokeWidth float64 `json:"strokeWidth"`
+}
+
+type RenderService struct {
+	db            *gorm.DB
+	pageService   *PageService
+	uploadService *UploadService
+}
+
+func NewRenderService(db *gorm.DB) *RenderService {
+	return &RenderService{
+		db:            db,
+		pageService:   NewPageService(db),
+		uploadService: NewUploadService(),
+	}
+}
+
+// RenderPage rasterizes a page of a board and encodes it as PNG or PDF. With a pinned snapshot
+// the page is rendered as stored in the snapshot.
+func (s *RenderService) RenderPage(boardID, pageID uuid.UUID, format string, scale float64, pinned *models.Board) ([]byte, error) {
+	if pinned != nil {
+		for i := range pinned.Pages {
+			if pinned.Pages[i].ID == pageID {
+				return s.encodePage(boardID, pinned.Skin, pinned.Pages[i].Elements, format, scale)
+			}
+		}
+		return nil, utils.ErrNotFound
+	}
+
+	var board models.Board
+	if err := s.db.First(&board, "id = ?", boardID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+
+	page, err := s.pageService.GetPageByID(pageID)
+	if err != nil {
+		return nil, err
+	}
+	if page.BoardID != boardID {
+		return nil, utils.ErrNotFound
+	}
+
+	return s.encodePage(boardID, board.Skin, page.Elements, format, scale)
+}
+
+// encodePage rasterizes the elements of a page and encodes the image as PNG or PDF
+func (s *RenderService) encodePage(boardID uuid.UUID, skin string, elements []models.Element, format string, scale float64) ([]byte, error) {
+	img := s.RasterizeElements(boardID, skin, elements, scale)
+
+	switch format {
+	case RenderFormatPDF:
+		return encodePDF(img, RenderCanvasWidth, RenderCanvasHeight)
+	default:
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("failed to encode png: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// RasterizeElements draws elements in z order onto a canvas with the skin background. Images
+// are only drawn from the uploads of the given board.
+func (s *RenderService) RasterizeElements(boardID uuid.UUID, skin string, elements []models.Element, scale float64) *image.RGBA {
+	if !(scale > 0) {
+		scale = 1
+	}
+	if scale > MaxRenderScale {
+		scale = MaxRenderScale
+	}
+
+	width := int(math.Ceil(RenderCanvasWidth * scale))
+	height := int(math.Ceil(RenderCanvasHeight * scale))
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	drawSkin(canvas, skin, scale)
+
+	images := make(map[string]image.Image)
+	for _, element := range sortElementsByZ(elements) {
+		if !element.Visible {
+			continue
+		}
+
+		tile := s.renderElement(boardID, element, scale, images)
+		if tile == nil {
+			continue
+		}
+
+		drawTransformed(canvas, tile,
+			element.X*scale, element.Y*scale,
+			element.W*scale, element.H*scale,
+			element.Rotation)
+	}
+
+	return canvas
+}
+
+// renderElement produces the unrotated image for a single element, or nil if nothing should be drawn.
+// Elements larger than a tile are rendered at a lower scale and stretched when drawn.
+func (s *RenderService) renderElement(boardID uuid.UUID, element models.Element, scale float64, images map[string]image.Image) image.Image {
+	if !validRenderSize(element.W) || !validRenderSize(element.H) {
+		return nil
+	}
+	scale = math.Min(scale, math.Min(maxRenderTileSize/element.W, maxRenderTileSize/element.H))
+
+	width := int(math.Ceil(element.W * scale))
+	height := int(math.Ceil(element.H * scale))
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	switch element.Kind {
+	case "text":
+		var payload textPayload
+		if err := json.Unmarshal(element.Payload, &payload); err != nil {
+			return nil
+		}
+		return renderText(payload, width, height, scale)
+
+	case "shape":
+		var payload shapePayload
+		if err := json.Unmarshal(element.Payload, &payload); err != nil {
+			return nil
+		}
+		return renderShape(payload, width, height, scale)
+
+	case "image", "sticker":
+		var payload imagePayload
+		if err := json.Unmarshal(element.Payload, &payload); err != nil || payload.URL == "" {
+			return nil
+		}
+		if img, ok := images[payload.URL]; ok {
+			return img
+		}
+		// Missing or unreachable images are skipped rather than failing the whole render
+		img, err := s.loadImage(boardID, payload.URL)
+		if err != nil {
+			images[payload.URL] = nil
+			return nil
+		}
+		images[payload.URL] = img
+		return img
+	}
+
+	return nil
+}
+
+// loadImage loads an image uploaded to the board. Other URLs are never fetched, so that
+// rendering cannot be used to reach the server's network.
+func (s *RenderService) loadImage(boardID uuid.UUID, rawURL string) (image.Image, error) {
+	localPath, ok := s.uploadService.ResolveLocalPath(rawURL)
+	if !ok || filepath.Dir(localPath) != s.uploadService.GetBoardUploadDir(boardID) {
+		return nil, fmt.Errorf("image is not an upload of the board: %s", rawURL)
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer file.Close()
+
+	// Check the dimensions before decoding, a small file can describe a huge image
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxRenderImagePixels {
+		return nil, fmt.Errorf("image is too large: %dx%d", config.Width, config.Height)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	return img, nil
+}
+
+// validRenderSize reports whether an element dimension is a positive finite number
+func validRenderSize(value float64) bool {
+	return value > 0 && !math.IsInf(value, 1)
+}
+
+// sortElementsByZ returns a copy of the elements ordered by ascending z index
+func sortElementsByZ(elements []models.Element) []models.Element {
+	sorted := make([]models.Element, len(elements))
+	copy(sorted, elements)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Z < sorted[j].Z
+	})
+	return sorted
+}
+
+// drawSkin fills the canvas with the board skin background
+func drawSkin(canvas *image.RGBA, skin string, scale float64) {
+	background, ok := skinBackgrounds[skin]
+	if !ok {
+		background = skinBackgrounds["default"]
+	}
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	if skin == "notebook" {
+		// Ruled lines every 24 canvas units
+		line := color.RGBA{0xC7, 0xD8, 0xEE, 0xFF}
+		spacing := 24 * scale
+		for y := spacing; y < float64(canvas.Bounds().Dy()); y += spacing {
+			row := int(y)
+			for x := 0; x < canvas.Bounds().Dx(); x++ {
+				canvas.SetRGBA(x, row, line)
+			}
+		}
+	}
+}
+
+// drawTransformed draws src into the box (x, y, w, h) of dst, rotated by degrees around the box center
+func drawTransformed(dst draw.Image, src image.Image, x, y, w, h, degrees float64) {
+	bounds := src.Bounds()
+	srcW := float64(bounds.Dx())
+	srcH := float64(bounds.Dy())
+	if srcW == 0 || srcH == 0 {
+		return
+	}
+
+	sx := w / srcW
+	sy := h / srcH
+	rad := degrees * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	cx, cy := x+w/2, y+h/2
+
+	// Scale to the target box, move the center to the origin, rotate and translate back
+	matrix := f64.Aff3{
+		sx * cos, -sy * sin, cx - (w/2)*cos + (h/2)*sin - float64(bounds.Min.X)*sx*cos + float64(bounds.Min.Y)*sy*sin,
+		sx * sin, sy * cos, cy - (w/2)*sin - (h/2)*cos - float64(bounds.Min.X)*sx*sin - float64(bounds.Min.Y)*sy*cos,
+	}
+
+	draw.BiLinear.Transform(dst, matrix, src, bounds, draw.Over, nil)
+}
+
+// renderShape draws a rectangle, circle or triangle with fill and stroke
+func renderShape(payload shapePayload, width, height int, scale float64) image.Image {
+	tile := image.NewNRGBA(image.Rect(0, 0, width, height))
+	fill, hasFill := parseCSSColor(payload.Fill)
+	stroke, hasStroke := parseCSSColor(payload.Stroke)
+	strokeWidth := payload.StrokeWidth * scale
+	if strokeWidth <= 0 {
+		hasStroke = false
+	}
+
+	w, h := float64(width), float64(height)
+	for py := 0; py < height; py++ {
+		for px := 0; px < width; px++ {
+			distance := shapeEdgeDistance(payload.ShapeType, float64(px)+0.5, float64(py)+0.5, w, h)
+			if distance < 0 {
+				continue
+			}
+			if hasStroke && distance < strokeWidth {
+				tile.SetNRGBA(px, py, stroke)
+			} else if hasFill {
+				tile.SetNRGBA(px, py, fill)
+			}
+		}
+	}
+
+	return tile
+}
+
+// shapeEdgeDistance returns the distance from (x, y) to the shape outline, negative when outside
+func shapeEdgeDistance(shapeType string, x, y, w, h float64) float64 {
+	switch shapeType {
+	case "circle":
+		rx, ry := w/2, h/2
+		dx, dy := (x-rx)/rx, (y-ry)/ry
+		norm := math.Sqrt(dx*dx + dy*dy)
+		// Approximate distance along the radius for ellipses
+		return (1 - norm) * math.Min(rx, ry)
+
+	case "triangle":
+		// Apex at top center, base along the bottom edge
+		ax, ay := w/2, 0.0
+		bx, by := w, h
+		cx, cy := 0.0, h
+		d1 := lineDistance(ax, ay, bx, by, x, y)
+		d2 := lineDistance(bx, by, cx, cy, x, y)
+		d3 := lineDistance(cx, cy, ax, ay, x, y)
+		return math.Min(d1, math.Min(d2, d3))
+
+	default:
+		return math.Min(math.Min(x, y), math.Min(w-x, h-y))
+	}
+}
+
+// lineDistance returns the signed distance from (x, y) to the line through a and b,
+// positive on the right-hand side of the direction a -> b in screen coordinates
+func lineDistance(ax, ay, bx, by, x, y float64) float64 {
+	dx, dy := bx-ax, by-ay
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return 0
+	}
+	return (dx*(y-ay) - dy*(x-ax)) / length
+}
+
+var (
+	renderFontsOnce sync.Once
+	renderFonts     map[string]*opentype.Font
+)
+
+// loadRenderFonts parses the embedded Go fonts once
+func loadRenderFonts() map[string]*opentype.Font {
+	renderFontsOnce.Do(func() {
+		renderFonts = make(map[string]*opentype.Font)
+		sources := map[string][]byte{
+			"regular":     goregular.TTF,
+			"bold":        gobold.TTF,
+			"italic":      goitalic.TTF,
+			"bold-italic": gobolditalic.TTF,
+		}
+		for name, data := range sources {
+			if parsed, err := opentype.Parse(data); err == nil {
+				renderFonts[name] = parsed
+			}
+		}
+	})
+	return renderFonts
+}
+
+// renderText draws wrapped and aligned text into a box
+func renderText(payload textPayload, width, height int, scale float64) image.Image {
+	tile := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if payload.Content == "" {
+		return tile
+	}
+
+	fontSize := payload.FontSize
+	if fontSize <= 0 {
+		fontSize = 16
+	}
+	textColor, ok := parseCSSColor(payload.Color)
+	if !ok {
+		textColor = color.NRGBA{0, 0, 0, 0xFF}
+	}
+
+	style := "regular"
+	switch {
+	case payload.Bold && payload.Italic:
+		style = "bold-italic"
+	case payload.Bold:
+		style = "bold"
+	case payload.Italic:
+		style = "italic"
+	}
+
+	fonts := loadRenderFonts()
+	parsed, ok := fonts[style]
+	if !ok {
+		return tile
+	}
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{
+		Size:    fontSize * scale,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return tile
+	}
+	defer face.Close()
+
+	drawer := &font.Drawer{
+		Dst:  tile,
+		Src:  image.NewUniform(textColor),
+		Face: face,
+	}
+
+	metrics := face.Metrics()
+	lineHeight := fontSize * scale * 1.2
+	baseline := float64(metrics.Ascent.Ceil())
+
+	for _, line := range wrapText(drawer, payload.Content, width) {
+		if baseline-float64(metrics.Ascent.Ceil()) > float64(height) {
+			break
+		}
+
+		lineWidth := float64(drawer.MeasureString(line).Ceil())
+		x := 0.0
+		switch payload.TextAlign {
+		case "center":
+			x = (float64(width) - lineWidth) / 2
+		case "right":
+			x = float64(width) - lineWidth
+		}
+
+		drawer.Dot = fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(baseline * 64)}
+		drawer.DrawString(line)
+		baseline += lineHeight
+	}
+
+	return tile
+}
+
+// wrapText splits text on newlines and wraps words that exceed the available width
+func wrapText(drawer *font.Drawer, text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		current := words[0]
+		for _, word := range words[1:] {
+			candidate := current + " " + word
+			if drawer.MeasureString(candidate).Ceil() > width {
+				lines = append(lines, current)
+				current = word
+			} else {
+				current = candidate
+			}
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// parseCSSColor parses #rgb, #rrggbb, #rrggbbaa, rgb() and rgba() colors
+func parseCSSColor(value string) (color.NRGBA, bool) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" || value == "transparent" || value == "none" {
+		return color.NRGBA{}, false
+	}
+
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) == 6 {
+			hex += "ff"
+		}
+		if len(hex) != 8 {
+			return color.NRGBA{}, false
+		}
+		parsed, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return color.NRGBA{}, false
+		}
+		return color.NRGBA{
+			R: uint8(parsed >> 24),
+			G: uint8(parsed >> 16),
+			B: uint8(parsed >> 8),
+			A: uint8(parsed),
+		}, true
+	}
+
+	if strings.HasPrefix(value, "rgb") {
+		start := strings.Index(value, "(")
+		end := strings.LastIndex(value, ")")
+		if start < 0 || end <= start {
+			return color.NRGBA{}, false
+		}
+		parts := strings.Split(value[start+1:end], ",")
+		if len(parts) < 3 {
+			return color.NRGBA{}, false
+		}
+		var channels [3]uint8
+		for i := 0; i < 3; i++ {
+			channel, err := strconv.ParseFloat(strings.TrimSpace(parts[i]), 64)
+			if err != nil {
+				return color.NRGBA{}, false
+			}
+			channels[i] = uint8(math.Max(0, math.Min(255, channel)))
+		}
+		alpha := uint8(0xFF)
+		if len(parts) > 3 {
+			a, err := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+			if err != nil {
+				return color.NRGBA{}, false
+			}
+			alpha = uint8(math.Max(0, math.Min(1, a)) * 255)
+		}
+		return color.NRGBA{R: channels[0], G: channels[1], B: channels[2], A: alpha}, true
+	}
+
+	return color.NRGBA{}, false
+}
+
+// encodePDF wraps a raster image into a single-page PDF document sized in points
+func encodePDF(img *image.RGBA, widthPt, heightPt float64) ([]byte, error) {
+	bounds := img.Bounds()
+
+	// Flatten to RGB and compress the image stream
+	var raw bytes.Buffer
+	compressor := zlib.NewWriter(&raw)
+	row := make([]byte, bounds.Dx()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := img.RGBAAt(x, y)
+			i := (x - bounds.Min.X) * 3
+			row[i], row[i+1], row[i+2] = pixel.R, pixel.G, pixel.B
+		}
+		if _, err := compressor.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to compress pdf image: %w", err)
+		}
+	}
+	if err := compressor.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress pdf image: %w", err)
+	}
+
+	content := fmt.Sprintf("q %s 0 0 %s 0 0 cm /Im0 Do Q", formatPDFNumber(widthPt), formatPDFNumber(heightPt))
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /XObject << /Im0 4 0 R >> >> /Contents 5 0 R >>",
+			formatPDFNumber(widthPt), formatPDFNumber(heightPt)),
+		fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+			bounds.Dx(), bounds.Dy(), raw.Len(), raw.String()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return out.Bytes(), nil
+}
+
+// formatPDFNumber formats a float without trailing zeros for PDF operators
+func formatPDFNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
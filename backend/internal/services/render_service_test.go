@@ -0,0 +1,199 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func TestParseCSSColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected color.NRGBA
+		ok       bool
+	}{
+		{name: "Short hex", value: "#f00", expected: color.NRGBA{0xFF, 0, 0, 0xFF}, ok: true},
+		{name: "Long hex", value: "#2563EB", expected: color.NRGBA{0x25, 0x63, 0xEB, 0xFF}, ok: true},
+		{name: "Hex with alpha", value: "#00000080", expected: color.NRGBA{0, 0, 0, 0x80}, ok: true},
+		{name: "RGBA", value: "rgba(10, 20, 30, 0.5)", expected: color.NRGBA{10, 20, 30, 127}, ok: true},
+		{name: "Transparent", value: "transparent", ok: false},
+		{name: "Invalid", value: "#zzz", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, ok := parseCSSColor(tt.value)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && parsed != tt.expected {
+				t.Errorf("Expected color %v, got %v", tt.expected, parsed)
+			}
+		})
+	}
+}
+
+func TestRasterizeElementsRespectsVisibilityAndZOrder(t *testing.T) {
+	service := &RenderService{}
+
+	red := datatypes.JSON(`{"shapeType":"rectangle","fill":"#ff0000"}`)
+	blue := datatypes.JSON(`{"shapeType":"rectangle","fill":"#0000ff"}`)
+	green := datatypes.JSON(`{"shapeType":"rectangle","fill":"#00ff00"}`)
+
+	elements := []models.Element{
+		{Kind: "shape", X: 100, Y: 100, W: 100, H: 100, Z: 2, Visible: true, Payload: blue},
+		{Kind: "shape", X: 100, Y: 100, W: 100, H: 100, Z: 1, Visible: true, Payload: red},
+		{Kind: "shape", X: 100, Y: 100, W: 100, H: 100, Z: 3, Visible: false, Payload: green},
+	}
+
+	img := service.RasterizeElements(uuid.New(), "default", elements, 1)
+
+	if got := img.RGBAAt(150, 150); got != (color.RGBA{0, 0, 0xFF, 0xFF}) {
+		t.Errorf("Expected top visible element to be blue, got %v", got)
+	}
+
+	if got := img.RGBAAt(10, 10); got != (color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Expected default skin background, got %v", got)
+	}
+}
+
+func TestRasterizeElementsAppliesRotation(t *testing.T) {
+	service := &RenderService{}
+
+	// A wide bar rotated 90 degrees becomes a tall bar around the same center
+	elements := []models.Element{
+		{Kind: "shape", X: 300, Y: 290, W: 200, H: 20, Rotation: 90, Visible: true,
+			Payload: datatypes.JSON(`{"shapeType":"rectangle","fill":"#000000"}`)},
+	}
+
+	img := service.RasterizeElements(uuid.New(), "default", elements, 1)
+
+	if got := img.RGBAAt(400, 220); got.R > 0x20 {
+		t.Errorf("Expected rotated bar to cover (400, 220), got %v", got)
+	}
+	if got := img.RGBAAt(320, 300); got.R < 0xE0 {
+		t.Errorf("Expected (320, 300) to be uncovered after rotation, got %v", got)
+	}
+}
+
+func TestEncodePDF(t *testing.T) {
+	service := &RenderService{}
+	img := service.RasterizeElements(uuid.New(), "cork", nil, 0.5)
+
+	data, err := encodePDF(img, RenderCanvasWidth, RenderCanvasHeight)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) {
+		t.Errorf("Expected PDF header")
+	}
+	if !bytes.Contains(data, []byte("/MediaBox [0 0 800 600]")) {
+		t.Errorf("Expected media box sized to the canvas")
+	}
+	if !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Errorf("Expected PDF trailer")
+	}
+}
+
+func TestRasterizeElementsCapsSizes(t *testing.T) {
+	service := &RenderService{}
+
+	elements := []models.Element{
+		{Kind: "shape", X: 0, Y: 0, W: 1e7, H: 10, Visible: true,
+			Payload: datatypes.JSON(`{"shapeType":"rectangle","fill":"#000000"}`)},
+	}
+
+	img := service.RasterizeElements(uuid.New(), "default", elements, 100)
+
+	if bounds := img.Bounds(); bounds.Dx() != RenderCanvasWidth*MaxRenderScale || bounds.Dy() != RenderCanvasHeight*MaxRenderScale {
+		t.Fatalf("Expected the scale to be capped, got %v", bounds)
+	}
+	if got := img.RGBAAt(100, 20); got.R > 0x20 {
+		t.Errorf("Expected the oversized element to be stretched over the canvas, got %v", got)
+	}
+
+	tile := service.renderElement(uuid.New(), elements[0], MaxRenderScale, map[string]image.Image{})
+	if bounds := tile.Bounds(); bounds.Dx() > maxRenderTileSize || bounds.Dy() > maxRenderTileSize {
+		t.Errorf("Expected the tile to be capped, got %v", bounds)
+	}
+}
+
+func TestLoadImageOnlyReadsBoardUploads(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	service := &RenderService{uploadService: NewUploadService()}
+	boardID := uuid.New()
+	otherBoardID := uuid.New()
+
+	var small bytes.Buffer
+	png.Encode(&small, image.NewNRGBA(image.Rect(0, 0, 4, 4)))
+	writeUpload(t, service, boardID, "small.png", small.Bytes())
+	writeUpload(t, service, otherBoardID, "other.png", small.Bytes())
+	writeUpload(t, service, boardID, "huge.png", pngHeader(20000, 20000))
+
+	if _, err := service.loadImage(boardID, "http://localhost:8080/uploads/boards/"+boardID.String()+"/small.png"); err != nil {
+		t.Errorf("Expected the board's upload to load, got %v", err)
+	}
+
+	rejected := []string{
+		"http://localhost:8080/uploads/boards/" + otherBoardID.String() + "/other.png",
+		"/uploads/boards/" + boardID.String() + "/../" + otherBoardID.String() + "/other.png",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://localhost:8080/uploads/boards/" + boardID.String() + "/huge.png",
+	}
+	for _, rawURL := range rejected {
+		if _, err := service.loadImage(boardID, rawURL); err == nil {
+			t.Errorf("Expected %s to be rejected", rawURL)
+		}
+	}
+}
+
+// writeUpload stores a file in a board's upload directory
+func writeUpload(t *testing.T, service *RenderService, boardID uuid.UUID, name string, data []byte) {
+	t.Helper()
+	uploadDir := service.uploadService.GetBoardUploadDir(boardID)
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(uploadDir, name), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pngHeader returns the start of a PNG file declaring the given dimensions
+func pngHeader(width, height uint32) []byte {
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:4], width)
+	binary.BigEndian.PutUint32(ihdr[4:8], height)
+	ihdr[8] = 8 // bit depth
+	ihdr[9] = 6 // RGBA
+
+	chunk := append([]byte("IHDR"), ihdr...)
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&buf, binary.BigEndian, uint32(len(ihdr)))
+	buf.Write(chunk)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	return buf.Bytes()
+}
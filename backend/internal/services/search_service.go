@@ -0,0 +1,151 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Search settings
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	// searchConfig is the Postgres text search configuration used for stemming; the search
+	// indexes of migration 023 are built with it
+	searchConfig = "english"
+)
+
+// Snippet highlight markers; they cannot occur in user text and are turned into <mark> tags after escaping
+const (
+	searchMarkStart = "\x01"
+	searchMarkStop  = "\x02"
+)
+
+// liveSearchDocuments lists the searchable text of a live board, skipping trashed rows. Each
+// document is computed from a single column expression so matching can use the GIN indexes.
+const liveSearchDocuments = `
+	SELECT 'board' AS kind, 'description' AS field, NULL::uuid AS page_id, NULL::uuid AS element_id,
+		description AS body, to_tsvector('%[1]s', description) AS document
+	FROM boards WHERE id = @board AND deleted_at IS NULL
+	UNION ALL
+	SELECT 'page', 'title', id, NULL, title, to_tsvector('%[1]s', title)
+	FROM pages WHERE board_id = @board AND deleted_at IS NULL
+	UNION ALL
+	SELECT 'element', 'content', page_id, e.id, payload->>'content', to_tsvector('%[1]s', payload->>'content')
+	FROM elements e JOIN pages p ON p.id = e.page_id
+	WHERE p.board_id = @board AND p.deleted_at IS NULL AND e.deleted_at IS NULL AND kind = 'text'
+	UNION ALL
+	SELECT 'element', 'description', page_id, e.id, payload->>'description', to_tsvector('%[1]s', payload->>'description')
+	FROM elements e JOIN pages p ON p.id = e.page_id
+	WHERE p.board_id = @board AND p.deleted_at IS NULL AND e.deleted_at IS NULL AND kind = 'image'`
+
+// snapshotSearchDocuments lists the searchable text of a board snapshot
+const snapshotSearchDocuments = `
+	WITH snapshot AS (
+		SELECT content FROM board_snapshots WHERE id = @snapshot AND board_id = @board
+	), snapshot_pages AS (
+		SELECT p AS page FROM snapshot, jsonb_array_elements(COALESCE(snapshot.content->'pages', '[]'::jsonb)) p
+	), snapshot_elements AS (
+		SELECT (page->>'id')::uuid AS page_id, e AS element
+		FROM snapshot_pages, jsonb_array_elements(COALESCE(page->'elements', '[]'::jsonb)) e
+	), snapshot_documents AS (
+		SELECT 'board' AS kind, 'description' AS field, NULL::uuid AS page_id, NULL::uuid AS element_id, content->>'description' AS body
+		FROM snapshot
+		UNION ALL
+		SELECT 'page', 'title', (page->>'id')::uuid, NULL, page->>'title'
+		FROM snapshot_pages
+		UNION ALL
+		SELECT 'element', CASE WHEN element->>'kind' = 'text' THEN 'content' ELSE 'description' END, page_id, (element->>'id')::uuid,
+			CASE WHEN element->>'kind' = 'text' THEN element->'payload'->>'content' ELSE element->'payload'->>'description' END
+		FROM snapshot_elements WHERE element->>'kind' IN ('text', 'image')
+	)
+	SELECT kind, field, page_id, element_id, body, to_tsvector('%[1]s', body) AS document
+	FROM snapshot_documents`
+
+// searchQuery ranks documents against a web-style query and builds highlighted snippets.
+// Documents without text have an empty or NULL document and never match.
+const searchQuery = `
+	SELECT documents.kind, documents.field, documents.page_id, documents.element_id,
+		ts_rank(documents.document, query) AS rank,
+		ts_headline('%[2]s', documents.body, query, @headline) AS snippet
+	FROM (%[1]s) AS documents, websearch_to_tsquery('%[2]s', @query) AS query
+	WHERE documents.document @@ query
+	ORDER BY rank DESC, documents.kind, documents.page_id, documents.element_id
+	LIMIT @limit`
+
+type SearchService struct {
+	db *gorm.DB
+}
+
+func NewSearchService(db *gorm.DB) *SearchService {
+	return &SearchService{db: db}
+}
+
+// Search runs a full-text query over a board's description, page titles, text element content and
+// image descriptions. With usePinned the board's pinned snapshot, if any, is searched instead of the
+// live board, matching what readers without the edit token see.
+func (s *SearchService) Search(boardID uuid.UUID, query string, limit int, usePinned bool) ([]dto.SearchHit, error) {
+	args := map[string]interface{}{
+		"board":    boardID,
+		"query":    query,
+		"headline": fmt.Sprintf("StartSel=%s, StopSel=%s, MaxWords=25, MinWords=8, MaxFragments=2", searchMarkStart, searchMarkStop),
+		"limit":    searchLimit(limit),
+	}
+
+	documents := liveSearchDocuments
+	if usePinned {
+		var board models.Board
+		if err := s.db.Select("id", "pinned_snapshot_id").First(&board, "id = ?", boardID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, utils.ErrNotFound
+			}
+			return nil, fmt.Errorf("failed to get board: %w", err)
+		}
+		if board.PinnedSnapshotID != nil {
+			documents = snapshotSearchDocuments
+			args["snapshot"] = *board.PinnedSnapshotID
+		}
+	}
+
+	var hits []dto.SearchHit
+	if err := s.db.Raw(buildSearchSQL(documents), args).Scan(&hits).Error; err != nil {
+		return nil, fmt.Errorf("failed to search board: %w", err)
+	}
+
+	for i := range hits {
+		hits[i].Snippet = highlightSnippet(hits[i].Snippet)
+	}
+
+	return hits, nil
+}
+
+// searchLimit applies the default and maximum number of search hits
+func searchLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		return MaxSearchLimit
+	}
+	return limit
+}
+
+// buildSearchSQL builds the search query over a set of documents in the search configuration
+func buildSearchSQL(documents string) string {
+	return fmt.Sprintf(searchQuery, fmt.Sprintf(documents, searchConfig), searchConfig)
+}
+
+// highlightSnippet escapes a ts_headline snippet for HTML and turns the match markers into <mark> tags
+func highlightSnippet(snippet string) string {
+	return strings.NewReplacer(
+		searchMarkStart, "<mark>",
+		searchMarkStop, "</mark>",
+	).Replace(html.EscapeString(snippet))
+}
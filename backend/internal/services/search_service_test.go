@@ -0,0 +1,80 @@
+package services
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestHighlightSnippet(t *testing.T) {
+	snippet := "Lunch at " + searchMarkStart + "Café" + searchMarkStop + " <b>Rouge</b> & tea"
+
+	expected := "Lunch at <mark>Café</mark> &lt;b&gt;Rouge&lt;/b&gt; &amp; tea"
+	if got := highlightSnippet(snippet); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{name: "Default when omitted", limit: 0, expected: DefaultSearchLimit},
+		{name: "Default when negative", limit: -5, expected: DefaultSearchLimit},
+		{name: "Requested limit", limit: 50, expected: 50},
+		{name: "Capped at the maximum", limit: 500, expected: MaxSearchLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchLimit(tt.limit); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildSearchSQL(t *testing.T) {
+	for name, documents := range map[string]string{"live": liveSearchDocuments, "snapshot": snapshotSearchDocuments} {
+		t.Run(name, func(t *testing.T) {
+			sql := buildSearchSQL(documents)
+
+			if strings.Contains(sql, "%!") || strings.Contains(sql, "%[") {
+				t.Fatalf("Expected all placeholders to be filled, got %s", sql)
+			}
+			if strings.Contains(sql, "to_tsvector('english', documents.body)") {
+				t.Error("Expected documents to be matched by their precomputed vectors")
+			}
+			// Best matches come first, with a stable order between equal ranks
+			if !strings.Contains(sql, "ts_rank(documents.document, query) AS rank") ||
+				!strings.Contains(sql, "ORDER BY rank DESC, documents.kind, documents.page_id, documents.element_id") {
+				t.Errorf("Expected hits ordered by rank, got %s", sql)
+			}
+			if !strings.Contains(sql, "websearch_to_tsquery('"+searchConfig+"', @query)") {
+				t.Errorf("Expected the query to use the %s configuration", searchConfig)
+			}
+		})
+	}
+}
+
+func TestLiveSearchDocumentsUseSearchIndexes(t *testing.T) {
+	migration, err := os.ReadFile("../migrations/023_add_search_indexes.sql")
+	if err != nil {
+		t.Fatalf("Failed to read search index migration: %v", err)
+	}
+
+	expressions := regexp.MustCompile(`to_tsvector\('[a-z]+', [^()]+\)`).FindAllString(string(migration), -1)
+	if len(expressions) != 4 {
+		t.Fatalf("Expected 4 indexed expressions, got %v", expressions)
+	}
+
+	sql := buildSearchSQL(liveSearchDocuments)
+	for _, expression := range expressions {
+		if !strings.Contains(sql, expression) {
+			t.Errorf("Expected the live documents to use the indexed expression %s", expression)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidShareLink is returned when a share link's page or expiry is not acceptable
+var ErrInvalidShareLink = errors.New("invalid share link")
+
+// ShareLinkService manages per-collaborator share links of boards
+type ShareLinkService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewShareLinkService(db *gorm.DB) *ShareLinkService {
+	return &ShareLinkService{db: db, now: time.Now}
+}
+
+// CreateShareLink creates a share link for a board, optionally scoped to one of its pages
+func (s *ShareLinkService) CreateShareLink(boardID uuid.UUID, role string, pageID *uuid.UUID, label string, expiresAt *time.Time) (*models.ShareLink, error) {
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidShareLink)
+	}
+
+	if pageID != nil {
+		var count int64
+		if err := s.db.Model(&models.Page{}).Where("id = ? AND board_id = ?", *pageID, boardID).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to validate page: %w", err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("%w: page does not belong to the board", ErrInvalidShareLink)
+		}
+	}
+
+	link := &models.ShareLink{
+		BoardID:   boardID,
+		Role:      role,
+		PageID:    pageID,
+		Label:     label,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.db.Create(link).Error; err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return link, nil
+}
+
+// ListShareLinks returns all share links of a board, including expired ones, newest first
+func (s *ShareLinkService) ListShareLinks(boardID uuid.UUID) ([]models.ShareLink, error) {
+	var links []models.ShareLink
+	if err := s.db.Where("board_id = ?", boardID).Order("created_at DESC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink deletes a share link; its token stops working immediately
+func (s *ShareLinkService) RevokeShareLink(boardID, linkID uuid.UUID) error {
+	result := s.db.Where("id = ? AND board_id = ?", linkID, boardID).Delete(&models.ShareLink{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke share link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// ResolveShareLink finds an unexpired share link of a board that has not been deleted by its token
+func (s *ShareLinkService) ResolveShareLink(token uuid.UUID) (*models.ShareLink, error) {
+	var link models.ShareLink
+	err := s.db.Joins("JOIN boards ON boards.id = share_links.board_id AND boards.deleted_at IS NULL").
+		Where("share_links.token_hash = ? AND (share_links.expires_at IS NULL OR share_links.expires_at > ?)", utils.HashToken(token), s.now()).
+		First(&link).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// IsExpired reports whether a share link is past its expiry
+func (s *ShareLinkService) IsExpired(link *models.ShareLink) bool {
+	return link.ExpiresAt != nil && !link.ExpiresAt.After(s.now())
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestShareLinkAllows(t *testing.T) {
+	pageID := uuid.New()
+	otherPageID := uuid.New()
+
+	tests := []struct {
+		name     string
+		link     *models.ShareLink
+		pageID   *uuid.UUID
+		edit     bool
+		expected bool
+	}{
+		{name: "no link", link: nil, pageID: nil, edit: false, expected: false},
+		{name: "board viewer reads board", link: &models.ShareLink{Role: models.ShareRoleViewer}, pageID: nil, edit: false, expected: true},
+		{name: "board viewer reads page", link: &models.ShareLink{Role: models.ShareRoleViewer}, pageID: &pageID, edit: false, expected: true},
+		{name: "board viewer edits board", link: &models.ShareLink{Role: models.ShareRoleViewer}, pageID: nil, edit: true, expected: false},
+		{name: "board commenter edits page", link: &models.ShareLink{Role: models.ShareRoleCommenter}, pageID: &pageID, edit: true, expected: false},
+		{name: "board editor edits board", link: &models.ShareLink{Role: models.ShareRoleEditor}, pageID: nil, edit: true, expected: true},
+		{name: "board editor edits page", link: &models.ShareLink{Role: models.ShareRoleEditor}, pageID: &pageID, edit: true, expected: true},
+		{name: "page viewer reads its page", link: &models.ShareLink{Role: models.ShareRoleViewer, PageID: &pageID}, pageID: &pageID, edit: false, expected: true},
+		{name: "page viewer reads board", link: &models.ShareLink{Role: models.ShareRoleViewer, PageID: &pageID}, pageID: nil, edit: false, expected: false},
+		{name: "page viewer reads other page", link: &models.ShareLink{Role: models.ShareRoleViewer, PageID: &pageID}, pageID: &otherPageID, edit: false, expected: false},
+		{name: "page viewer edits its page", link: &models.ShareLink{Role: models.ShareRoleViewer, PageID: &pageID}, pageID: &pageID, edit: true, expected: false},
+		{name: "page editor edits its page", link: &models.ShareLink{Role: models.ShareRoleEditor, PageID: &pageID}, pageID: &pageID, edit: true, expected: true},
+		{name: "page editor edits board", link: &models.ShareLink{Role: models.ShareRoleEditor, PageID: &pageID}, pageID: nil, edit: true, expected: false},
+		{name: "page editor edits other page", link: &models.ShareLink{Role: models.ShareRoleEditor, PageID: &pageID}, pageID: &otherPageID, edit: true, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shareLinkAllows(tt.link, tt.pageID, tt.edit); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestShareLinkIsExpired(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	service := &ShareLinkService{now: func() time.Time { return now }}
+
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		expected  bool
+	}{
+		{name: "no expiry", expiresAt: nil, expected: false},
+		{name: "expired", expiresAt: &past, expected: true},
+		{name: "expires now", expiresAt: &now, expected: true},
+		{name: "not yet expired", expiresAt: &future, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.IsExpired(&models.ShareLink{ExpiresAt: tt.expiresAt}); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
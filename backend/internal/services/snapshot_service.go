@@ -0,0 +1,503 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SnapshotLive names the live board in snapshot diffs
+const SnapshotLive = "live"
+
+type SnapshotService struct {
+	db *gorm.DB
+}
+
+func NewSnapshotService(db *gorm.DB) *SnapshotService {
+	return &SnapshotService{db: db}
+}
+
+// CreateSnapshot freezes the current state of a board, its pages and elements under a name
+func (s *SnapshotService) CreateSnapshot(boardID uuid.UUID, name string) (*models.BoardSnapshot, error) {
+	board, err := loadBoardContent(s.db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(snapshotContent(board))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	snapshot := &models.BoardSnapshot{
+		BoardID:   boardID,
+		Name:      name,
+		PageCount: len(board.Pages),
+		Content:   datatypes.JSON(content),
+	}
+	for _, page := range board.Pages {
+		snapshot.ElementCount += len(page.Elements)
+	}
+
+	if err := s.db.Create(snapshot).Error; err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// ListSnapshots returns a board's snapshots newest first, without their content
+func (s *SnapshotService) ListSnapshots(boardID uuid.UUID) ([]models.BoardSnapshot, error) {
+	var snapshots []models.BoardSnapshot
+	err := s.db.Omit("content").
+		Where("board_id = ?", boardID).
+		Order("created_at DESC").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetSnapshot retrieves a snapshot of a board together with its decoded content
+func (s *SnapshotService) GetSnapshot(boardID, snapshotID uuid.UUID) (*models.BoardSnapshot, *models.Board, error) {
+	var snapshot models.BoardSnapshot
+	if err := s.db.First(&snapshot, "id = ? AND board_id = ?", snapshotID, boardID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, utils.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	board, err := decodeSnapshotContent(&snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &snapshot, board, nil
+}
+
+// DeleteSnapshot deletes a snapshot; boards pinned to it fall back to the live board
+func (s *SnapshotService) DeleteSnapshot(boardID, snapshotID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Board{}).
+			Where("id = ? AND pinned_snapshot_id = ?", boardID, snapshotID).
+			Update("pinned_snapshot_id", nil).Error
+		if err != nil {
+			return fmt.Errorf("failed to unpin snapshot: %w", err)
+		}
+
+		result := tx.Delete(&models.BoardSnapshot{}, "id = ? AND board_id = ?", snapshotID, boardID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete snapshot: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return utils.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// PinSnapshot points readers without the edit token at a snapshot; a nil ID unpins
+func (s *SnapshotService) PinSnapshot(boardID uuid.UUID, snapshotID *uuid.UUID) error {
+	if snapshotID != nil {
+		var count int64
+		err := s.db.Model(&models.BoardSnapshot{}).
+			Where("id = ? AND board_id = ?", *snapshotID, boardID).
+			Count(&count).Error
+		if err != nil {
+			return fmt.Errorf("failed to check snapshot: %w", err)
+		}
+		if count == 0 {
+			return utils.ErrNotFound
+		}
+	}
+
+	err := s.db.Model(&models.Board{}).
+		Where("id = ?", boardID).
+		Update("pinned_snapshot_id", snapshotID).Error
+	if err != nil {
+		return fmt.Errorf("failed to pin snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetPinnedBoard returns the pinned snapshot content of a board, or nil when nothing is pinned
+func (s *SnapshotService) GetPinnedBoard(boardID uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := s.db.Select("id", "public_token", "public_expires_at", "public_max_views", "public_view_count", "pinned_snapshot_id").
+		First(&board, "id = ?", boardID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+
+	if board.PinnedSnapshotID == nil {
+		return nil, nil
+	}
+
+	_, pinned, err := s.GetSnapshot(boardID, *board.PinnedSnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Identity fields and public link settings always come from the live board
+	pinned.ID = board.ID
+	pinned.PublicToken = board.PublicToken
+	pinned.PublicExpiresAt = board.PublicExpiresAt
+	pinned.PublicMaxViews = board.PublicMaxViews
+	pinned.PublicViewCount = board.PublicViewCount
+	pinned.PinnedSnapshotID = board.PinnedSnapshotID
+
+	return pinned, nil
+}
+
+// DiffSnapshot compares a snapshot with another snapshot of the same board, or with the
+// live board when againstID is nil
+func (s *SnapshotService) DiffSnapshot(boardID, snapshotID uuid.UUID, againstID *uuid.UUID) (*dto.SnapshotDiffResponse, error) {
+	_, from, err := s.GetSnapshot(boardID, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	to := SnapshotLive
+	var against *models.Board
+	if againstID != nil {
+		to = againstID.String()
+		if _, against, err = s.GetSnapshot(boardID, *againstID); err != nil {
+			return nil, err
+		}
+	} else if against, err = loadBoardContent(s.db, boardID); err != nil {
+		return nil, err
+	}
+
+	diff := diffBoards(from, against)
+	diff.From = snapshotID.String()
+	diff.To = to
+
+	return diff, nil
+}
+
+// RestoreInPlace brings the live board back to a snapshot. Pages and elements keep their IDs;
+// those that did not exist in the snapshot are moved to the trash.
+func (s *SnapshotService) RestoreInPlace(boardID, snapshotID uuid.UUID) (*models.Board, error) {
+	_, source, err := s.GetSnapshot(boardID, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Board{}).Where("id = ?", boardID).Updates(map[string]interface{}{
+			"title":       source.Title,
+			"description": source.Description,
+			"skin":        source.Skin,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to restore board: %w", err)
+		}
+
+		// Trashed pages are included so they can be brought back
+		var currentPages []models.Page
+		err = tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("board_id = ?", boardID).
+			Find(&currentPages).Error
+		if err != nil {
+			return fmt.Errorf("failed to get pages: %w", err)
+		}
+
+		remaining := make(map[uuid.UUID]*models.Page, len(currentPages))
+		for i := range currentPages {
+			remaining[currentPages[i].ID] = &currentPages[i]
+		}
+
+		for i := range source.Pages {
+			if err := restoreSnapshotPage(tx, boardID, remaining[source.Pages[i].ID], &source.Pages[i]); err != nil {
+				return err
+			}
+			delete(remaining, source.Pages[i].ID)
+		}
+
+		// Pages added after the snapshot go to the trash
+		for _, page := range remaining {
+			if page.DeletedAt.Valid {
+				continue
+			}
+			if err := tx.Delete(&models.Page{}, "id = ?", page.ID).Error; err != nil {
+				return fmt.Errorf("failed to delete page: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loadBoardContent(s.db, boardID)
+}
+
+// restoreSnapshotPage writes a snapshot page and its elements over the current page,
+// recreating it if it was purged in the meantime
+func restoreSnapshotPage(tx *gorm.DB, boardID uuid.UUID, current, source *models.Page) error {
+	if current == nil {
+		page := &models.Page{
+			ID:       source.ID,
+			BoardID:  boardID,
+			Title:    source.Title,
+			Date:     source.Date,
+			OrderIdx: source.OrderIdx,
+		}
+		if err := tx.Omit("Elements").Create(page).Error; err != nil {
+			return fmt.Errorf("failed to recreate page %s: %w", source.ID, err)
+		}
+	} else {
+		err := tx.Unscoped().Model(&models.Page{}).Where("id = ?", source.ID).Updates(map[string]interface{}{
+			"title":      source.Title,
+			"date":       source.Date,
+			"order_idx":  source.OrderIdx,
+			"version":    gorm.Expr("version + 1"),
+			"deleted_at": nil,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to restore page %s: %w", source.ID, err)
+		}
+	}
+
+	var currentElements []models.Element
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("page_id = ?", source.ID).
+		Find(&currentElements).Error
+	if err != nil {
+		return fmt.Errorf("failed to get elements: %w", err)
+	}
+
+	remaining := make(map[uuid.UUID]*models.Element, len(currentElements))
+	for i := range currentElements {
+		remaining[currentElements[i].ID] = &currentElements[i]
+	}
+
+	for i := range source.Elements {
+		target := source.Elements[i]
+		target.PageID = source.ID
+		if _, err := applyElementState(tx, remaining[target.ID], &target); err != nil {
+			return err
+		}
+		delete(remaining, target.ID)
+	}
+
+	// Elements added after the snapshot go to the trash
+	for _, element := range remaining {
+		if element.DeletedAt.Valid {
+			continue
+		}
+		if err := tx.Delete(&models.Element{}, "id = ?", element.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete element: %w", err)
+		}
+		if err := recordElementRevision(tx, models.ElementActionRestore, element, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RestoreAsNewBoard creates a new board with fresh IDs and tokens from a snapshot.
+// Uploaded images still present are copied into the new board's own upload directory.
+func (s *SnapshotService) RestoreAsNewBoard(boardID, snapshotID uuid.UUID) (*models.Board, error) {
+	snapshot, source, err := s.GetSnapshot(boardID, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	// The snapshot points at the uploads in its board's directory
+	source.ID = boardID
+	source.Title = fmt.Sprintf("%s (%s)", source.Title, snapshot.Name)
+
+	return createBoardCopy(s.db, source, uuid.New(), true)
+}
+
+// loadBoardContent loads a live board with its pages and elements
+func loadBoardContent(db *gorm.DB, boardID uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	err := db.Preload("Pages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_idx ASC")
+	}).Preload("Pages.Elements", func(db *gorm.DB) *gorm.DB {
+		return db.Order("z ASC")
+	}).Where("id = ?", boardID).First(&board).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to load board: %w", err)
+	}
+
+	return &board, nil
+}
+
+// snapshotContent returns the board as stored in a snapshot: its content without the public
+// token and public link settings, which are not part of what a snapshot freezes
+func snapshotContent(board *models.Board) *models.Board {
+	content := *board
+	content.PublicToken = uuid.Nil
+	content.PublicExpiresAt = nil
+	content.PublicMaxViews = nil
+	content.PublicViewCount = 0
+	content.PinnedSnapshotID = nil
+	return &content
+}
+
+// decodeSnapshotContent decodes the board stored in a snapshot
+func decodeSnapshotContent(snapshot *models.BoardSnapshot) (*models.Board, error) {
+	var board models.Board
+	if err := json.Unmarshal(snapshot.Content, &board); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", snapshot.ID, err)
+	}
+
+	return &board, nil
+}
+
+// diffBoards lists what changed between two versions of the same board, matching pages and
+// elements by ID
+func diffBoards(from, to *models.Board) *dto.SnapshotDiffResponse {
+	diff := &dto.SnapshotDiffResponse{
+		BoardChanges:    []string{},
+		PagesAdded:      []dto.PageDiffEntry{},
+		PagesRemoved:    []dto.PageDiffEntry{},
+		PagesChanged:    []dto.PageDiffEntry{},
+		ElementsAdded:   []dto.ElementDiffEntry{},
+		ElementsRemoved: []dto.ElementDiffEntry{},
+		ElementsChanged: []dto.ElementDiffEntry{},
+	}
+
+	if from.Title != to.Title {
+		diff.BoardChanges = append(diff.BoardChanges, "title")
+	}
+	if from.Description != to.Description {
+		diff.BoardChanges = append(diff.BoardChanges, "description")
+	}
+	if from.Skin != to.Skin {
+		diff.BoardChanges = append(diff.BoardChanges, "skin")
+	}
+
+	fromPages := make(map[uuid.UUID]*models.Page, len(from.Pages))
+	fromElements := make(map[uuid.UUID]*models.Element)
+	for i := range from.Pages {
+		fromPages[from.Pages[i].ID] = &from.Pages[i]
+		for j := range from.Pages[i].Elements {
+			fromElements[from.Pages[i].Elements[j].ID] = &from.Pages[i].Elements[j]
+		}
+	}
+
+	for i := range to.Pages {
+		page := &to.Pages[i]
+		previous, ok := fromPages[page.ID]
+		if !ok {
+			diff.PagesAdded = append(diff.PagesAdded, dto.PageDiffEntry{ID: page.ID, Title: page.Title})
+		} else if changes := pageChanges(previous, page); len(changes) > 0 {
+			diff.PagesChanged = append(diff.PagesChanged, dto.PageDiffEntry{ID: page.ID, Title: page.Title, Changes: changes})
+		}
+		delete(fromPages, page.ID)
+
+		for j := range page.Elements {
+			element := &page.Elements[j]
+			entry := dto.ElementDiffEntry{ID: element.ID, PageID: page.ID, Kind: element.Kind}
+			previous, ok := fromElements[element.ID]
+			if !ok {
+				diff.ElementsAdded = append(diff.ElementsAdded, entry)
+			} else if entry.Changes = elementChanges(previous, element); len(entry.Changes) > 0 {
+				diff.ElementsChanged = append(diff.ElementsChanged, entry)
+			}
+			delete(fromElements, element.ID)
+		}
+	}
+
+	// Whatever was not matched only exists in the older version
+	for i := range from.Pages {
+		if page, ok := fromPages[from.Pages[i].ID]; ok {
+			diff.PagesRemoved = append(diff.PagesRemoved, dto.PageDiffEntry{ID: page.ID, Title: page.Title})
+		}
+		for j := range from.Pages[i].Elements {
+			if element, ok := fromElements[from.Pages[i].Elements[j].ID]; ok {
+				diff.ElementsRemoved = append(diff.ElementsRemoved, dto.ElementDiffEntry{ID: element.ID, PageID: element.PageID, Kind: element.Kind})
+			}
+		}
+	}
+
+	return diff
+}
+
+// pageChanges lists the page fields that differ between two versions
+func pageChanges(a, b *models.Page) []string {
+	var changes []string
+	if a.Title != b.Title {
+		changes = append(changes, "title")
+	}
+	if !a.Date.Equal(b.Date) {
+		changes = append(changes, "date")
+	}
+	if a.OrderIdx != b.OrderIdx {
+		changes = append(changes, "order_idx")
+	}
+	return changes
+}
+
+// elementChanges lists the element fields that differ between two versions, ignoring
+// bookkeeping fields such as version and timestamps
+func elementChanges(a, b *models.Element) []string {
+	var changes []string
+	if a.Kind != b.Kind {
+		changes = append(changes, "kind")
+	}
+	if a.X != b.X || a.Y != b.Y {
+		changes = append(changes, "position")
+	}
+	if a.W != b.W || a.H != b.H {
+		changes = append(changes, "size")
+	}
+	if a.Rotation != b.Rotation {
+		changes = append(changes, "rotation")
+	}
+	if a.Z != b.Z {
+		changes = append(changes, "z")
+	}
+	if a.Visible != b.Visible {
+		changes = append(changes, "visible")
+	}
+	if a.Locked != b.Locked {
+		changes = append(changes, "locked")
+	}
+	if !samePayload(a.Payload, b.Payload) {
+		changes = append(changes, "payload")
+	}
+	return changes
+}
+
+// samePayload compares payloads structurally since jsonb does not preserve key order or spacing
+func samePayload(a, b datatypes.JSON) bool {
+	var payloadA, payloadB interface{}
+	if len(a) > 0 {
+		if err := json.Unmarshal(a, &payloadA); err != nil {
+			return false
+		}
+	}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &payloadB); err != nil {
+			return false
+		}
+	}
+
+	return reflect.DeepEqual(payloadA, payloadB)
+}
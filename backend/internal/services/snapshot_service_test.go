@@ -0,0 +1,136 @@
+package services
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func TestDiffBoards(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	keptPage := models.Page{ID: uuid.New(), Title: "Day one", Date: date}
+	removedPage := models.Page{ID: uuid.New(), Title: "Day two", Date: date, OrderIdx: 1}
+
+	moved := models.Element{ID: uuid.New(), PageID: keptPage.ID, Kind: "text", X: 10, Payload: datatypes.JSON(`{"content":"hi"}`)}
+	untouched := models.Element{ID: uuid.New(), PageID: keptPage.ID, Kind: "shape", Z: 1}
+	removed := models.Element{ID: uuid.New(), PageID: removedPage.ID, Kind: "sticker"}
+
+	from := &models.Board{Title: "Trip", Skin: "default", Pages: []models.Page{keptPage, removedPage}}
+	from.Pages[0].Elements = []models.Element{moved, untouched}
+	from.Pages[1].Elements = []models.Element{removed}
+
+	renamedPage := keptPage
+	renamedPage.Title = "Arrival"
+	movedAfter := moved
+	movedAfter.X = 40
+	movedAfter.Payload = datatypes.JSON(`{"content": "hello"}`)
+	added := models.Element{ID: uuid.New(), PageID: keptPage.ID, Kind: "image", Z: 2}
+
+	to := &models.Board{Title: "Trip", Skin: "cork", Pages: []models.Page{renamedPage}}
+	to.Pages[0].Elements = []models.Element{movedAfter, untouched, added}
+
+	diff := diffBoards(from, to)
+
+	if len(diff.BoardChanges) != 1 || diff.BoardChanges[0] != "skin" {
+		t.Errorf("Expected board changes [skin], got %v", diff.BoardChanges)
+	}
+	if len(diff.PagesChanged) != 1 || diff.PagesChanged[0].Changes[0] != "title" {
+		t.Errorf("Expected renamed page, got %+v", diff.PagesChanged)
+	}
+	if len(diff.PagesRemoved) != 1 || diff.PagesRemoved[0].ID != removedPage.ID {
+		t.Errorf("Expected removed page %s, got %+v", removedPage.ID, diff.PagesRemoved)
+	}
+	if len(diff.PagesAdded) != 0 {
+		t.Errorf("Expected no added pages, got %+v", diff.PagesAdded)
+	}
+	if len(diff.ElementsAdded) != 1 || diff.ElementsAdded[0].ID != added.ID {
+		t.Errorf("Expected added element %s, got %+v", added.ID, diff.ElementsAdded)
+	}
+	if len(diff.ElementsRemoved) != 1 || diff.ElementsRemoved[0].ID != removed.ID {
+		t.Errorf("Expected removed element %s, got %+v", removed.ID, diff.ElementsRemoved)
+	}
+	if len(diff.ElementsChanged) != 1 {
+		t.Fatalf("Expected 1 changed element, got %+v", diff.ElementsChanged)
+	}
+	changes := diff.ElementsChanged[0].Changes
+	if len(changes) != 2 || changes[0] != "position" || changes[1] != "payload" {
+		t.Errorf("Expected [position payload] changes, got %v", changes)
+	}
+}
+
+func TestSnapshotContentStripsPublicLink(t *testing.T) {
+	expiresAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	maxViews := 5
+	pinnedID := uuid.New()
+	board := &models.Board{
+		ID:               uuid.New(),
+		Title:            "Trip",
+		PublicToken:      uuid.New(),
+		PublicExpiresAt:  &expiresAt,
+		PublicMaxViews:   &maxViews,
+		PublicViewCount:  3,
+		PinnedSnapshotID: &pinnedID,
+		Pages:            []models.Page{{ID: uuid.New(), Title: "Day one"}},
+	}
+
+	content := snapshotContent(board)
+
+	if content.PublicToken != uuid.Nil || content.PublicExpiresAt != nil || content.PublicMaxViews != nil ||
+		content.PublicViewCount != 0 || content.PinnedSnapshotID != nil {
+		t.Errorf("Expected the public link to be stripped, got %+v", content)
+	}
+	if content.ID != board.ID || content.Title != board.Title || len(content.Pages) != 1 {
+		t.Errorf("Expected the content to be kept, got %+v", content)
+	}
+	if board.PublicToken == uuid.Nil || board.PinnedSnapshotID == nil {
+		t.Error("Expected the live board to be left alone")
+	}
+}
+
+func TestRestoreAsNewBoardCopiesUploads(t *testing.T) {
+	useTempWorkDir(t)
+
+	db := newTestDB(t, &models.Board{}, &models.Page{}, &models.Element{}, &models.BoardSnapshot{}, &models.BoardUploadReference{})
+	uploadService := NewUploadService()
+	service := NewSnapshotService(db)
+
+	source := newBoardWithUpload(t, db)
+	snapshot, err := service.CreateSnapshot(source.ID, "Before")
+	if err != nil {
+		t.Fatalf("Failed to create snapshot: %v", err)
+	}
+
+	restored, err := service.RestoreAsNewBoard(source.ID, snapshot.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	board, err := loadBoardContent(db, restored.ID)
+	if err != nil {
+		t.Fatalf("Failed to load restored board: %v", err)
+	}
+	if len(board.Pages) != 1 || len(board.Pages[0].Elements) != 1 {
+		t.Fatalf("Expected 1 page with 1 element, got %+v", board.Pages)
+	}
+
+	rawURL, _ := payloadURL(board.Pages[0].Elements[0].Payload)
+	if uploadBoardID, ok := uploadService.ResolveBoardID(rawURL); !ok || uploadBoardID != restored.ID {
+		t.Errorf("Expected the upload to be copied to board %s, got %q", restored.ID, rawURL)
+	}
+	if localPath, _ := uploadService.ResolveLocalPath(rawURL); localPath != "" {
+		if _, err := os.Stat(localPath); err != nil {
+			t.Errorf("Expected the copied upload to exist, got %v", err)
+		}
+	}
+
+	var references int64
+	db.Model(&models.BoardUploadReference{}).Count(&references)
+	if references != 0 {
+		t.Errorf("Expected no shared uploads, got %d references", references)
+	}
+}
@@ -0,0 +1,576 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrTagExists is returned when a board already has a tag with the same name
+	ErrTagExists = errors.New("tag already exists")
+	// ErrInvalidTag is returned when a tag name is empty after normalization
+	ErrInvalidTag = errors.New("invalid tag")
+	// ErrTagTargetNotFound is returned when the page or element to (un)tag is not on the board or not tagged
+	ErrTagTargetNotFound = errors.New("tag target not found")
+)
+
+// tagUsageQuery selects a board's tags with the number of live pages and elements carrying them
+const tagUsageQuery = `
+	SELECT tags.*,
+		(SELECT COUNT(*) FROM page_tags pt
+			JOIN pages p ON p.id = pt.page_id AND p.deleted_at IS NULL
+			WHERE pt.tag_id = tags.id) AS page_count,
+		(SELECT COUNT(*) FROM element_tags et
+			JOIN elements e ON e.id = et.element_id AND e.deleted_at IS NULL
+			JOIN pages p ON p.id = e.page_id AND p.deleted_at IS NULL
+			WHERE et.tag_id = tags.id) AS element_count
+	FROM tags`
+
+// pinnedTagUsageQuery selects a board's tags with the number of pages and elements of a pinned
+// snapshot carrying them, given as @pages and @elements
+const pinnedTagUsageQuery = `
+	SELECT tags.*,
+		(SELECT COUNT(*) FROM page_tags pt
+			WHERE pt.tag_id = tags.id AND pt.page_id IN @pages) AS page_count,
+		(SELECT COUNT(*) FROM element_tags et
+			WHERE et.tag_id = tags.id AND et.element_id IN @elements) AS element_count
+	FROM tags`
+
+// taggedPagesQuery selects the live pages of a board carrying any of the @names tags, either on
+// the page itself or on one of its live elements
+const taggedPagesQuery = `
+	SELECT pt.page_id FROM page_tags pt
+	JOIN tags t ON t.id = pt.tag_id
+	JOIN pages p ON p.id = pt.page_id AND p.deleted_at IS NULL
+	WHERE t.board_id = @board AND t.name IN @names
+	UNION
+	SELECT e.page_id FROM element_tags et
+	JOIN tags t ON t.id = et.tag_id
+	JOIN elements e ON e.id = et.element_id AND e.deleted_at IS NULL
+	JOIN pages p ON p.id = e.page_id AND p.deleted_at IS NULL
+	WHERE t.board_id = @board AND t.name IN @names`
+
+// Element counts of tagCountsQuery: live elements on @pages, or the pinned snapshot's @elements
+const (
+	liveTagElementCount = `(SELECT COUNT(*) FROM element_tags et
+				JOIN elements e ON e.id = et.element_id AND e.deleted_at IS NULL
+				WHERE et.tag_id = t.id AND e.page_id IN @pages)`
+	pinnedTagElementCount = `(SELECT COUNT(*) FROM element_tags et
+				WHERE et.tag_id = t.id AND et.element_id IN @elements)`
+)
+
+// tagCountsQuery selects each tag of a board with how many of @pages, and elements on them, carry it
+const tagCountsQuery = `
+	SELECT t.id, t.name, t.color,
+		(SELECT COUNT(*) FROM page_tags pt WHERE pt.tag_id = t.id AND pt.page_id IN @pages) AS page_count,
+		%s AS element_count
+	FROM tags t
+	WHERE t.board_id = @board
+	ORDER BY t.name ASC`
+
+// TagUsage is a tag with the number of pages and elements it is attached to
+type TagUsage struct {
+	models.Tag
+	PageCount    int
+	ElementCount int
+}
+
+type TagService struct {
+	db *gorm.DB
+}
+
+func NewTagService(db *gorm.DB) *TagService {
+	return &TagService{db: db}
+}
+
+// ListTags returns a board's tags with usage counts, ordered by name. With a pinned snapshot
+// only its pages and elements are counted.
+func (s *TagService) ListTags(boardID uuid.UUID, pinned *models.Board) ([]TagUsage, error) {
+	var tags []TagUsage
+	var err error
+	if pinned != nil {
+		scope := newPinnedTagScope(pinned.Pages)
+		err = s.db.Raw(pinnedTagUsageQuery+" WHERE tags.board_id = @board ORDER BY tags.name ASC",
+			scope.namedArgs(boardID)).Scan(&tags).Error
+	} else {
+		err = s.db.Raw(tagUsageQuery+" WHERE tags.board_id = ? ORDER BY tags.name ASC", boardID).Scan(&tags).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetTag retrieves a tag with usage counts and the IDs of the pages and elements it is attached to.
+// With a pinned snapshot only its pages and elements are included.
+func (s *TagService) GetTag(boardID, tagID uuid.UUID, pinned *models.Board) (*TagUsage, []uuid.UUID, []uuid.UUID, error) {
+	if pinned != nil {
+		return s.getPinnedTag(boardID, tagID, newPinnedTagScope(pinned.Pages))
+	}
+
+	tag, err := s.getTagUsage(boardID, tagID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pageIDs := []uuid.UUID{}
+	err = s.db.Model(&models.PageTag{}).
+		Joins("JOIN pages ON pages.id = page_tags.page_id AND pages.deleted_at IS NULL").
+		Where("page_tags.tag_id = ?", tagID).
+		Order("pages.date ASC, pages.order_idx ASC").
+		Pluck("page_tags.page_id", &pageIDs).Error
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get tagged pages: %w", err)
+	}
+
+	elementIDs := []uuid.UUID{}
+	err = s.db.Model(&models.ElementTag{}).
+		Joins("JOIN elements ON elements.id = element_tags.element_id AND elements.deleted_at IS NULL").
+		Joins("JOIN pages ON pages.id = elements.page_id AND pages.deleted_at IS NULL").
+		Where("element_tags.tag_id = ?", tagID).
+		Order("element_tags.created_at ASC").
+		Pluck("element_tags.element_id", &elementIDs).Error
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get tagged elements: %w", err)
+	}
+
+	return tag, pageIDs, elementIDs, nil
+}
+
+// getPinnedTag retrieves a tag as GetTag does, limited to the pages and elements of a pinned snapshot
+func (s *TagService) getPinnedTag(boardID, tagID uuid.UUID, scope *pinnedTagScope) (*TagUsage, []uuid.UUID, []uuid.UUID, error) {
+	args := scope.namedArgs(boardID)
+	args["tag"] = tagID
+
+	var tags []TagUsage
+	if err := s.db.Raw(pinnedTagUsageQuery+" WHERE tags.id = @tag AND tags.board_id = @board", args).Scan(&tags).Error; err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, nil, nil, utils.ErrNotFound
+	}
+
+	var tagged []uuid.UUID
+	err := s.db.Model(&models.PageTag{}).
+		Where("tag_id = ? AND page_id IN ?", tagID, scope.pageIDs).
+		Pluck("page_id", &tagged).Error
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get tagged pages: %w", err)
+	}
+
+	elementIDs := []uuid.UUID{}
+	err = s.db.Model(&models.ElementTag{}).
+		Where("tag_id = ? AND element_id IN ?", tagID, scope.elementIDs).
+		Order("created_at ASC").
+		Pluck("element_id", &elementIDs).Error
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get tagged elements: %w", err)
+	}
+
+	return &tags[0], scope.orderPages(tagged), elementIDs, nil
+}
+
+// CreateTag creates a tag on a board; names are compared case-insensitively
+func (s *TagService) CreateTag(boardID uuid.UUID, name, color string) (*TagUsage, error) {
+	name = NormalizeTagName(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: tag name is required", ErrInvalidTag)
+	}
+
+	tag := &models.Tag{
+		BoardID: boardID,
+		Name:    name,
+		Color:   color,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureTagNameFree(tx, boardID, name, uuid.Nil); err != nil {
+			return err
+		}
+		if err := tx.Create(tag).Error; err != nil {
+			return fmt.Errorf("failed to create tag: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TagUsage{Tag: *tag}, nil
+}
+
+// UpdateTag renames or recolors a tag
+func (s *TagService) UpdateTag(boardID, tagID uuid.UUID, name, color *string) (*TagUsage, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var tag models.Tag
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&tag, "id = ? AND board_id = ?", tagID, boardID).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			return fmt.Errorf("failed to get tag: %w", err)
+		}
+
+		updates := make(map[string]interface{})
+		if name != nil {
+			normalized := NormalizeTagName(*name)
+			if normalized == "" {
+				return fmt.Errorf("%w: tag name is required", ErrInvalidTag)
+			}
+			if err := ensureTagNameFree(tx, boardID, normalized, tagID); err != nil {
+				return err
+			}
+			updates["name"] = normalized
+		}
+		if color != nil {
+			updates["color"] = *color
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&tag).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update tag: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getTagUsage(boardID, tagID)
+}
+
+// DeleteTag deletes a tag and detaches it from all pages and elements
+func (s *TagService) DeleteTag(boardID, tagID uuid.UUID) error {
+	result := s.db.Delete(&models.Tag{}, "id = ? AND board_id = ?", tagID, boardID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete tag: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// TagPage attaches a tag to a page of the same board; attaching twice is a no-op
+func (s *TagService) TagPage(boardID, tagID, pageID uuid.UUID) error {
+	if err := s.ensureTag(boardID, tagID); err != nil {
+		return err
+	}
+	if err := NewPageService(s.db).ValidatePageBelongsToBoard(pageID, boardID); err != nil {
+		if err == utils.ErrNotFound {
+			return ErrTagTargetNotFound
+		}
+		return err
+	}
+
+	link := &models.PageTag{PageID: pageID, TagID: tagID}
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(link).Error; err != nil {
+		return fmt.Errorf("failed to tag page: %w", err)
+	}
+
+	return nil
+}
+
+// UntagPage detaches a tag from a page
+func (s *TagService) UntagPage(boardID, tagID, pageID uuid.UUID) error {
+	if err := s.ensureTag(boardID, tagID); err != nil {
+		return err
+	}
+
+	result := s.db.Delete(&models.PageTag{}, "page_id = ? AND tag_id = ?", pageID, tagID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to untag page: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTagTargetNotFound
+	}
+
+	return nil
+}
+
+// TagElement attaches a tag to an element on a page of the same board; attaching twice is a no-op
+func (s *TagService) TagElement(boardID, tagID, elementID uuid.UUID) error {
+	if err := s.ensureTag(boardID, tagID); err != nil {
+		return err
+	}
+
+	var count int64
+	err := s.db.Model(&models.Element{}).
+		Joins("JOIN pages ON pages.id = elements.page_id AND pages.deleted_at IS NULL").
+		Where("elements.id = ? AND pages.board_id = ?", elementID, boardID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to validate element: %w", err)
+	}
+	if count == 0 {
+		return ErrTagTargetNotFound
+	}
+
+	link := &models.ElementTag{ElementID: elementID, TagID: tagID}
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(link).Error; err != nil {
+		return fmt.Errorf("failed to tag element: %w", err)
+	}
+
+	return nil
+}
+
+// UntagElement detaches a tag from an element
+func (s *TagService) UntagElement(boardID, tagID, elementID uuid.UUID) error {
+	if err := s.ensureTag(boardID, tagID); err != nil {
+		return err
+	}
+
+	result := s.db.Delete(&models.ElementTag{}, "element_id = ? AND tag_id = ?", elementID, tagID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to untag element: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTagTargetNotFound
+	}
+
+	return nil
+}
+
+// PageIDsWithTags returns the pages of a board that carry any of the named tags,
+// either on the page itself or on one of its elements. With a pinned snapshot only its pages
+// and elements are considered.
+func (s *TagService) PageIDsWithTags(boardID uuid.UUID, names []string, pinned *models.Board) (map[uuid.UUID]bool, error) {
+	if pinned != nil {
+		return s.pinnedPageIDsWithTags(boardID, names, newPinnedTagScope(pinned.Pages))
+	}
+
+	var pageIDs []uuid.UUID
+	err := s.db.Raw(taggedPagesQuery, map[string]interface{}{"board": boardID, "names": names}).Scan(&pageIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tagged pages: %w", err)
+	}
+
+	return taggedPageSet(pageIDs, nil, nil), nil
+}
+
+// pinnedPageIDsWithTags finds the tagged pages of a pinned snapshot, going by the snapshot's
+// elements since their pages are not in the live tables
+func (s *TagService) pinnedPageIDsWithTags(boardID uuid.UUID, names []string, scope *pinnedTagScope) (map[uuid.UUID]bool, error) {
+	args := scope.namedArgs(boardID)
+	args["names"] = names
+
+	var pageIDs []uuid.UUID
+	err := s.db.Raw(`
+		SELECT pt.page_id FROM page_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE t.board_id = @board AND t.name IN @names AND pt.page_id IN @pages`,
+		args).Scan(&pageIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tagged pages: %w", err)
+	}
+
+	var elementIDs []uuid.UUID
+	err = s.db.Raw(`
+		SELECT et.element_id FROM element_tags et
+		JOIN tags t ON t.id = et.tag_id
+		WHERE t.board_id = @board AND t.name IN @names AND et.element_id IN @elements`,
+		args).Scan(&elementIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tagged elements: %w", err)
+	}
+
+	return taggedPageSet(pageIDs, elementIDs, scope.elementPages), nil
+}
+
+// taggedPageSet collects tagged pages and the pages of tagged elements, looked up in
+// elementPages; elements without a known page are ignored
+func taggedPageSet(pageIDs, elementIDs []uuid.UUID, elementPages map[uuid.UUID]uuid.UUID) map[uuid.UUID]bool {
+	matches := make(map[uuid.UUID]bool, len(pageIDs)+len(elementIDs))
+	for _, pageID := range pageIDs {
+		matches[pageID] = true
+	}
+	for _, elementID := range elementIDs {
+		if pageID, ok := elementPages[elementID]; ok {
+			matches[pageID] = true
+		}
+	}
+	return matches
+}
+
+// TagCounts counts how many of the given pages, and elements on them, carry each of a board's tags.
+// With a pinned snapshot the elements on the pages are those of the snapshot. Tags used on none
+// of them are left out.
+func (s *TagService) TagCounts(boardID uuid.UUID, pageIDs []uuid.UUID, pinned *models.Board) ([]dto.TagCount, error) {
+	counts := []dto.TagCount{}
+	if len(pageIDs) == 0 {
+		return counts, nil
+	}
+
+	args := map[string]interface{}{"board": boardID, "pages": pageIDs}
+	elementCount := liveTagElementCount
+	if pinned != nil {
+		args["elements"] = newPinnedTagScope(pinned.Pages).elementsOn(pageIDs)
+		elementCount = pinnedTagElementCount
+	}
+
+	if err := s.db.Raw(fmt.Sprintf(tagCountsQuery, elementCount), args).Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	return usedTagCounts(counts), nil
+}
+
+// usedTagCounts drops the tags used on none of the counted pages and elements
+func usedTagCounts(counts []dto.TagCount) []dto.TagCount {
+	used := counts[:0]
+	for _, count := range counts {
+		if count.PageCount+count.ElementCount > 0 {
+			used = append(used, count)
+		}
+	}
+	return used
+}
+
+// pinnedTagScope holds the pages and elements of a pinned snapshot, which tag usage is limited to
+// when readers see the snapshot instead of the live board
+type pinnedTagScope struct {
+	pageIDs      []uuid.UUID // by date, then order index, as tagged pages are listed
+	elementIDs   []uuid.UUID
+	elementPages map[uuid.UUID]uuid.UUID
+}
+
+func newPinnedTagScope(pages []models.Page) *pinnedTagScope {
+	ordered := make([]models.Page, len(pages))
+	copy(ordered, pages)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].Date.Equal(ordered[j].Date) {
+			return ordered[i].Date.Before(ordered[j].Date)
+		}
+		return ordered[i].OrderIdx < ordered[j].OrderIdx
+	})
+
+	scope := &pinnedTagScope{
+		pageIDs:      make([]uuid.UUID, 0, len(ordered)),
+		elementIDs:   []uuid.UUID{},
+		elementPages: make(map[uuid.UUID]uuid.UUID),
+	}
+	for _, page := range ordered {
+		scope.pageIDs = append(scope.pageIDs, page.ID)
+		for _, element := range page.Elements {
+			scope.elementIDs = append(scope.elementIDs, element.ID)
+			scope.elementPages[element.ID] = page.ID
+		}
+	}
+
+	return scope
+}
+
+// namedArgs returns the board, @pages and @elements arguments of the pinned tag queries
+func (s *pinnedTagScope) namedArgs(boardID uuid.UUID) map[string]interface{} {
+	return map[string]interface{}{"board": boardID, "pages": s.pageIDs, "elements": s.elementIDs}
+}
+
+// orderPages returns the given pages of the snapshot in listing order, dropping any not in it
+func (s *pinnedTagScope) orderPages(pageIDs []uuid.UUID) []uuid.UUID {
+	wanted := make(map[uuid.UUID]bool, len(pageIDs))
+	for _, pageID := range pageIDs {
+		wanted[pageID] = true
+	}
+
+	ordered := []uuid.UUID{}
+	for _, pageID := range s.pageIDs {
+		if wanted[pageID] {
+			ordered = append(ordered, pageID)
+		}
+	}
+	return ordered
+}
+
+// elementsOn returns the elements of the snapshot on the given pages
+func (s *pinnedTagScope) elementsOn(pageIDs []uuid.UUID) []uuid.UUID {
+	onPages := make(map[uuid.UUID]bool, len(pageIDs))
+	for _, pageID := range pageIDs {
+		onPages[pageID] = true
+	}
+
+	elementIDs := []uuid.UUID{}
+	for _, elementID := range s.elementIDs {
+		if onPages[s.elementPages[elementID]] {
+			elementIDs = append(elementIDs, elementID)
+		}
+	}
+	return elementIDs
+}
+
+// getTagUsage loads a single tag of a board with its usage counts
+func (s *TagService) getTagUsage(boardID, tagID uuid.UUID) (*TagUsage, error) {
+	var tags []TagUsage
+	if err := s.db.Raw(tagUsageQuery+" WHERE tags.id = ? AND tags.board_id = ?", tagID, boardID).Scan(&tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, utils.ErrNotFound
+	}
+
+	return &tags[0], nil
+}
+
+// ensureTag checks that a tag belongs to a board
+func (s *TagService) ensureTag(boardID, tagID uuid.UUID) error {
+	var count int64
+	if err := s.db.Model(&models.Tag{}).Where("id = ? AND board_id = ?", tagID, boardID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to validate tag: %w", err)
+	}
+	if count == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// ensureTagNameFree checks that no other tag of the board uses a name
+func ensureTagNameFree(tx *gorm.DB, boardID uuid.UUID, name string, exceptID uuid.UUID) error {
+	var count int64
+	err := tx.Model(&models.Tag{}).
+		Where("board_id = ? AND name = ? AND id <> ?", boardID, name, exceptID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check tag name: %w", err)
+	}
+	if count > 0 {
+		return ErrTagExists
+	}
+
+	return nil
+}
+
+// NormalizeTagName lowercases a tag name and collapses its whitespace
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ParseTagFilter splits a comma-separated tag filter into normalized, de-duplicated names
+func ParseTagFilter(filter string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(filter, ",") {
+		name := NormalizeTagName(part)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
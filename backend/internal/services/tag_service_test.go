@@ -0,0 +1,238 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	tests := []struct {
+		filter   string
+		expected []string
+	}{
+		{"", nil},
+		{"travel", []string{"travel"}},
+		{" Travel , gratitude,travel,, ", []string{"travel", "gratitude"}},
+		{"Road   Trip", []string{"road trip"}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseTagFilter(tt.filter); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseTagFilter(%q): expected %v, got %v", tt.filter, tt.expected, got)
+		}
+	}
+}
+
+func TestPinnedTagScope(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	elementA := models.Element{ID: uuid.New()}
+	elementB := models.Element{ID: uuid.New()}
+	elementC := models.Element{ID: uuid.New()}
+	late := models.Page{ID: uuid.New(), Date: day.AddDate(0, 0, 1), Elements: []models.Element{elementC}}
+	second := models.Page{ID: uuid.New(), Date: day, OrderIdx: 1, Elements: []models.Element{elementB}}
+	first := models.Page{ID: uuid.New(), Date: day, Elements: []models.Element{elementA}}
+
+	scope := newPinnedTagScope([]models.Page{late, second, first})
+
+	expectedPages := []uuid.UUID{first.ID, second.ID, late.ID}
+	if !reflect.DeepEqual(scope.pageIDs, expectedPages) {
+		t.Errorf("Expected pages by date and order, got %v", scope.pageIDs)
+	}
+	if len(scope.elementIDs) != 3 || scope.elementPages[elementB.ID] != second.ID {
+		t.Errorf("Expected every element with its page, got %v %v", scope.elementIDs, scope.elementPages)
+	}
+
+	ordered := scope.orderPages([]uuid.UUID{late.ID, uuid.New(), first.ID})
+	if !reflect.DeepEqual(ordered, []uuid.UUID{first.ID, late.ID}) {
+		t.Errorf("Expected snapshot pages in listing order, got %v", ordered)
+	}
+
+	onPages := scope.elementsOn([]uuid.UUID{second.ID, late.ID})
+	if !reflect.DeepEqual(onPages, []uuid.UUID{elementB.ID, elementC.ID}) {
+		t.Errorf("Expected the elements on the given pages, got %v", onPages)
+	}
+
+	empty := newPinnedTagScope(nil)
+	if len(empty.orderPages(nil)) != 0 || empty.elementIDs == nil {
+		t.Error("Expected an empty scope for a snapshot without pages")
+	}
+}
+
+func TestTagsSkipTrashed(t *testing.T) {
+	db := newTestDB(t, &models.Page{}, &models.Element{}, &models.Tag{}, &models.PageTag{}, &models.ElementTag{})
+	service := NewTagService(db)
+	boardID := uuid.New()
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tag := models.Tag{BoardID: boardID, Name: "trip"}
+	if err := db.Create(&tag).Error; err != nil {
+		t.Fatalf("Failed to create tag: %v", err)
+	}
+
+	newPage := func(trashed bool) models.Page {
+		t.Helper()
+		page := models.Page{BoardID: boardID, Title: "Day", Date: day}
+		if err := db.Create(&page).Error; err != nil {
+			t.Fatalf("Failed to create page: %v", err)
+		}
+		if trashed {
+			if err := db.Delete(&page).Error; err != nil {
+				t.Fatalf("Failed to trash page: %v", err)
+			}
+		}
+		return page
+	}
+	newElement := func(page models.Page, trashed bool) models.Element {
+		t.Helper()
+		element := models.Element{PageID: page.ID, Kind: "text", Visible: true}
+		if err := db.Create(&element).Error; err != nil {
+			t.Fatalf("Failed to create element: %v", err)
+		}
+		if trashed {
+			if err := db.Delete(&element).Error; err != nil {
+				t.Fatalf("Failed to trash element: %v", err)
+			}
+		}
+		return element
+	}
+	tagPage := func(page models.Page) {
+		t.Helper()
+		if err := db.Create(&models.PageTag{PageID: page.ID, TagID: tag.ID}).Error; err != nil {
+			t.Fatalf("Failed to tag page: %v", err)
+		}
+	}
+	tagElement := func(element models.Element) {
+		t.Helper()
+		if err := db.Create(&models.ElementTag{ElementID: element.ID, TagID: tag.ID}).Error; err != nil {
+			t.Fatalf("Failed to tag element: %v", err)
+		}
+	}
+
+	// Tagged live page and live element count; everything trashed, or on a trashed page, does not
+	livePage := newPage(false)
+	tagPage(livePage)
+	tagPage(newPage(true))
+
+	elementPage := newPage(false)
+	liveElement := newElement(elementPage, false)
+	tagElement(liveElement)
+	tagElement(newElement(elementPage, true))
+
+	trashedElementPage := newPage(false)
+	tagElement(newElement(trashedElementPage, true))
+	tagElement(newElement(newPage(true), false))
+
+	pageIDs, err := service.PageIDsWithTags(boardID, []string{"trip"}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := map[uuid.UUID]bool{livePage.ID: true, elementPage.ID: true}
+	if !reflect.DeepEqual(pageIDs, expected) {
+		t.Errorf("Expected only live tagged pages %v, got %v", expected, pageIDs)
+	}
+
+	tags, err := service.ListTags(boardID, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tags) != 1 || tags[0].PageCount != 1 || tags[0].ElementCount != 1 {
+		t.Errorf("Expected 1 live page and 1 live element, got %+v", tags)
+	}
+
+	_, taggedPages, taggedElements, err := service.GetTag(boardID, tag.ID, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(taggedPages, []uuid.UUID{livePage.ID}) || !reflect.DeepEqual(taggedElements, []uuid.UUID{liveElement.ID}) {
+		t.Errorf("Expected only the live page and element, got %v and %v", taggedPages, taggedElements)
+	}
+
+	counts, err := service.TagCounts(boardID, []uuid.UUID{livePage.ID, elementPage.ID, trashedElementPage.ID}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(counts) != 1 || counts[0].PageCount != 1 || counts[0].ElementCount != 1 {
+		t.Errorf("Expected 1 page and 1 live element, got %+v", counts)
+	}
+
+	// Once the last live use is trashed the tag is left out of the counts
+	if err := db.Delete(&liveElement).Error; err != nil {
+		t.Fatalf("Failed to trash element: %v", err)
+	}
+	counts, err = service.TagCounts(boardID, []uuid.UUID{elementPage.ID, trashedElementPage.ID}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("Expected no counts for trashed uses, got %+v", counts)
+	}
+}
+
+func TestTaggedPageSet(t *testing.T) {
+	pageA := uuid.New()
+	pageB := uuid.New()
+	pageC := uuid.New()
+	element := uuid.New()
+	unknownElement := uuid.New()
+
+	tests := []struct {
+		name         string
+		pageIDs      []uuid.UUID
+		elementIDs   []uuid.UUID
+		elementPages map[uuid.UUID]uuid.UUID
+		expected     map[uuid.UUID]bool
+	}{
+		{
+			name:     "Tagged pages",
+			pageIDs:  []uuid.UUID{pageA, pageB, pageA},
+			expected: map[uuid.UUID]bool{pageA: true, pageB: true},
+		},
+		{
+			name:         "Pages of tagged elements",
+			pageIDs:      []uuid.UUID{pageA},
+			elementIDs:   []uuid.UUID{element},
+			elementPages: map[uuid.UUID]uuid.UUID{element: pageC},
+			expected:     map[uuid.UUID]bool{pageA: true, pageC: true},
+		},
+		{
+			name:         "Elements without a known page are ignored",
+			elementIDs:   []uuid.UUID{unknownElement},
+			elementPages: map[uuid.UUID]uuid.UUID{element: pageC},
+			expected:     map[uuid.UUID]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taggedPageSet(tt.pageIDs, tt.elementIDs, tt.elementPages)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUsedTagCounts(t *testing.T) {
+	counts := []dto.TagCount{
+		{Name: "gratitude", PageCount: 2},
+		{Name: "travel", ElementCount: 1},
+		{Name: "unused"},
+		{Name: "work", PageCount: 1, ElementCount: 3},
+	}
+
+	used := usedTagCounts(counts)
+
+	var names []string
+	for _, count := range used {
+		names = append(names, count.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"gratitude", "travel", "work"}) {
+		t.Errorf("Expected only used tags in order, got %v", names)
+	}
+}
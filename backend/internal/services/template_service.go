@@ -0,0 +1,233 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"junk-journal-board/internal/dto"
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ErrTemplateNotFound is returned when a page is created from a template the board cannot use
+var ErrTemplateNotFound = errors.New("template not found")
+
+type TemplateService struct {
+	db *gorm.DB
+}
+
+func NewTemplateService(db *gorm.DB) *TemplateService {
+	return &TemplateService{db: db}
+}
+
+// CreateTemplate saves the element layout of a page as a named template.
+// Global templates are usable on every board; otherwise the template is scoped to the page's board.
+func (s *TemplateService) CreateTemplate(boardID, pageID uuid.UUID, name, description string, global bool) (*models.PageTemplate, error) {
+	var page models.Page
+	err := s.db.Preload("Elements", func(db *gorm.DB) *gorm.DB {
+		return db.Order("z ASC")
+	}).First(&page, "id = ? AND board_id = ?", pageID, boardID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+
+	elements := templateElementsFromPage(page.Elements)
+	content, err := json.Marshal(elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template: %w", err)
+	}
+
+	template := &models.PageTemplate{
+		OwnerBoardID: &boardID,
+		Name:         name,
+		Description:  description,
+		ElementCount: len(elements),
+		Elements:     datatypes.JSON(content),
+	}
+	if !global {
+		template.BoardID = &boardID
+	}
+
+	if err := s.db.Create(template).Error; err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	return template, nil
+}
+
+// ListTemplates returns the templates usable on a board, or only the global ones when boardID is nil
+func (s *TemplateService) ListTemplates(boardID *uuid.UUID) ([]models.PageTemplate, error) {
+	var templates []models.PageTemplate
+	err := scopeTemplates(s.db.Omit("elements"), boardID).
+		Order("name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// GetTemplate retrieves a template usable on a board together with its element layout
+func (s *TemplateService) GetTemplate(boardID *uuid.UUID, templateID uuid.UUID) (*models.PageTemplate, []dto.TemplateElement, error) {
+	template, err := findTemplate(s.db, boardID, templateID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	elements, err := decodeTemplateElements(template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return template, elements, nil
+}
+
+// DeleteTemplate deletes a template scoped to the board or a global template the board created
+func (s *TemplateService) DeleteTemplate(boardID, templateID uuid.UUID) error {
+	result := s.db.Where("id = ?", templateID).
+		Where("board_id = ? OR (board_id IS NULL AND owner_board_id = ?)", boardID, boardID).
+		Delete(&models.PageTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrNotFound
+	}
+
+	return nil
+}
+
+// instantiateTemplate creates a template's elements on a page with fresh IDs, stacked above existing elements
+func instantiateTemplate(tx *gorm.DB, boardID, templateID, pageID uuid.UUID) ([]models.Element, error) {
+	template, err := findTemplate(tx, &boardID, templateID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	templateElements, err := decodeTemplateElements(template)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextZ int
+	err = tx.Model(&models.Element{}).
+		Where("page_id = ?", pageID).
+		Select("COALESCE(MAX(z), -1) + 1").
+		Scan(&nextZ).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next z-index: %w", err)
+	}
+
+	elements := buildTemplateElements(pageID, templateElements, nextZ)
+	for i := range elements {
+		// Select all columns so false/zero values are not replaced by column defaults
+		if err := tx.Select("*").Create(&elements[i]).Error; err != nil {
+			return nil, fmt.Errorf("failed to create element: %w", err)
+		}
+		if err := recordElementRevision(tx, models.ElementActionCreate, nil, &elements[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Templates saved from another board may point at its uploads
+	if err := recordUploadReferences(tx, boardID, elements); err != nil {
+		return nil, err
+	}
+
+	return elements, nil
+}
+
+// findTemplate loads a template that is global or scoped to the given board
+func findTemplate(db *gorm.DB, boardID *uuid.UUID, templateID uuid.UUID) (*models.PageTemplate, error) {
+	var template models.PageTemplate
+	if err := scopeTemplates(db, boardID).First(&template, "id = ?", templateID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// scopeTemplates limits a query to global templates and, when given, those of a board
+func scopeTemplates(db *gorm.DB, boardID *uuid.UUID) *gorm.DB {
+	if boardID == nil {
+		return db.Where("board_id IS NULL")
+	}
+	return db.Where("board_id IS NULL OR board_id = ?", *boardID)
+}
+
+// decodeTemplateElements decodes the element layout stored in a template
+func decodeTemplateElements(template *models.PageTemplate) ([]dto.TemplateElement, error) {
+	var elements []dto.TemplateElement
+	if err := json.Unmarshal(template.Elements, &elements); err != nil {
+		return nil, fmt.Errorf("failed to decode template %s: %w", template.ID, err)
+	}
+
+	return elements, nil
+}
+
+// templateElementsFromPage captures a page's elements in stacking order with z indexes starting at zero
+func templateElementsFromPage(elements []models.Element) []dto.TemplateElement {
+	templateElements := make([]dto.TemplateElement, len(elements))
+	for i, element := range elements {
+		templateElements[i] = dto.TemplateElement{
+			Kind:     element.Kind,
+			X:        element.X,
+			Y:        element.Y,
+			W:        element.W,
+			H:        element.H,
+			Rotation: element.Rotation,
+			Z:        i,
+			Visible:  element.Visible,
+			Locked:   element.Locked,
+			Payload:  element.Payload,
+		}
+	}
+
+	return templateElements
+}
+
+// buildTemplateElements turns a template layout into new elements for a page, keeping the
+// template's stacking order and assigning z indexes from startZ upwards
+func buildTemplateElements(pageID uuid.UUID, templateElements []dto.TemplateElement, startZ int) []models.Element {
+	ordered := make([]dto.TemplateElement, len(templateElements))
+	copy(ordered, templateElements)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Z < ordered[j].Z
+	})
+
+	elements := make([]models.Element, len(ordered))
+	for i, templateElement := range ordered {
+		elements[i] = models.Element{
+			ID:       uuid.New(),
+			PageID:   pageID,
+			Kind:     templateElement.Kind,
+			X:        templateElement.X,
+			Y:        templateElement.Y,
+			W:        templateElement.W,
+			H:        templateElement.H,
+			Rotation: templateElement.Rotation,
+			Z:        startZ + i,
+			Visible:  templateElement.Visible,
+			Locked:   templateElement.Locked,
+			Payload:  templateElement.Payload,
+		}
+	}
+
+	return elements
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"junk-journal-board/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func TestTemplateElementsRoundTrip(t *testing.T) {
+	sourcePageID := uuid.New()
+	source := []models.Element{
+		{ID: uuid.New(), PageID: sourcePageID, Kind: "shape", X: 5, Z: 3, Visible: true},
+		{ID: uuid.New(), PageID: sourcePageID, Kind: "text", X: 10, Z: 7, Visible: false, Locked: true, Payload: datatypes.JSON(`{"content":"Today"}`)},
+	}
+
+	templateElements := templateElementsFromPage(source)
+	if templateElements[0].Z != 0 || templateElements[1].Z != 1 {
+		t.Fatalf("Expected template z indexes 0 and 1, got %d and %d", templateElements[0].Z, templateElements[1].Z)
+	}
+
+	// Shuffle the stored layout to check that stacking order comes from z, not position
+	templateElements[0], templateElements[1] = templateElements[1], templateElements[0]
+
+	pageID := uuid.New()
+	elements := buildTemplateElements(pageID, templateElements, 4)
+	if len(elements) != 2 {
+		t.Fatalf("Expected 2 elements, got %d", len(elements))
+	}
+
+	for i, element := range elements {
+		if element.PageID != pageID {
+			t.Errorf("Element %d: expected page %s, got %s", i, pageID, element.PageID)
+		}
+		if element.ID == uuid.Nil || element.ID == source[i].ID {
+			t.Errorf("Element %d: expected a fresh ID, got %s", i, element.ID)
+		}
+		if element.Z != 4+i {
+			t.Errorf("Element %d: expected z %d, got %d", i, 4+i, element.Z)
+		}
+		if element.Kind != source[i].Kind || element.X != source[i].X {
+			t.Errorf("Element %d: expected %s at x=%v, got %s at x=%v", i, source[i].Kind, source[i].X, element.Kind, element.X)
+		}
+		if element.Visible != source[i].Visible || element.Locked != source[i].Locked {
+			t.Errorf("Element %d: visibility or lock was not preserved", i)
+		}
+	}
+
+	if string(elements[1].Payload) != `{"content":"Today"}` {
+		t.Errorf("Expected payload to be copied, got %s", elements[1].Payload)
+	}
+}
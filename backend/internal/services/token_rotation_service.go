@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxTokenGracePeriod caps how long a retired board token may keep working
+const MaxTokenGracePeriod = 7 * 24 * time.Hour
+
+// Conditions matching a board's current token, or a retired one still inside its grace period.
+// The edit condition takes the token hash twice, the public one the token and its hash; both
+// are followed by the current time.
+const (
+	editTokenCondition   = "(boards.edit_token_hash = ? OR EXISTS (SELECT 1 FROM board_token_rotations r WHERE r.board_id = boards.id AND r.kind = 'edit' AND r.retired_token_hash = ? AND r.grace_until > ?))"
+	publicTokenCondition = "(boards.public_token = ? OR EXISTS (SELECT 1 FROM board_token_rotations r WHERE r.board_id = boards.id AND r.kind = 'public' AND r.retired_token_hash = ? AND r.grace_until > ?))"
+)
+
+// TokenRotationService replaces board tokens and keeps the audit trail of rotations
+type TokenRotationService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewTokenRotationService(db *gorm.DB) *TokenRotationService {
+	return &TokenRotationService{
+		db:  db,
+		now: time.Now,
+	}
+}
+
+// RotateToken replaces the edit or public token of a board and records the rotation. When
+// editToken is set it must be the board's current edit token; retired tokens still in their
+// grace period cannot rotate. The old token stops working at once unless gracePeriod is positive.
+// Rotating the edit token also drops the board from every keyring, since keyrings list edit tokens.
+func (s *TokenRotationService) RotateToken(boardID uuid.UUID, kind string, editToken *uuid.UUID, gracePeriod time.Duration, reason string, userID *uuid.UUID, clientIP string) (*models.Board, *models.BoardTokenRotation, error) {
+	var board models.Board
+	var rotation *models.BoardTokenRotation
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		board = models.Board{}
+		if err := s.lockBoard(tx, boardID, editToken, &board); err != nil {
+			return err
+		}
+
+		now := s.now()
+		rotation = &models.BoardTokenRotation{
+			BoardID:   boardID,
+			Kind:      kind,
+			Reason:    reason,
+			RotatedBy: userID,
+			ClientIP:  clientIP,
+		}
+
+		// Earlier tokens of this kind stop working; only the token just retired gets a grace period
+		if err := s.endGracePeriods(tx, boardID, kind, now); err != nil {
+			return err
+		}
+		updates := rotateBoardToken(&board, rotation, editToken, uuid.New(), gracePeriod, now)
+
+		if err := tx.Model(&board).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to rotate board token: %w", err)
+		}
+		if err := tx.Create(rotation).Error; err != nil {
+			return fmt.Errorf("failed to record token rotation: %w", err)
+		}
+
+		if kind == models.BoardTokenEdit {
+			if err := tx.Where("board_id = ?", boardID).Delete(&models.KeyringBoard{}).Error; err != nil {
+				return fmt.Errorf("failed to remove board from keyrings: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &board, rotation, nil
+}
+
+// rotateBoardToken swaps the token of the rotation's kind for newToken on the board, records the
+// hash of the retired token and its grace period, capped at MaxTokenGracePeriod, on the rotation,
+// and returns the column updates to store. editToken, when set, is the caller's current edit token.
+func rotateBoardToken(board *models.Board, rotation *models.BoardTokenRotation, editToken *uuid.UUID, newToken uuid.UUID, gracePeriod time.Duration, now time.Time) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if rotation.Kind == models.BoardTokenEdit {
+		rotation.RetiredTokenHash = board.EditTokenHash
+		board.EditToken = newToken
+		board.EditTokenHash = utils.HashToken(newToken)
+		updates["edit_token_hash"] = board.EditTokenHash
+	} else {
+		rotation.RetiredTokenHash = utils.HashToken(board.PublicToken)
+		board.PublicToken = newToken
+		updates["public_token"] = newToken
+		// The caller's edit token is still current and can be handed back
+		if editToken != nil {
+			board.EditToken = *editToken
+		}
+	}
+
+	if gracePeriod > MaxTokenGracePeriod {
+		gracePeriod = MaxTokenGracePeriod
+	}
+	if gracePeriod > 0 {
+		graceUntil := now.Add(gracePeriod)
+		rotation.GraceUntil = &graceUntil
+	}
+
+	return updates
+}
+
+// EndGracePeriods makes every retired token of a board stop working now and returns how many
+// were still inside their grace period. editToken follows the same rules as in RotateToken.
+func (s *TokenRotationService) EndGracePeriods(boardID uuid.UUID, editToken *uuid.UUID) (int64, error) {
+	var revoked int64
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var board models.Board
+		if err := s.lockBoard(tx, boardID, editToken, &board); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.BoardTokenRotation{}).
+			Where("board_id = ? AND grace_until > ?", boardID, s.now()).
+			Update("grace_until", s.now())
+		if result.Error != nil {
+			return fmt.Errorf("failed to end token grace periods: %w", result.Error)
+		}
+		revoked = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
+// ListRotations returns the token rotations of a board, newest first
+func (s *TokenRotationService) ListRotations(boardID uuid.UUID) ([]models.BoardTokenRotation, error) {
+	var rotations []models.BoardTokenRotation
+	if err := s.db.Where("board_id = ?", boardID).Order("created_at DESC").Find(&rotations).Error; err != nil {
+		return nil, fmt.Errorf("failed to list token rotations: %w", err)
+	}
+
+	return rotations, nil
+}
+
+// GracePeriodEnd returns when a retired token stops working, or nil when the token is not
+// a retired token inside its grace period
+func (s *TokenRotationService) GracePeriodEnd(token uuid.UUID) (*time.Time, error) {
+	var rotation models.BoardTokenRotation
+	err := s.db.Select("grace_until").
+		Where("retired_token_hash = ? AND grace_until > ?", utils.HashToken(token), s.now()).
+		Order("grace_until DESC").
+		First(&rotation).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to look up retired token: %w", err)
+	}
+
+	return rotation.GraceUntil, nil
+}
+
+// lockBoard loads and locks a board for a token change, checking editToken against the
+// board's current edit token when it is set
+func (s *TokenRotationService) lockBoard(tx *gorm.DB, boardID uuid.UUID, editToken *uuid.UUID, board *models.Board) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", boardID).
+		First(board).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("failed to get board: %w", err)
+	}
+
+	if editToken != nil && utils.HashToken(*editToken) != board.EditTokenHash {
+		return utils.ErrUnauthorized
+	}
+
+	return nil
+}
+
+// endGracePeriods makes the retired tokens of one kind stop working at the given time
+func (s *TokenRotationService) endGracePeriods(tx *gorm.DB, boardID uuid.UUID, kind string, now time.Time) error {
+	err := tx.Model(&models.BoardTokenRotation{}).
+		Where("board_id = ? AND kind = ? AND grace_until > ?", boardID, kind, now).
+		Update("grace_until", now).Error
+	if err != nil {
+		return fmt.Errorf("failed to end token grace periods: %w", err)
+	}
+
+	return nil
+}
+
+// IsInGracePeriod reports whether a rotation's retired token still works
+func (s *TokenRotationService) IsInGracePeriod(rotation *models.BoardTokenRotation) bool {
+	return rotation.GraceUntil != nil && rotation.GraceUntil.After(s.now())
+}
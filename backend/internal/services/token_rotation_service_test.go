@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestIsInGracePeriod(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		graceUntil *time.Time
+		expected   bool
+	}{
+		{
+			name:       "Rotation without grace period revokes at once",
+			graceUntil: nil,
+			expected:   false,
+		},
+		{
+			name:       "Retired token works until the grace period ends",
+			graceUntil: &later,
+			expected:   true,
+		},
+		{
+			name:       "Ended grace period",
+			graceUntil: &earlier,
+			expected:   false,
+		},
+		{
+			name:       "Grace period ending now",
+			graceUntil: &now,
+			expected:   false,
+		},
+	}
+
+	service := NewTokenRotationService(nil)
+	service.now = func() time.Time { return now }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rotation := &models.BoardTokenRotation{GraceUntil: tt.graceUntil}
+			if result := service.IsInGracePeriod(rotation); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRotateBoardToken(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	oldEdit := uuid.New()
+	oldPublic := uuid.New()
+	newToken := uuid.New()
+
+	t.Run("Edit token without grace period", func(t *testing.T) {
+		board := &models.Board{EditTokenHash: utils.HashToken(oldEdit), PublicToken: oldPublic}
+		rotation := &models.BoardTokenRotation{Kind: models.BoardTokenEdit}
+
+		updates := rotateBoardToken(board, rotation, &oldEdit, newToken, 0, now)
+
+		if rotation.RetiredTokenHash != utils.HashToken(oldEdit) {
+			t.Errorf("Expected the old edit token's hash to be retired, got %s", rotation.RetiredTokenHash)
+		}
+		if board.EditToken != newToken || board.EditTokenHash != utils.HashToken(newToken) {
+			t.Errorf("Expected the new edit token on the board, got %+v", board)
+		}
+		if updates["edit_token_hash"] != utils.HashToken(newToken) || len(updates) != 1 {
+			t.Errorf("Expected only the edit token hash to be stored, got %v", updates)
+		}
+		if board.PublicToken != oldPublic {
+			t.Error("Expected the public token to be left alone")
+		}
+		if rotation.GraceUntil != nil {
+			t.Errorf("Expected the old token to stop working at once, got grace until %v", rotation.GraceUntil)
+		}
+	})
+
+	t.Run("Public token with grace period", func(t *testing.T) {
+		board := &models.Board{EditTokenHash: utils.HashToken(oldEdit), PublicToken: oldPublic}
+		rotation := &models.BoardTokenRotation{Kind: models.BoardTokenPublic}
+
+		updates := rotateBoardToken(board, rotation, &oldEdit, newToken, time.Hour, now)
+
+		if rotation.RetiredTokenHash != utils.HashToken(oldPublic) {
+			t.Errorf("Expected the old public token's hash to be retired, got %s", rotation.RetiredTokenHash)
+		}
+		if board.PublicToken != newToken || updates["public_token"] != newToken || len(updates) != 1 {
+			t.Errorf("Expected only the new public token to be stored, got %v", updates)
+		}
+		if board.EditToken != oldEdit || board.EditTokenHash != utils.HashToken(oldEdit) {
+			t.Error("Expected the caller's edit token to be kept and handed back")
+		}
+		if rotation.GraceUntil == nil || !rotation.GraceUntil.Equal(now.Add(time.Hour)) {
+			t.Errorf("Expected a grace period of an hour, got %v", rotation.GraceUntil)
+		}
+	})
+
+	t.Run("Grace period is capped", func(t *testing.T) {
+		board := &models.Board{PublicToken: oldPublic}
+		rotation := &models.BoardTokenRotation{Kind: models.BoardTokenPublic}
+
+		rotateBoardToken(board, rotation, nil, newToken, 30*24*time.Hour, now)
+
+		if rotation.GraceUntil == nil || !rotation.GraceUntil.Equal(now.Add(MaxTokenGracePeriod)) {
+			t.Errorf("Expected the grace period to be capped at %v, got %v", MaxTokenGracePeriod, rotation.GraceUntil)
+		}
+		if board.EditToken != uuid.Nil {
+			t.Error("Expected no edit token to be handed back to owners")
+		}
+	})
+}
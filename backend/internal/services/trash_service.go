@@ -0,0 +1,377 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"junk-journal-board/internal/models"
+	"junk-journal-board/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Trash retention defaults
+const (
+	defaultTrashRetention     = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval = time.Hour
+)
+
+// ErrParentTrashed is returned when restoring an item whose page or board is still in the trash
+var ErrParentTrashed = errors.New("parent is in the trash")
+
+// BoardTrash lists the trashed items of a board
+type BoardTrash struct {
+	// Board is set when the board itself is in the trash
+	Board    *models.Board
+	Pages    []models.Page
+	Elements []models.Element
+}
+
+// PurgeResult counts what a purge run removed for good
+type PurgeResult struct {
+	Boards   int64
+	Pages    int64
+	Elements int64
+}
+
+type TrashService struct {
+	db            *gorm.DB
+	uploadService *UploadService
+	retention     time.Duration
+	now           func() time.Time
+}
+
+func NewTrashService(db *gorm.DB) *TrashService {
+	retention := defaultTrashRetention
+	if value := os.Getenv("TRASH_RETENTION_DAYS"); value != "" {
+		if days, err := strconv.Atoi(value); err == nil && days > 0 {
+			retention = time.Duration(days) * 24 * time.Hour
+		}
+	}
+
+	return &TrashService{
+		db:            db,
+		uploadService: NewUploadService(),
+		retention:     retention,
+		now:           time.Now,
+	}
+}
+
+// PurgeAt returns when an item trashed at the given time will be removed for good
+func (s *TrashService) PurgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(s.retention)
+}
+
+// ValidateEditAccess validates an edit token for a board, including boards in the trash and
+// retired edit tokens inside their grace period
+func (s *TrashService) ValidateEditAccess(boardID, editToken uuid.UUID) error {
+	var board models.Board
+	if err := s.db.Unscoped().Select("id").First(&board, "id = ?", boardID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return utils.ErrNotFound
+		}
+		return fmt.Errorf("failed to validate board edit access: %w", err)
+	}
+
+	editTokenHash := utils.HashToken(editToken)
+	var count int64
+	err := s.db.Unscoped().Model(&models.Board{}).
+		Where("id = ? AND "+editTokenCondition, boardID, editTokenHash, editTokenHash, s.now()).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to validate board edit access: %w", err)
+	}
+	if count == 0 {
+		return utils.ErrUnauthorized
+	}
+
+	return nil
+}
+
+// ListTrash returns the trashed board, pages and elements of a board, most recently deleted first.
+// Elements of trashed pages are not listed separately since they come back with their page.
+func (s *TrashService) ListTrash(boardID uuid.UUID) (*BoardTrash, error) {
+	trash := &BoardTrash{}
+
+	var board models.Board
+	if err := s.db.Unscoped().First(&board, "id = ?", boardID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, utils.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+	if board.DeletedAt.Valid {
+		trash.Board = &board
+	}
+
+	err := s.db.Unscoped().
+		Where("board_id = ? AND deleted_at IS NOT NULL", boardID).
+		Order("deleted_at DESC").
+		Find(&trash.Pages).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trashed pages: %w", err)
+	}
+
+	err = s.db.Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Where("page_id IN (?)", s.db.Model(&models.Page{}).Select("id").Where("board_id = ?", boardID)).
+		Order("deleted_at DESC").
+		Find(&trash.Elements).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trashed elements: %w", err)
+	}
+
+	return trash, nil
+}
+
+// RestoreBoard takes a board out of the trash
+func (s *TrashService) RestoreBoard(boardID uuid.UUID) (*models.Board, error) {
+	result := s.db.Unscoped().Model(&models.Board{}).
+		Where("id = ? AND deleted_at IS NOT NULL", boardID).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to restore board: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, utils.ErrNotFound
+	}
+
+	var board models.Board
+	if err := s.db.Preload("Pages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("order_idx ASC")
+	}).First(&board, "id = ?", boardID).Error; err != nil {
+		return nil, fmt.Errorf("failed to reload board: %w", err)
+	}
+
+	return &board, nil
+}
+
+// RestorePage takes a page out of the trash and appends it after the board's other pages
+func (s *TrashService) RestorePage(boardID, pageID uuid.UUID) (*models.Page, error) {
+	var page models.Page
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureBoardActive(tx, boardID); err != nil {
+			return err
+		}
+
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND board_id = ? AND deleted_at IS NOT NULL", pageID, boardID).
+			First(&page).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			return fmt.Errorf("failed to get trashed page: %w", err)
+		}
+
+		// Other pages may have taken its position in the meantime
+		var maxOrder int
+		err = tx.Model(&models.Page{}).
+			Where("board_id = ?", boardID).
+			Select("COALESCE(MAX(order_idx), -1) + 1").
+			Scan(&maxOrder).Error
+		if err != nil {
+			return fmt.Errorf("failed to get next order index: %w", err)
+		}
+
+		err = tx.Unscoped().Model(&models.Page{}).Where("id = ?", pageID).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"order_idx":  maxOrder,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to restore page: %w", err)
+		}
+
+		return tx.First(&page, "id = ?", pageID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// RestoreElement takes an element out of the trash; its page must not be in the trash
+func (s *TrashService) RestoreElement(boardID, elementID uuid.UUID) (*models.Element, error) {
+	var element models.Element
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureBoardActive(tx, boardID); err != nil {
+			return err
+		}
+
+		var before models.Element
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", elementID).
+			Where("page_id IN (?)", tx.Unscoped().Model(&models.Page{}).Select("id").Where("board_id = ?", boardID)).
+			First(&before).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return utils.ErrNotFound
+			}
+			return fmt.Errorf("failed to get trashed element: %w", err)
+		}
+
+		var pageCount int64
+		if err := tx.Model(&models.Page{}).Where("id = ?", before.PageID).Count(&pageCount).Error; err != nil {
+			return fmt.Errorf("failed to check page: %w", err)
+		}
+		if pageCount == 0 {
+			return ErrParentTrashed
+		}
+
+		err = tx.Unscoped().Model(&models.Element{}).Where("id = ?", elementID).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to restore element: %w", err)
+		}
+
+		if err := tx.First(&element, "id = ?", elementID).Error; err != nil {
+			return fmt.Errorf("failed to reload element: %w", err)
+		}
+
+		return recordElementRevision(tx, models.ElementActionRestore, nil, &element)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &element, nil
+}
+
+// ensureBoardActive returns ErrParentTrashed when the board is in the trash
+func ensureBoardActive(tx *gorm.DB, boardID uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&models.Board{}).Where("id = ?", boardID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check board: %w", err)
+	}
+	if count == 0 {
+		return ErrParentTrashed
+	}
+	return nil
+}
+
+// Purge permanently removes everything that has been in the trash longer than the retention window.
+// Purging a board or page also removes its pages, elements and history through cascading deletes.
+// A purged board's upload directory is kept while other boards still reference its files.
+func (s *TrashService) Purge() (PurgeResult, error) {
+	var result PurgeResult
+	cutoff := s.now().Add(-s.retention)
+
+	var boardIDs []uuid.UUID
+	err := s.db.Unscoped().Model(&models.Board{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("id", &boardIDs).Error
+	if err != nil {
+		return result, fmt.Errorf("failed to find expired boards: %w", err)
+	}
+
+	if len(boardIDs) > 0 {
+		// Upload directories of other boards these boards share, which may be left unreferenced
+		var sharedIDs []uuid.UUID
+		err := s.db.Model(&models.BoardUploadReference{}).
+			Where("board_id IN ?", boardIDs).
+			Distinct().Pluck("upload_board_id", &sharedIDs).Error
+		if err != nil {
+			return result, fmt.Errorf("failed to find shared uploads: %w", err)
+		}
+
+		deleted := s.db.Unscoped().Where("id IN ?", boardIDs).Delete(&models.Board{})
+		if deleted.Error != nil {
+			return result, fmt.Errorf("failed to purge boards: %w", deleted.Error)
+		}
+		result.Boards = deleted.RowsAffected
+
+		err = s.db.Where("board_id IN ?", boardIDs).Delete(&models.BoardUploadReference{}).Error
+		if err != nil {
+			return result, fmt.Errorf("failed to purge upload references: %w", err)
+		}
+
+		for _, uploadBoardID := range append(boardIDs, sharedIDs...) {
+			if err := s.removeUnreferencedUploads(uploadBoardID); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	deleted := s.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Delete(&models.Page{})
+	if deleted.Error != nil {
+		return result, fmt.Errorf("failed to purge pages: %w", deleted.Error)
+	}
+	result.Pages = deleted.RowsAffected
+
+	deleted = s.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Delete(&models.Element{})
+	if deleted.Error != nil {
+		return result, fmt.Errorf("failed to purge elements: %w", deleted.Error)
+	}
+	result.Elements = deleted.RowsAffected
+
+	return result, nil
+}
+
+// removeUnreferencedUploads removes a board's upload directory once the board is gone and no
+// other board references its files
+func (s *TrashService) removeUnreferencedUploads(boardID uuid.UUID) error {
+	var count int64
+	if err := s.db.Unscoped().Model(&models.Board{}).Where("id = ?", boardID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check board: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	err := s.db.Model(&models.BoardUploadReference{}).Where("upload_board_id = ?", boardID).Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check references to board uploads: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	os.RemoveAll(s.uploadService.GetBoardUploadDir(boardID))
+	return nil
+}
+
+// StartPurger periodically purges expired trash until the returned stop function is called.
+// Purging is idempotent, so it is safe to run on every instance.
+func (s *TrashService) StartPurger(logger *utils.Logger) func() {
+	ticker := time.NewTicker(defaultTrashPurgeInterval)
+	stop := make(chan struct{})
+
+	purge := func() {
+		result, err := s.Purge()
+		if err != nil {
+			logger.Errorw("Failed to purge trash", "error", err)
+			return
+		}
+		if result.Boards+result.Pages+result.Elements > 0 {
+			logger.Infow("Purged expired trash", "boards", result.Boards, "pages", result.Pages, "elements", result.Elements)
+		}
+	}
+
+	go func() {
+		defer ticker.Stop()
+		purge()
+		for {
+			select {
+			case <-ticker.C:
+				purge()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stop) })
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"junk-journal-board/internal/models"
+)
+
+func TestTrashRetention(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		env      string
+		expected time.Time
+	}{
+		{
+			name:     "Default retention is 30 days",
+			env:      "",
+			expected: deletedAt.AddDate(0, 0, 30),
+		},
+		{
+			name:     "Retention is read from the environment",
+			env:      "7",
+			expected: deletedAt.AddDate(0, 0, 7),
+		},
+		{
+			name:     "Invalid retention falls back to the default",
+			env:      "-3",
+			expected: deletedAt.AddDate(0, 0, 30),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRASH_RETENTION_DAYS", tt.env)
+
+			service := NewTrashService(nil)
+			if result := service.PurgeAt(deletedAt); !result.Equal(tt.expected) {
+				t.Errorf("Expected purge at %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPurgeKeepsSharedUploads(t *testing.T) {
+	useTempWorkDir(t)
+
+	db := newTestDB(t, &models.Board{}, &models.Page{}, &models.Element{}, &models.BoardUploadReference{})
+	uploadService := NewUploadService()
+	service := &TrashService{
+		db:            db,
+		uploadService: uploadService,
+		retention:     time.Hour,
+		now:           func() time.Time { return time.Now().Add(2 * time.Hour) },
+	}
+
+	source := newBoardWithUpload(t, db)
+	sourceDir := uploadService.GetBoardUploadDir(source.ID)
+
+	clone, err := NewBoardService(db).CloneBoard(source.ID, nil, false)
+	if err != nil {
+		t.Fatalf("Failed to clone board: %v", err)
+	}
+
+	purge := func(board *models.Board) {
+		t.Helper()
+		if err := db.Delete(board).Error; err != nil {
+			t.Fatalf("Failed to trash board: %v", err)
+		}
+		if _, err := service.Purge(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	purge(source)
+	if _, err := os.Stat(sourceDir); err != nil {
+		t.Errorf("Expected the uploads the clone references to be kept, got %v", err)
+	}
+
+	purge(clone)
+	if _, err := os.Stat(sourceDir); !os.IsNotExist(err) {
+		t.Errorf("Expected the unreferenced uploads to be removed, got %v", err)
+	}
+}
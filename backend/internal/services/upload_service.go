@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +14,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// MaxUploadFileSize is the largest accepted upload (10MB in bytes)
+const MaxUploadFileSize = 10 * 1024 * 1024
+
 type UploadService struct{}
 
 func NewUploadService() *UploadService {
@@ -21,14 +25,18 @@ func NewUploadService() *UploadService {
 
 // ValidateFile validates the uploaded file type and size
 func (s *UploadService) ValidateFile(fileHeader *multipart.FileHeader) error {
+	return s.ValidateFileInfo(fileHeader.Filename, fileHeader.Size)
+}
+
+// ValidateFileInfo validates a file name and size against the upload rules
+func (s *UploadService) ValidateFileInfo(name string, size int64) error {
 	// Check file size (10MB limit)
-	const maxFileSize = 10 * 1024 * 1024 // 10MB in bytes
-	if fileHeader.Size > maxFileSize {
+	if size > MaxUploadFileSize {
 		return utils.NewValidationError("File size exceeds 10MB limit")
 	}
 
 	// Check file type by extension
-	filename := strings.ToLower(fileHeader.Filename)
+	filename := strings.ToLower(name)
 	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".gif"}
 
 	isValidExtension := false
@@ -48,24 +56,29 @@ func (s *UploadService) ValidateFile(fileHeader *multipart.FileHeader) error {
 
 // SaveFile saves the uploaded file to the organized directory structure
 func (s *UploadService) SaveFile(fileHeader *multipart.FileHeader, boardID uuid.UUID) (string, error) {
+	// Open the uploaded file
+	src, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	return s.SaveReader(src, fileHeader.Filename, boardID)
+}
+
+// SaveReader saves file contents from a reader under the board's upload directory
+func (s *UploadService) SaveReader(src io.Reader, originalName string, boardID uuid.UUID) (string, error) {
 	// Create directory structure: uploads/boards/{boardID}/
-	uploadDir := filepath.Join("uploads", "boards", boardID.String())
+	uploadDir := s.GetBoardUploadDir(boardID)
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create upload directory: %w", err)
 	}
 
 	// Generate unique filename with UUID
-	fileExt := filepath.Ext(fileHeader.Filename)
+	fileExt := filepath.Ext(originalName)
 	fileName := fmt.Sprintf("%s%s", uuid.New().String(), fileExt)
 	filePath := filepath.Join(uploadDir, fileName)
 
-	// Open the uploaded file
-	src, err := fileHeader.Open()
-	if err != nil {
-		return "", fmt.Errorf("failed to open uploaded file: %w", err)
-	}
-	defer src.Close()
-
 	// Create the destination file
 	dst, err := os.Create(filePath)
 	if err != nil {
@@ -78,13 +91,57 @@ func (s *UploadService) SaveFile(fileHeader *multipart.FileHeader, boardID uuid.
 		return "", fmt.Errorf("failed to save file: %w", err)
 	}
 
-	// Return the full public URL with backend host
+	return s.GetPublicURL(boardID, fileName), nil
+}
+
+// GetBoardUploadDir returns the directory holding a board's uploaded files
+func (s *UploadService) GetBoardUploadDir(boardID uuid.UUID) string {
+	return filepath.Join("uploads", "boards", boardID.String())
+}
+
+// GetPublicURL returns the full public URL of a board upload with backend host
+func (s *UploadService) GetPublicURL(boardID uuid.UUID, fileName string) string {
 	backendURL := os.Getenv("BACKEND_URL")
 	if backendURL == "" {
 		backendURL = "http://localhost:8080" // Default backend URL
 	}
-	publicURL := fmt.Sprintf("%s/uploads/boards/%s/%s", backendURL, boardID.String(), fileName)
-	return publicURL, nil
+	return fmt.Sprintf("%s/uploads/boards/%s/%s", backendURL, boardID.String(), fileName)
+}
+
+// ResolveLocalPath converts a public upload URL into a file system path,
+// returning false when the URL does not point at this server's uploads
+func (s *UploadService) ResolveLocalPath(publicURL string) (string, bool) {
+	parsed, err := url.Parse(publicURL)
+	if err != nil || !strings.HasPrefix(parsed.Path, "/uploads/") {
+		return "", false
+	}
+
+	// Clean the path so it cannot escape the uploads directory
+	cleaned := filepath.Clean(parsed.Path)
+	if !strings.HasPrefix(cleaned, "/uploads/") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(cleaned, "/"), true
+}
+
+// ResolveBoardID returns the board whose upload directory a public upload URL points into
+func (s *UploadService) ResolveBoardID(publicURL string) (uuid.UUID, bool) {
+	localPath, ok := s.ResolveLocalPath(publicURL)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	boardDir := filepath.Dir(localPath)
+	if filepath.Dir(boardDir) != filepath.Join("uploads", "boards") {
+		return uuid.Nil, false
+	}
+	boardID, err := uuid.Parse(filepath.Base(boardDir))
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return boardID, true
 }
 
 // DeleteFile removes a file from the storage
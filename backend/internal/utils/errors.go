@@ -3,7 +3,10 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -14,6 +17,8 @@ var (
 	ErrNotFound     = errors.New("resource not found")
 	ErrUnauthorized = errors.New("unauthorized access")
 	ErrForbidden    = errors.New("forbidden access")
+	ErrConflict     = errors.New("resource version conflict")
+	ErrLinkExpired  = errors.New("link expired")
 )
 
 // Global validator instance
@@ -34,6 +39,12 @@ const (
 	ErrCodeInternalError   ErrorCode = "INTERNAL_ERROR"
 	ErrCodeValidationError ErrorCode = "VALIDATION_ERROR"
 	ErrCodeDatabaseError   ErrorCode = "DATABASE_ERROR"
+	ErrCodeConflict        ErrorCode = "CONFLICT"
+
+	ErrCodePassphraseRequired ErrorCode = "PASSPHRASE_REQUIRED"
+	ErrCodeTooManyRequests    ErrorCode = "TOO_MANY_REQUESTS"
+	ErrCodeLinkExpired        ErrorCode = "LINK_EXPIRED"
+	ErrCodeViewRequired       ErrorCode = "VIEW_REQUIRED"
 )
 
 // ErrorResponse represents the standardized error response format
@@ -86,6 +97,21 @@ func SendForbidden(c *fiber.Ctx, message string) error {
 	return SendError(c, fiber.StatusForbidden, ErrCodeForbidden, message, nil)
 }
 
+// SendConflict sends a 409 Conflict error, details carry the current server copy
+func SendConflict(c *fiber.Ctx, message string, details interface{}) error {
+	return SendError(c, fiber.StatusConflict, ErrCodeConflict, message, details)
+}
+
+// SendTooManyRequests sends a 429 Too Many Requests error telling the client when to retry
+func SendTooManyRequests(c *fiber.Ctx, message string, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return SendError(c, fiber.StatusTooManyRequests, ErrCodeTooManyRequests, message, nil)
+}
+
 // SendInternalError sends a 500 Internal Server Error
 func SendInternalError(c *fiber.Ctx, message string, details interface{}) error {
 	return SendError(c, fiber.StatusInternalServerError, ErrCodeInternalError, message, details)
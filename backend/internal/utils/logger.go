@@ -63,16 +63,24 @@ func (l *Logger) LogError(err error, message string, fields ...zap.Field) {
 	l.Error(message, allFields...)
 }
 
-// LogRequest logs an HTTP request with standard fields
-func (l *Logger) LogRequest(method, path, userAgent, ip string, statusCode int, duration int64) {
-	l.Info("HTTP Request",
+// LogRequest logs an HTTP request with standard fields. Callers are expected to have
+// redacted any tokens from the path and query.
+func (l *Logger) LogRequest(method, path, query, userAgent, ip string, statusCode int, duration int64) {
+	fields := []zap.Field{
 		zap.String("method", method),
 		zap.String("path", path),
+	}
+	if query != "" {
+		fields = append(fields, zap.String("query", query))
+	}
+	fields = append(fields,
 		zap.String("user_agent", userAgent),
 		zap.String("ip", ip),
 		zap.Int("status_code", statusCode),
 		zap.Int64("duration_ms", duration),
 	)
+
+	l.Info("HTTP Request", fields...)
 }
 
 // LogDatabaseOperation logs database operations
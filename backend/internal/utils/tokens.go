@@ -1,9 +1,21 @@
 package utils
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+
 	"github.com/google/uuid"
 )
 
+// developmentTokenHashSecret keys token hashes in development when TOKEN_HASH_SECRET is unset
+const developmentTokenHashSecret = "junk-journal-development-token-hash-secret"
+
+// minTokenHashSecretLength is the shortest TOKEN_HASH_SECRET accepted
+const minTokenHashSecretLength = 32
+
 // GenerateUUID generates a new UUID v4
 func GenerateUUID() uuid.UUID {
 	return uuid.New()
@@ -33,3 +45,39 @@ func ValidateToken(token string) (uuid.UUID, bool) {
 
 	return parsedUUID, true
 }
+
+// HashToken returns the keyed hash (HMAC-SHA256 with TOKEN_HASH_SECRET) stored in place of a token
+func HashToken(token uuid.UUID) string {
+	return SignValue(token.String())
+}
+
+// SignValue returns the HMAC-SHA256 of a value keyed with TOKEN_HASH_SECRET, hex encoded
+func SignValue(value string) string {
+	mac := hmac.New(sha256.New, tokenHashSecret())
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CheckTokenHashSecret verifies that a token hash secret is configured. Development falls back to
+// a fixed secret. Changing the secret later invalidates every stored edit token.
+func CheckTokenHashSecret() error {
+	secret := os.Getenv("TOKEN_HASH_SECRET")
+	if secret == "" {
+		if os.Getenv("GO_ENV") == "development" {
+			return nil
+		}
+		return errors.New("TOKEN_HASH_SECRET is required")
+	}
+	if len(secret) < minTokenHashSecretLength {
+		return errors.New("TOKEN_HASH_SECRET must be at least 32 characters")
+	}
+	return nil
+}
+
+// tokenHashSecret returns the secret keying token hashes
+func tokenHashSecret() []byte {
+	if secret := os.Getenv("TOKEN_HASH_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(developmentTokenHashSecret)
+}
@@ -3,10 +3,12 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
 
 	"junk-journal-board/internal/config"
 	"junk-journal-board/internal/middleware"
 	"junk-journal-board/internal/routes"
+	"junk-journal-board/internal/services"
 	"junk-journal-board/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -29,6 +31,11 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// Edit tokens are stored as keyed hashes, so the secret must be set and kept stable
+	if err := utils.CheckTokenHashSecret(); err != nil {
+		logger.Fatal("Invalid token hash configuration", zap.Error(err))
+	}
+
 	// Connect to database
 	db, err := config.ConnectDatabase()
 	if err != nil {
@@ -40,9 +47,26 @@ func main() {
 		logger.Fatal("Failed to initialize database", zap.Error(err))
 	}
 
-	// Create Fiber app with custom error handler
+	// Configure rate limiting before serving any request
+	rateLimiter, err := services.NewRateLimiterFromEnv(db)
+	if err != nil {
+		logger.Fatal("Invalid rate limit configuration", zap.Error(err))
+	}
+
+	// Create Fiber app with custom error handler. Behind a reverse proxy, PROXY_HEADER names the
+	// header carrying the client IP used for rate limiting. It is only honored on requests from
+	// the TRUSTED_PROXIES, so clients cannot pick the IP they are rate limited by.
+	proxyHeader := os.Getenv("PROXY_HEADER")
+	trustedProxies := trustedProxiesFromEnv()
+	if proxyHeader != "" && len(trustedProxies) == 0 {
+		logger.Warn("PROXY_HEADER is ignored because TRUSTED_PROXIES is not set")
+	}
+
 	app := fiber.New(fiber.Config{
-		ErrorHandler: middleware.ErrorHandler(logger),
+		ErrorHandler:            middleware.ErrorHandler(logger),
+		ProxyHeader:             proxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
 	})
 
 	// Core middleware
@@ -54,9 +78,10 @@ func main() {
 	}))
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization,X-Request-ID",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,DELETE,OPTIONS",
+		AllowHeaders:  "Origin,Content-Type,Accept,Authorization,X-Request-ID,X-Live-Client-ID,X-Session-Token,X-Board-Token,X-Board-Unlock,X-Board-View,If-Match",
+		ExposeHeaders: "ETag,Deprecation,Sunset,X-Board-View,Retry-After,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset",
 	}))
 
 	app.Use(middleware.LoggingMiddleware(logger))
@@ -79,20 +104,93 @@ func main() {
 		return c.JSON(fiber.Map{"status": "ok", "database": "connected"})
 	})
 
+	// Shared hub for real-time board updates
+	liveHub := services.NewLiveHub()
+
+	// Presence roster for live sessions, idle sessions are expired in the background
+	presenceService := services.NewPresenceService(liveHub)
+	stopPresenceJanitor := presenceService.StartJanitor()
+	defer stopPresenceJanitor()
+
+	// Expired trash is purged in the background
+	stopTrashPurger := services.NewTrashService(db).StartPurger(logger)
+	defer stopTrashPurger()
+
+	// Recurring pages are created in the background
+	stopRecurrenceScheduler := services.NewRecurrenceService(db).StartScheduler(logger)
+	defer stopRecurrenceScheduler()
+
+	// Expired rate limit windows are pruned in the background
+	if rateLimiter != nil {
+		stopRateLimitJanitor := rateLimiter.StartJanitor(logger)
+		defer stopRateLimitJanitor()
+	}
+
 	// API routes
 	api := app.Group("/api/v1")
 
 	// Add optional token middleware to API routes for token extraction
 	api.Use(middleware.OptionalTokenMiddleware())
 
+	// Throttle API requests per client IP and per edit token, and failed token lookups per client IP
+	api.Use(middleware.RateLimitMiddleware(rateLimiter, services.NewBoardService(db).IsEditToken))
+
+	// Flag requests made with retired board tokens that are still in their grace period
+	api.Use(middleware.TokenDeprecationMiddleware(services.NewTokenRotationService(db).GracePeriodEnd))
+
+	// Resolve the optional account session of signed-in requests
+	api.Use(middleware.OptionalSessionMiddleware(services.NewAccountService(db).AuthenticateSession))
+
 	// Setup board routes
 	routes.SetupBoardRoutes(api, db)
 
 	// Setup page routes
-	routes.SetupPageRoutes(api, db)
+	routes.SetupPageRoutes(api, db, liveHub)
 
 	// Setup element routes
-	routes.SetupElementRoutes(api, db)
+	routes.SetupElementRoutes(api, db, liveHub)
+
+	// Setup history routes
+	routes.SetupHistoryRoutes(api, db, liveHub)
+
+	// Setup trash routes
+	routes.SetupTrashRoutes(api, db, liveHub)
+
+	// Setup snapshot routes
+	routes.SetupSnapshotRoutes(api, db, liveHub)
+
+	// Setup template routes
+	routes.SetupTemplateRoutes(api, db)
+
+	// Setup board template routes
+	routes.SetupBoardTemplateRoutes(api, db)
+
+	// Setup recurrence routes
+	routes.SetupRecurrenceRoutes(api, db)
+
+	// Setup tag routes
+	routes.SetupTagRoutes(api, db)
+
+	// Setup search routes
+	routes.SetupSearchRoutes(api, db)
+
+	// Setup share link routes
+	routes.SetupShareLinkRoutes(api, db, liveHub)
+
+	// Setup token rotation routes
+	routes.SetupTokenRotationRoutes(api, db, liveHub)
+
+	// Setup public link routes
+	routes.SetupPublicLinkRoutes(api, db)
+
+	// Setup board unlock routes
+	routes.SetupBoardUnlockRoutes(api, db, rateLimiter)
+
+	// Setup account routes
+	routes.SetupAccountRoutes(api, db)
+
+	// Setup keyring routes
+	routes.SetupKeyringRoutes(api, db)
 
 	// Setup upload routes
 	routes.SetupUploadRoutes(api, db)
@@ -100,6 +198,18 @@ func main() {
 	// Setup recap routes
 	routes.SetupRecapRoutes(api, db)
 
+	// Setup render routes
+	routes.SetupRenderRoutes(api, db)
+
+	// Setup archive routes
+	routes.SetupArchiveRoutes(api, db)
+
+	// Setup live routes
+	routes.SetupLiveRoutes(api, db, liveHub, presenceService)
+
+	// Setup presence routes
+	routes.SetupPresenceRoutes(api, db, presenceService)
+
 	// Static file serving for uploads
 	app.Static("/uploads", "./uploads")
 
@@ -112,3 +222,14 @@ func main() {
 	logger.Info("Server starting", zap.String("port", port))
 	log.Fatal(app.Listen(":" + port))
 }
+
+// trustedProxiesFromEnv reads the comma-separated IPs and CIDR ranges of TRUSTED_PROXIES
+func trustedProxiesFromEnv() []string {
+	proxies := make([]string, 0)
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
@@ -1,5 +1,6 @@
-import apiClient from './client'
+import apiClient, { BOARD_TOKEN_HEADER } from './client'
 import type { Board, ApiResponse } from '@/types'
+import { getOwnedEditTokens, rememberEditToken, forgetEditToken } from '@/utils/tokens'
 
 export interface CreateBoardRequest {
   title: string
@@ -24,6 +25,7 @@ export const boardsApi = {
     if (response.data.error) {
       throw response.data
     }
+    rememberEditToken(response.data.data!.board.edit_token)
     return response.data.data!
   },
 
@@ -33,6 +35,7 @@ export const boardsApi = {
     if (response.data.error) {
       throw response.data
     }
+    rememberEditToken(editToken)
     return response.data.data!
   },
 
@@ -54,9 +57,13 @@ export const boardsApi = {
     return response.data.data!
   },
 
-  // Get all boards (for overview)
+  // Get the boards owned by this browser (for overview)
   async getAll(): Promise<Board[]> {
-    const response = await apiClient.get<ApiResponse<Board[]>>('/boards')
+    const editTokens = getOwnedEditTokens()
+    if (editTokens.length === 0) {
+      return []
+    }
+    const response = await apiClient.post<ApiResponse<Board[]>>('/boards/directory', { edit_tokens: editTokens })
     if (response.data.error) {
       throw response.data
     }
@@ -65,10 +72,13 @@ export const boardsApi = {
 
   // Delete board
   async delete(boardId: string, editToken: string): Promise<void> {
-    const response = await apiClient.delete<ApiResponse<void>>(`/boards/${boardId}?edit_token=${editToken}`)
+    const response = await apiClient.delete<ApiResponse<void>>(`/boards/${boardId}`, {
+      headers: { [BOARD_TOKEN_HEADER]: editToken },
+    })
     if (response.data.error) {
       throw response.data
     }
+    forgetEditToken(editToken)
   },
 
   // Delete all boards
@@ -18,6 +18,13 @@ const apiClient: AxiosInstance = axios.create({
   },
 })
 
+// Header carrying the board token, so it stays out of request URLs and server logs
+export const BOARD_TOKEN_HEADER = 'X-Board-Token'
+
+// Header carrying the view grant of a view-limited public link, handed out when the board is opened
+export const BOARD_VIEW_HEADER = 'X-Board-View'
+let boardViewGrant: string | null = null
+
 // Request interceptor to add edit token if available
 apiClient.interceptors.request.use(
   (config) => {
@@ -32,10 +39,13 @@ apiClient.interceptors.request.use(
       editToken = getStoredEditToken()
     }
     
-    if (editToken && config.params) {
-      config.params.edit_token = editToken
-    } else if (editToken) {
-      config.params = { edit_token: editToken }
+    // Requests that already name their token keep it
+    if (editToken && !config.headers.has(BOARD_TOKEN_HEADER)) {
+      config.headers.set(BOARD_TOKEN_HEADER, editToken)
+    }
+
+    if (boardViewGrant && !config.headers.has(BOARD_VIEW_HEADER)) {
+      config.headers.set(BOARD_VIEW_HEADER, boardViewGrant)
     }
     
     return config
@@ -50,6 +60,10 @@ apiClient.interceptors.request.use(
 apiClient.interceptors.response.use(
   (response: AxiosResponse) => {
     console.log('API Response:', response.status, response.config.url, response.data)
+    const viewGrant = response.headers[BOARD_VIEW_HEADER.toLowerCase()]
+    if (typeof viewGrant === 'string' && viewGrant) {
+      boardViewGrant = viewGrant
+    }
     return response
   },
   (error: AxiosError) => {
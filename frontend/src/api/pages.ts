@@ -23,14 +23,26 @@ export const pagesApi = {
     return response.data.data!
   },
 
-  // Get all pages for a board
+  // Get all pages for a board, following the list cursor until the last page
   async list(boardId: string): Promise<Page[]> {
-    const response = await apiClient.get<ApiResponse<{ pages: Page[], total: number }>>(`/boards/${boardId}/pages`)
-    if (response.data.error) {
-      throw response.data
-    }
-    // Extract pages from the new response structure
-    return response.data.data!.pages
+    const pages: Page[] = []
+    let cursor: string | null = null
+    do {
+      const params: Record<string, string | number> = { limit: 200 }
+      if (cursor) {
+        params.cursor = cursor
+      }
+      const response = await apiClient.get<ApiResponse<{ pages: Page[], total: number, next_cursor: string | null }>>(
+        `/boards/${boardId}/pages`,
+        { params }
+      )
+      if (response.data.error) {
+        throw response.data
+      }
+      pages.push(...response.data.data!.pages)
+      cursor = response.data.data!.next_cursor
+    } while (cursor)
+    return pages
   },
 
   // Get a specific page
@@ -74,9 +74,15 @@ export const getFriendlyErrorMessage = (error: any): string => {
       return 'Access denied. Please check your access token'
     case 'NOT_FOUND':
       return 'The requested resource was not found'
+    case 'VIEW_REQUIRED':
+      return 'Please open this board through its public link'
+    case 'LINK_EXPIRED':
+      return 'This link has expired or reached its view limit'
     case 'VALIDATION_ERROR':
     case 'BAD_REQUEST':
       return error.error.message || 'Invalid input data'
+    case 'TOO_MANY_REQUESTS':
+      return 'Too many requests. Please wait a moment and try again'
     case 'TIMEOUT':
       return 'Request timed out. Please try again'
     case 'NETWORK_ERROR':
@@ -91,4 +91,35 @@ export const copyUrlToClipboard = async (url: string): Promise<void> => {
     document.execCommand('copy')
     document.body.removeChild(textArea)
   }
-}
\ No newline at end of file
+}
+const OWNED_EDIT_TOKENS_KEY = 'owned_edit_tokens'
+
+/**
+ * Edit tokens of the boards this browser has created or opened for editing
+ */
+export const getOwnedEditTokens = (): string[] => {
+  try {
+    const stored = JSON.parse(localStorage.getItem(OWNED_EDIT_TOKENS_KEY) || '[]')
+    return Array.isArray(stored) ? stored : []
+  } catch {
+    return []
+  }
+}
+
+/**
+ * Remember an edit token so its board shows up in the board overview
+ */
+export const rememberEditToken = (editToken: string): void => {
+  const tokens = getOwnedEditTokens()
+  if (!tokens.includes(editToken)) {
+    localStorage.setItem(OWNED_EDIT_TOKENS_KEY, JSON.stringify([...tokens, editToken]))
+  }
+}
+
+/**
+ * Forget an edit token, e.g. after its board was deleted
+ */
+export const forgetEditToken = (editToken: string): void => {
+  const tokens = getOwnedEditTokens().filter(token => token !== editToken)
+  localStorage.setItem(OWNED_EDIT_TOKENS_KEY, JSON.stringify(tokens))
+}